@@ -0,0 +1,122 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// availabilityResolution is the time span of an availability bucket.
+	availabilityResolution = time.Minute
+
+	// availabilityBuckets is the number of buckets to cover the longest
+	// availability window, that is 24 hours.
+	availabilityBuckets = int(24 * time.Hour / availabilityResolution)
+)
+
+// Availability struct holds the rolling success ratio of the reporter's
+// checks in percent, for e.g. to report the SLO compliance of the
+// dependency. Window without any check is 100.
+type Availability struct {
+	LastHour float64 `json:"1h" xml:"lastHour,attr"`
+	LastDay  float64 `json:"24h" xml:"lastDay,attr"`
+}
+
+// Availability method returns the rolling availability of given reporter,
+// it returns false if the reporter does not exist or not checked yet.
+// Checks skipped for the unhealthy dependency or run within the maintenance
+// window are not counted.
+func (c *Collector) Availability(name string) (Availability, bool) {
+	now := c.clock.Now()
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if r, found := c.availability[name]; found {
+		return r.availability(now), true
+	}
+	return Availability{}, false
+}
+
+// recordAvailability method counts the result into reporter's availability
+// buckets. Caller must hold the lock.
+func (c *Collector) recordAvailability(name string, result *CheckResult) {
+	if result.Skipped || result.Maintenance {
+		return
+	}
+	r, found := c.availability[name]
+	if !found {
+		r = &availabilityRing{buckets: make([]availabilityBucket, availabilityBuckets)}
+		c.availability[name] = r
+	}
+	r.add(result.LastChecked, result.IsOK())
+}
+
+// withAvailability method returns the copy of given results along with the
+// reporter's availability.
+func (c *Collector) withAvailability(results map[string]CheckResult) map[string]CheckResult {
+	now := c.clock.Now()
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	marked := make(map[string]CheckResult, len(results))
+	for name, result := range results {
+		if r, found := c.availability[name]; found {
+			a := r.availability(now)
+			result.Availability = &a
+		}
+		marked[name] = result
+	}
+	return marked
+}
+
+// availabilityBucket struct holds the check counts of a time span.
+type availabilityBucket struct {
+	slot  int64
+	ok    uint32
+	total uint32
+}
+
+// availabilityRing struct holds the check counts of last 24 hours in the
+// buckets of `availabilityResolution`.
+type availabilityRing struct {
+	buckets []availabilityBucket
+}
+
+func (r *availabilityRing) add(t time.Time, ok bool) {
+	slot := t.UnixNano() / int64(availabilityResolution)
+	b := &r.buckets[slot%int64(len(r.buckets))]
+	if b.slot != slot {
+		*b = availabilityBucket{slot: slot}
+	}
+	b.total++
+	if ok {
+		b.ok++
+	}
+}
+
+func (r *availabilityRing) availability(now time.Time) Availability {
+	return Availability{
+		LastHour: r.ratio(now, time.Hour),
+		LastDay:  r.ratio(now, 24*time.Hour),
+	}
+}
+
+// ratio method returns the success percent of the checks within given
+// window ending now, rounded to three decimals.
+func (r *availabilityRing) ratio(now time.Time, window time.Duration) float64 {
+	last := now.UnixNano() / int64(availabilityResolution)
+	first := last - int64(window/availabilityResolution) + 1
+	var ok, total uint64
+	for _, b := range r.buckets {
+		if b.total > 0 && b.slot >= first && b.slot <= last {
+			ok += uint64(b.ok)
+			total += uint64(b.total)
+		}
+	}
+	if total == 0 {
+		return 100
+	}
+	return math.Round(float64(ok)/float64(total)*100000) / 1000
+}
@@ -0,0 +1,75 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthAvailabilityRing(t *testing.T) {
+	now := time.Date(2019, 3, 1, 10, 0, 0, 0, time.UTC)
+	r := &availabilityRing{buckets: make([]availabilityBucket, availabilityBuckets)}
+	assert.Equal(t, Availability{LastHour: 100, LastDay: 100}, r.availability(now))
+
+	// failures outside of the last hour
+	r.add(now.Add(-3*time.Hour), false)
+	r.add(now.Add(-2*time.Hour), true)
+	for i := 0; i < 7; i++ {
+		r.add(now.Add(-time.Duration(i)*time.Minute), true)
+	}
+	r.add(now.Add(-30*time.Minute), false)
+	assert.Equal(t, Availability{LastHour: 87.5, LastDay: 80}, r.availability(now))
+
+	// expired buckets are not counted, reused bucket drops its old counts
+	assert.Equal(t, Availability{LastHour: 100, LastDay: 100}, r.availability(now.Add(25*time.Hour)))
+	r.add(now.Add(24*time.Hour), false)
+	assert.Equal(t, 0.0, r.availability(now.Add(24*time.Hour)).LastHour)
+	assert.Equal(t, 85.714, r.availability(now.Add(23*time.Hour)).LastDay)
+}
+
+func TestHealthAvailability(t *testing.T) {
+	now := time.Date(2019, 3, 1, 10, 0, 0, 0, time.UTC)
+	collector := newCollector()
+	db := &toggleReporter{}
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: db})
+	_, found := collector.Availability("db")
+	assert.False(t, found)
+
+	for i := 0; i < 4; i++ {
+		if i == 3 {
+			db.set(errors.New("down"))
+		}
+		collector.clock = fixedClock(now.Add(time.Duration(i) * time.Minute))
+		collector.runChecks()
+	}
+	a, found := collector.Availability("db")
+	assert.True(t, found)
+	assert.Equal(t, Availability{LastHour: 75, LastDay: 75}, a)
+
+	collector.clock = fixedClock(now.Add(2 * time.Hour))
+	a, _ = collector.Availability("db")
+	assert.Equal(t, Availability{LastHour: 100, LastDay: 75}, a)
+
+	h, _ := collector.HandlerWithOptions(RegisterOptions{})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthcheck", nil))
+	assert.Contains(t, w.Body.String(), `"availability":{"1h":100,"24h":75}`)
+
+	buf := new(bytes.Buffer)
+	assert.Nil(t, collector.WriteMetrics(buf))
+	assert.Contains(t, buf.String(), `health_check_availability{name="db",window="1h"} 100`)
+	assert.Contains(t, buf.String(), `health_check_availability{name="db",window="24h"} 75`)
+
+	_ = collector.RemoveReporter("db")
+	_, found = collector.Availability("db")
+	assert.False(t, found)
+}
@@ -0,0 +1,57 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"fmt"
+	"html"
+	"unicode/utf8"
+)
+
+// badgeColors are the badge colors of the aggregate status, same as
+// shields.io.
+var badgeColors = map[AggregateStatus]string{
+	Healthy:   "#4c1",
+	Degraded:  "#dfb317",
+	Unhealthy: "#e05d44",
+	Starting:  "#007ec6",
+}
+
+// badgeSVG returns the shields.io flat style SVG badge of given aggregate
+// status, label defaults to `health`.
+func badgeSVG(label string, status AggregateStatus) []byte {
+	if label == "" {
+		label = "health"
+	}
+	if utf8.RuneCountInString(label) > 32 {
+		label = string([]rune(label)[:32])
+	}
+	message := string(status)
+	lw, mw := badgeTextWidth(label), badgeTextWidth(message)
+	width := lw + mw
+	label, message = html.EscapeString(label), html.EscapeString(message)
+	return []byte(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">`+
+		`<title>%s: %s</title>`+
+		`<linearGradient id="s" x2="0" y2="100%%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>`+
+		`<clipPath id="r"><rect width="%d" height="20" rx="3" fill="#fff"/></clipPath>`+
+		`<g clip-path="url(#r)"><rect width="%d" height="20" fill="#555"/><rect x="%d" width="%d" height="20" fill="%s"/><rect width="%d" height="20" fill="url(#s)"/></g>`+
+		`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">`+
+		`<text x="%d" y="15" fill="#010101" fill-opacity=".3">%s</text><text x="%d" y="14">%s</text>`+
+		`<text x="%d" y="15" fill="#010101" fill-opacity=".3">%s</text><text x="%d" y="14">%s</text>`+
+		`</g></svg>`,
+		width, label, message,
+		label, message,
+		width,
+		lw, lw, mw, badgeColors[status], width,
+		lw/2, label, lw/2, label,
+		lw+mw/2, message, lw+mw/2, message,
+	))
+}
+
+// badgeTextWidth returns the approximate width of the badge text segment
+// including padding, Verdana 11px averages 7 pixels per character.
+func badgeTextWidth(s string) int {
+	return utf8.RuneCountInString(s)*7 + 10
+}
@@ -0,0 +1,57 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthBadge(t *testing.T) {
+	collector := newCollector()
+	reporter := &toggleReporter{}
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: reporter})
+	collector.runChecks()
+
+	// badge is not protected by auth
+	h, _ := collector.HandlerWithOptions(RegisterOptions{AuthToken: "secret"})
+	get := func(p string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, p, nil))
+		return w
+	}
+
+	w := get("/healthcheck/badge.svg")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, svgContentType, w.Header().Get("Content-Type"))
+	assert.Equal(t, "no-cache, no-store, must-revalidate", w.Header().Get("Cache-Control"))
+	assert.Contains(t, w.Body.String(), `aria-label="health: healthy"`)
+	assert.Contains(t, w.Body.String(), `fill="#4c1"`)
+	assert.NoError(t, xml.Unmarshal(w.Body.Bytes(), new(struct{})))
+
+	reporter.set(errors.New("down"))
+	collector.runChecks()
+	w = get("/healthcheck/badge.svg?label=<orders>")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `aria-label="&lt;orders&gt;: unhealthy"`)
+	assert.Contains(t, w.Body.String(), `fill="#e05d44"`)
+	assert.NoError(t, xml.Unmarshal(w.Body.Bytes(), new(struct{})))
+
+	h, _ = collector.HandlerWithOptions(RegisterOptions{DisableBadge: true})
+	assert.Equal(t, http.StatusNotFound, get("/healthcheck/badge.svg").Code)
+}
+
+func TestHealthBadgeSVG(t *testing.T) {
+	svg := string(badgeSVG("", Degraded))
+	assert.Contains(t, svg, `width="118"`) // 6*7+10 + 8*7+10
+	assert.Contains(t, svg, `fill="#dfb317"`)
+	assert.Contains(t, svg, `<text x="26" y="14">health</text>`)
+	assert.Contains(t, svg, `<text x="85" y="14">degraded</text>`)
+}
@@ -0,0 +1,52 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import "time"
+
+// Clock interface abstracts the time source of the collector, so that
+// the periodic checks can be driven deterministically in tests. Refer to
+// `WithClock` and package `healthtest`.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// NewTimer returns a timer which fires once after given duration.
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer interface represents a single event timer created by `Clock`,
+// it behaves same as `time.Timer`.
+type Timer interface {
+	// C returns the channel on which the time is delivered.
+	C() <-chan time.Time
+
+	// Stop prevents the timer from firing, it returns false if the timer
+	// has already expired or been stopped.
+	Stop() bool
+
+	// Reset changes the timer to expire after given duration, it returns
+	// true if the timer had been active.
+	Reset(d time.Duration) bool
+}
+
+// realClock implements `Clock` using the package `time`.
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return &realTimer{Timer: time.NewTimer(d)}
+}
+
+type realTimer struct {
+	*time.Timer
+}
+
+func (t *realTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
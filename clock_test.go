@@ -0,0 +1,299 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"aahframe.work/ec/health"
+	"aahframe.work/ec/health/healthtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// blockingReporter blocks its checks until released or the check context
+// is done, started receives on every check.
+type blockingReporter struct {
+	started chan struct{}
+	release chan struct{}
+	calls   int32
+}
+
+func newBlockingReporter() *blockingReporter {
+	return &blockingReporter{started: make(chan struct{}, 10), release: make(chan struct{})}
+}
+
+func (r *blockingReporter) Check() error {
+	return errors.New("check should not be called")
+}
+
+func (r *blockingReporter) CheckContext(ctx context.Context) error {
+	atomic.AddInt32(&r.calls, 1)
+	r.started <- struct{}{}
+	select {
+	case <-r.release:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+type okReporter struct {
+	calls int32
+}
+
+func (r *okReporter) Check() error {
+	atomic.AddInt32(&r.calls, 1)
+	return nil
+}
+
+// switchReporter fails its checks with the error once set.
+type switchReporter struct {
+	mu  sync.Mutex
+	err error
+}
+
+func (r *switchReporter) Check() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+func (r *switchReporter) set(err error) {
+	r.mu.Lock()
+	r.err = err
+	r.mu.Unlock()
+}
+
+// readSSE reads the event name and data of the next Server-Sent Event.
+func readSSE(t *testing.T, r *bufio.Reader) (string, string) {
+	var name, data string
+	for {
+		line, err := r.ReadString('\n')
+		if !assert.NoError(t, err) {
+			return name, data
+		}
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "" && name != "":
+			return name, data
+		case strings.HasPrefix(line, "event: "):
+			name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func TestHealthSimple(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer ln.Close()
+
+	clock := healthtest.NewFakeClock(time.Now())
+	collector := health.NewCollector(health.WithClock(clock))
+	defer collector.Stop()
+	err = collector.AddReporter(&health.Config{
+		Name:              "Local",
+		Reporter:          health.NewTCPReporter(ln.Addr().String(), 3*time.Second),
+		SoftFail:          true,
+		DeferInitialCheck: true,
+	})
+	assert.Nil(t, err)
+
+	// periodic timer is scheduled once the initial check is done
+	clock.BlockUntil(1)
+	clock.Advance(5 * time.Second)
+	clock.BlockUntil(1)
+
+	assert.True(t, collector.IsHealthy())
+	result := collector.Results()["Local"]
+	assert.Equal(t, health.StatusOK, result.Status)
+	assert.Empty(t, result.Error)
+
+	_ = ln.Close()
+	clock.Advance(10 * time.Second)
+	clock.BlockUntil(1)
+
+	result = collector.Results()["Local"]
+	assert.Equal(t, health.StatusKO, result.Status)
+	assert.Contains(t, result.Error, "connection refused")
+	assert.Equal(t, health.Degraded, collector.Status())
+}
+
+func TestHealthCheckCycleTimeout(t *testing.T) {
+	clock := healthtest.NewFakeClock(time.Now())
+	collector := health.NewCollector(health.WithClock(clock), health.WithCheckCycleTimeout(time.Second))
+	defer collector.Stop()
+	straggler := newBlockingReporter()
+	_ = collector.AddReporter(&health.Config{Name: "Fast", Reporter: &okReporter{}, DeferInitialCheck: true})
+	// depends on Fast, so that Fast is done before the straggler starts
+	_ = collector.AddReporter(&health.Config{Name: "Straggler", Reporter: straggler, DependsOn: []string{"Fast"},
+		DeferInitialCheck: true})
+
+	// initial delay, force check and cycle deadline timers
+	done := make(chan bool)
+	go func() { done <- collector.CheckNow(time.Minute) }()
+	<-straggler.started
+	clock.BlockUntil(3)
+	clock.Advance(time.Second)
+	assert.True(t, <-done)
+
+	results := collector.Results()
+	assert.Equal(t, health.StatusOK, results["Fast"].Status)
+	assert.Equal(t, health.StatusKO, results["Straggler"].Status)
+	assert.Equal(t, "timed out (still running)", results["Straggler"].Error)
+	assert.Equal(t, health.ErrorTypeTimeout, results["Straggler"].ErrorType)
+
+	// not checked again until the in-flight check finishes
+	assert.True(t, collector.CheckNow(time.Minute))
+	assert.Equal(t, "timed out (still running)", collector.Results()["Straggler"].Error)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&straggler.calls))
+
+	close(straggler.release)
+	assert.Eventually(t, func() bool {
+		collector.CheckNow(time.Minute)
+		return collector.Results()["Straggler"].Status == health.StatusOK
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&straggler.calls))
+}
+
+func TestHealthOverlappingCycles(t *testing.T) {
+	clock := healthtest.NewFakeClock(time.Now())
+	collector := health.NewCollector(health.WithClock(clock), health.WithInitialDelay(0),
+		health.WithInterval(10*time.Second))
+	defer collector.Stop()
+	clock.BlockUntil(1) // initial check is done
+
+	slow, db := newBlockingReporter(), &okReporter{}
+	_ = collector.AddReporter(&health.Config{Name: "Slow", Reporter: slow, DeferInitialCheck: true})
+	_ = collector.AddReporter(&health.Config{Name: "db", Reporter: db, DeferInitialCheck: true})
+
+	done := make(chan bool)
+	go func() { done <- collector.CheckNow(time.Minute) }()
+	<-slow.started
+
+	// periodic tick is skipped while the forced check cycle is running
+	clock.BlockUntil(2)
+	clock.Advance(10 * time.Second)
+	clock.BlockUntil(2)
+	close(slow.release)
+	assert.True(t, <-done)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&slow.calls))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&db.calls))
+
+	var buf bytes.Buffer
+	assert.Nil(t, collector.WriteMetrics(&buf))
+	assert.Contains(t, buf.String(), "health_check_cycles_skipped_total 1\n")
+
+	clock.Advance(10 * time.Second)
+	clock.BlockUntil(1)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&db.calls))
+}
+
+func TestHealthStop(t *testing.T) {
+	clock := healthtest.NewFakeClock(time.Now())
+	collector := health.NewCollector(health.WithClock(clock))
+	hung := newBlockingReporter()
+	err := collector.AddReporter(&health.Config{Name: "Hung", Reporter: hung, DeferInitialCheck: true})
+	assert.Nil(t, err)
+
+	done := make(chan bool)
+	go func() { done <- collector.CheckNow(time.Minute) }()
+	<-hung.started
+	collector.Stop()
+
+	// in-flight check is cancelled by Stop, its result is discarded
+	assert.True(t, <-done)
+	assert.True(t, collector.IsHealthy())
+	assert.Empty(t, collector.Results())
+}
+
+func TestHealthPauseResume(t *testing.T) {
+	clock := healthtest.NewFakeClock(time.Now())
+	collector := health.NewCollector(health.WithClock(clock))
+	defer collector.Stop()
+	reporter := &okReporter{}
+	_ = collector.AddReporter(&health.Config{Name: "db", Reporter: reporter, DeferInitialCheck: true})
+
+	clock.BlockUntil(1)
+	clock.Advance(5 * time.Second)
+	clock.BlockUntil(1)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&reporter.calls))
+
+	collector.Pause()
+	assert.True(t, collector.IsPaused())
+	clock.Advance(10 * time.Second)
+	clock.BlockUntil(1)
+	clock.Advance(10 * time.Second)
+	clock.BlockUntil(1)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&reporter.calls))
+
+	collector.Resume()
+	assert.False(t, collector.IsPaused())
+	clock.Advance(10 * time.Second)
+	clock.BlockUntil(1)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&reporter.calls))
+}
+
+func TestHealthStreamSummary(t *testing.T) {
+	clock := healthtest.NewFakeClock(time.Now())
+	collector := health.NewCollector(health.WithClock(clock))
+	defer collector.Stop()
+	db, cache := &switchReporter{}, &switchReporter{}
+	_ = collector.AddReporter(&health.Config{Name: "db", Reporter: db, DeferInitialCheck: true})
+	_ = collector.AddReporter(&health.Config{Name: "cache", Reporter: cache, Tags: []string{"external"},
+		DeferInitialCheck: true})
+	assert.True(t, collector.CheckNow(time.Minute))
+
+	h, err := collector.HandlerWithOptions(health.RegisterOptions{
+		Exposure:        health.ExposureSummary,
+		EnableStream:    true,
+		StreamHeartbeat: time.Second,
+	})
+	assert.Nil(t, err)
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	// reporter filter is ignored, only the aggregate status is sent
+	resp, err := http.Get(server.URL + "/healthcheck/stream?reporter=db&tag=external")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer resp.Body.Close()
+	r := bufio.NewReader(resp.Body)
+	name, data := readSSE(t, r)
+	assert.Equal(t, health.StreamEventAggregate, name)
+	assert.Contains(t, data, `"status":"healthy"`)
+	assert.NotContains(t, data, "reporter")
+
+	db.set(errors.New("dial tcp refused"))
+	assert.True(t, collector.CheckNow(time.Minute))
+	name, data = readSSE(t, r)
+	assert.Equal(t, health.StreamEventAggregate, name)
+	assert.Contains(t, data, `"status":"unhealthy"`)
+	assert.NotContains(t, data, "db")
+	assert.NotContains(t, data, "refused")
+
+	// aggregate status is unchanged, next is the heartbeat
+	cache.set(errors.New("timeout"))
+	assert.True(t, collector.CheckNow(time.Minute))
+	clock.BlockUntil(2) // initial delay and heartbeat timers
+	clock.Advance(time.Second)
+	name, data = readSSE(t, r)
+	assert.Equal(t, health.StreamEventHeartbeat, name)
+	assert.Contains(t, data, `"status":"unhealthy"`)
+	assert.NotContains(t, data, "cache")
+}
@@ -0,0 +1,149 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	_ ReporterDetails = (*CompositeReporter)(nil)
+	_ ReporterContext = (*namedReporter)(nil)
+)
+
+// CompositeReporter struct checks a group of reporters concurrently as a
+// single reporter, refer to `AllOf` and `AnyOf`. Passed and failed members
+// are reported in `CheckResult.Details` as `passed` and `failed`.
+type CompositeReporter struct {
+	any     bool
+	members []Reporter
+}
+
+// AllOf method returns the composite reporter which is healthy only if all
+// of the given reporters are healthy. Members are identified by their
+// position, use `Named` to give them a name, e.g.:
+//
+//	health.AllOf(
+//	    health.Named("primary", primaryDB),
+//	    health.Named("replica", replicaDB),
+//	)
+func AllOf(reporters ...Reporter) *CompositeReporter {
+	return &CompositeReporter{members: reporters}
+}
+
+// AnyOf method returns the composite reporter which is healthy if at least
+// one of the given reporters is healthy, for e.g. any replica of the cache
+// is reachable. Refer to `AllOf`.
+func AnyOf(reporters ...Reporter) *CompositeReporter {
+	return &CompositeReporter{any: true, members: reporters}
+}
+
+// Named method returns the reporter with given name, it is used to identify
+// the member of composite reporter.
+func Named(name string, r Reporter) Reporter {
+	return &namedReporter{name: name, Reporter: r}
+}
+
+// Check method checks all the members.
+func (r *CompositeReporter) Check() error {
+	_, err := r.CheckDetails(context.Background())
+	return err
+}
+
+// CheckDetails method checks all the members concurrently with given
+// context and returns the passed and failed members as details.
+func (r *CompositeReporter) CheckDetails(ctx context.Context) (map[string]interface{}, error) {
+	names := make([]string, len(r.members))
+	errs := make([]error, len(r.members))
+	var wg sync.WaitGroup
+	wg.Add(len(r.members))
+	for i, m := range r.members {
+		names[i] = strconv.Itoa(i)
+		if nr, ok := m.(*namedReporter); ok {
+			names[i] = nr.name
+		}
+		go func(i int, m Reporter) {
+			defer wg.Done()
+			_, errs[i] = checkDetails(ctx, m)
+		}(i, m)
+	}
+	wg.Wait()
+
+	passed := make([]string, 0, len(r.members))
+	failed := make(map[string]string)
+	for i, name := range names {
+		if errs[i] == nil {
+			passed = append(passed, name)
+		} else {
+			failed[name] = errs[i].Error()
+		}
+	}
+	details := map[string]interface{}{"passed": passed, "failed": failed}
+
+	switch {
+	case len(failed) == 0, r.any && len(passed) > 0:
+		return details, nil
+	case r.any:
+		return details, fmt.Errorf("all %d members failed: %s", len(r.members), joinFailures(failed))
+	default:
+		return details, fmt.Errorf("%d of %d members failed: %s", len(failed), len(r.members), joinFailures(failed))
+	}
+}
+
+// checkDetails performs the check of given reporter, panic raised by the
+// reporter is recovered and returned as an error.
+func checkDetails(ctx context.Context, r Reporter) (details map[string]interface{}, err error) {
+	defer func() {
+		if rv := recover(); rv != nil {
+			details, err = nil, fmt.Errorf("panic: %v", rv)
+		}
+	}()
+	return callReporter(ctx, r)
+}
+
+// callReporter performs the check of given reporter, it prefers
+// `ReporterDetails` and `ReporterContext` over `Reporter` if implemented.
+func callReporter(ctx context.Context, r Reporter) (map[string]interface{}, error) {
+	switch m := r.(type) {
+	case ReporterDetails:
+		return m.CheckDetails(ctx)
+	case ReporterContext:
+		return nil, m.CheckContext(ctx)
+	default:
+		return nil, r.Check()
+	}
+}
+
+// joinFailures returns the failed members sorted by name as
+// `name: error` pairs.
+func joinFailures(failed map[string]string) string {
+	names := make([]string, 0, len(failed))
+	for name := range failed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for i, name := range names {
+		names[i] = name + ": " + failed[name]
+	}
+	return strings.Join(names, "; ")
+}
+
+// namedReporter struct holds the reporter along with its name.
+type namedReporter struct {
+	Reporter
+	name string
+}
+
+// CheckContext method checks the reporter with given context if it
+// implements `ReporterContext`.
+func (r *namedReporter) CheckContext(ctx context.Context) error {
+	_, err := callReporter(ctx, r.Reporter)
+	return err
+}
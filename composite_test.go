@@ -0,0 +1,47 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthAllOf(t *testing.T) {
+	primary, replica := &toggleReporter{}, &toggleReporter{}
+	r := AllOf(Named("primary", primary), Named("replica", replica))
+	assert.Nil(t, r.Check())
+
+	replica.set(errors.New("timeout"))
+	err := r.Check()
+	assert.Equal(t, "1 of 2 members failed: replica: timeout", err.Error())
+
+	collector := newCollector()
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: r})
+	collector.runChecks()
+	result := collector.Results()["db"]
+	assert.Equal(t, StatusKO, result.Status)
+	assert.Equal(t, []string{"primary"}, result.Details["passed"])
+	assert.Equal(t, map[string]string{"replica": "timeout"}, result.Details["failed"])
+}
+
+func TestHealthAnyOf(t *testing.T) {
+	first, second := &toggleReporter{}, &toggleReporter{}
+	r := AnyOf(first, second, panicReporter{})
+
+	details, err := r.CheckDetails(newCollector().ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"0", "1"}, details["passed"])
+	assert.Equal(t, map[string]string{"2": "panic: boom"}, details["failed"])
+
+	first.set(errors.New("refused"))
+	assert.Nil(t, r.Check())
+
+	second.set(errors.New("refused"))
+	err = r.Check()
+	assert.Equal(t, "all 3 members failed: 0: refused; 1: refused; 2: panic: boom", err.Error())
+}
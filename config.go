@@ -0,0 +1,345 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"aahframe.work/config"
+)
+
+// applyAppConfig method applies the `health { ... }` section of aah
+// application config on the collector and given register options. Values
+// set in the code take precedence over the config values.
+//
+//	health {
+//	  # Periodic check interval, applied unless `WithInterval` is given.
+//	  interval = "30s"
+//
+//	  # Maximum random delay added to the check interval.
+//	  jitter = "5s"
+//
+//	  # Default timeout of `ReporterContext` checks.
+//	  timeout = "5s"
+//
+//	  # Logs a warning when a check takes longer, disabled by default.
+//	  slow_threshold = "2s"
+//
+//	  # Deadline of a check cycle, refer to `WithCheckCycleTimeout`.
+//	  check_cycle_timeout = "20s"
+//
+//	  # Maximum number of reporters checked concurrently, 0 means no limit.
+//	  max_concurrent_checks = 20
+//
+//	  # Logs every check result at debug level, refer to `WithCheckLogging`.
+//	  log_checks = true
+//
+//	  # Notification throttling of the reporters, refer to `NotifyPolicy`.
+//	  notify {
+//	    repeat_interval = "30m"
+//	    escalate_after = "1h"
+//	  }
+//
+//	  # Reports `starting` until critical reporters pass once, refer to
+//	  # `WithStartupGracePeriod`.
+//	  startup_grace_period = "2m"
+//
+//	  # Register options, refer to `RegisterOptions`.
+//	  base_path = "/admin"
+//	  route_name = "health"
+//	  path = "/internal/health"
+//	  ping_route_name = "alive"
+//	  ping_path = "/internal/alive"
+//	  format = "health+json"
+//	  exposure = "summary"
+//	  allow_verbose_query = true
+//	  envelope = true
+//	  force_check = true
+//	  force_check_timeout = "5s"
+//	  force_check_token = "secret"
+//	  unhealthy_status_code = 500
+//	  starting_status_code = 200
+//	  lame_duck_period = "15s"
+//	  score_threshold = 75
+//	  retry_after = true
+//	  gzip_min_size = 4096
+//	  stream_heartbeat = "30s"
+//
+//	  # Protects health check, ready and metrics routes.
+//	  auth_token = "secret"
+//	  basic_auth {
+//	    username = "monitor"
+//	    password = "secret"
+//	  }
+//
+//	  # Restricts health routes to given networks, refer to `AllowedCIDRs`.
+//	  allowed_cidrs = ["10.0.0.0/8", "192.168.1.10"]
+//	  restrict_ping = false
+//	  denied_status_code = 404
+//
+//	  # Allows browser-based dashboards of other origins, refer to
+//	  # `CORSAllowedOrigins`.
+//	  cors {
+//	    allowed_origins = ["https://status.example.com"]
+//	    allowed_methods = ["GET", "HEAD"]
+//	    allowed_headers = ["Authorization"]
+//	    max_age = "1h"
+//	  }
+//
+//	  # Registers `/healthcheck/tags/<tag>` routes, refer to `TagRoutes`.
+//	  tag_routes = ["core", "external"]
+//
+//	  # Publishes the health state via expvar under given name, refer to
+//	  # `Collector.PublishExpvar`. Named collector is published under
+//	  # `<expvar>_<name>`. Disabled by default.
+//	  expvar = "health"
+//
+//	  # Additional fields of the response envelope.
+//	  metadata {
+//	    region = "us-east-1"
+//	  }
+//
+//	  # Route enablement, all except stream, ws and ui are enabled by default.
+//	  routes {
+//	    live = true
+//	    ready = true
+//	    metrics = false
+//	    history = true
+//	    badge = true
+//	    ping = true
+//	    stream = false
+//	    ws = false
+//	    ui = false
+//	  }
+//
+//	  # Reporter specific settings, keyed by reporter name. Reporters of
+//	  # type tcp, http, dns and cmd can be declared too, the ones added in
+//	  # the code take precedence. Section `<name>.reporters` applies to the
+//	  # collector registered with `RegisterOptions.Name`.
+//	  reporters {
+//	    db {
+//	      timeout = "3s"
+//	    }
+//	    cache {
+//	      type = "tcp"
+//	      address = "cache:6379"
+//	    }
+//	  }
+//	}
+func (c *Collector) applyAppConfig(cfg *config.Config, opts *RegisterOptions) error {
+	if cfg == nil {
+		return nil
+	}
+
+	if !c.intervalSet {
+		interval, err := configDuration(cfg, "health.interval")
+		if err != nil {
+			return err
+		}
+		c.SetInterval(interval)
+	}
+
+	if atomic.LoadInt64(&c.jitter) == 0 {
+		jitter, err := configDuration(cfg, "health.jitter")
+		if err != nil {
+			return err
+		}
+		c.SetJitter(jitter)
+	}
+
+	timeout, err := configDuration(cfg, "health.timeout")
+	if err != nil {
+		return err
+	}
+	slow, err := configDuration(cfg, "health.slow_threshold")
+	if err != nil {
+		return err
+	}
+	grace, err := configDuration(cfg, "health.startup_grace_period")
+	if err != nil {
+		return err
+	}
+	cycleTimeout, err := configDuration(cfg, "health.check_cycle_timeout")
+	if err != nil {
+		return err
+	}
+	repeatInterval, err := configDuration(cfg, "health.notify.repeat_interval")
+	if err != nil {
+		return err
+	}
+	escalateAfter, err := configDuration(cfg, "health.notify.escalate_after")
+	if err != nil {
+		return err
+	}
+	section := reportersSection(opts.Name)
+	timeouts := make(map[string]time.Duration)
+	for _, name := range cfg.KeysByPath(section) {
+		t, err := configDuration(cfg, section+"."+name+".timeout")
+		if err != nil {
+			return err
+		}
+		if t > 0 {
+			timeouts[name] = t
+		}
+	}
+	if name := cfg.StringDefault("health.expvar", ""); len(name) > 0 {
+		if len(opts.Name) > 0 {
+			name += "_" + opts.Name
+		}
+		if err := c.PublishExpvar(name); err != nil {
+			return err
+		}
+	}
+
+	c.mu.Lock()
+	if c.timeout == 0 {
+		c.timeout = timeout
+	}
+	if c.slow == 0 {
+		c.slow = slow
+	}
+	if c.startupGrace == 0 {
+		c.startupGrace = grace
+	}
+	if c.cycleTimeout == 0 {
+		c.cycleTimeout = cycleTimeout
+	}
+	if c.notifyPolicy.RepeatInterval == 0 {
+		c.notifyPolicy.RepeatInterval = repeatInterval
+	}
+	if c.notifyPolicy.EscalateAfter == 0 {
+		c.notifyPolicy.EscalateAfter = escalateAfter
+	}
+	if c.maxChecks == 0 {
+		c.maxChecks = cfg.IntDefault("health.max_concurrent_checks", 0)
+	}
+	c.logChecks = c.logChecks || cfg.BoolDefault("health.log_checks", false)
+	for name, t := range timeouts {
+		c.timeouts[name] = t
+	}
+	c.mu.Unlock()
+	if err = c.addConfigReporters(cfg, section); err != nil {
+		return err
+	}
+
+	if opts.BasePath == "" {
+		opts.BasePath = cfg.StringDefault("health.base_path", "")
+	}
+	if opts.RouteName == "" {
+		opts.RouteName = cfg.StringDefault("health.route_name", "")
+	}
+	if opts.Path == "" {
+		opts.Path = cfg.StringDefault("health.path", "")
+	}
+	if opts.PingRouteName == "" {
+		opts.PingRouteName = cfg.StringDefault("health.ping_route_name", "")
+	}
+	if opts.PingPath == "" {
+		opts.PingPath = cfg.StringDefault("health.ping_path", "")
+	}
+	if opts.Format == "" {
+		opts.Format = cfg.StringDefault("health.format", "")
+	}
+	if opts.Exposure == "" {
+		opts.Exposure = cfg.StringDefault("health.exposure", "")
+	}
+	opts.AllowVerboseQuery = opts.AllowVerboseQuery || cfg.BoolDefault("health.allow_verbose_query", false)
+	opts.Envelope = opts.Envelope || cfg.BoolDefault("health.envelope", false)
+	if keys := cfg.KeysByPath("health.metadata"); len(keys) > 0 && opts.Metadata == nil {
+		opts.Metadata = make(map[string]string, len(keys))
+		for _, key := range keys {
+			opts.Metadata[key] = cfg.StringDefault("health.metadata."+key, "")
+		}
+	}
+	opts.ForceCheck = opts.ForceCheck || cfg.BoolDefault("health.force_check", false)
+	if opts.ForceCheckTimeout <= 0 {
+		if opts.ForceCheckTimeout, err = configDuration(cfg, "health.force_check_timeout"); err != nil {
+			return err
+		}
+	}
+	if opts.LameDuckPeriod <= 0 {
+		if opts.LameDuckPeriod, err = configDuration(cfg, "health.lame_duck_period"); err != nil {
+			return err
+		}
+	}
+	if opts.StreamHeartbeat <= 0 {
+		if opts.StreamHeartbeat, err = configDuration(cfg, "health.stream_heartbeat"); err != nil {
+			return err
+		}
+	}
+	if opts.ForceCheckToken == "" {
+		opts.ForceCheckToken = cfg.StringDefault("health.force_check_token", "")
+	}
+	if opts.AuthToken == "" {
+		opts.AuthToken = cfg.StringDefault("health.auth_token", "")
+	}
+	if opts.BasicAuthUsername == "" {
+		opts.BasicAuthUsername = cfg.StringDefault("health.basic_auth.username", "")
+		opts.BasicAuthPassword = cfg.StringDefault("health.basic_auth.password", "")
+	}
+	if len(opts.AllowedCIDRs) == 0 {
+		opts.AllowedCIDRs, _ = cfg.StringList("health.allowed_cidrs")
+	}
+	opts.RestrictPing = opts.RestrictPing || cfg.BoolDefault("health.restrict_ping", false)
+	if opts.DeniedStatusCode == 0 {
+		opts.DeniedStatusCode = cfg.IntDefault("health.denied_status_code", 0)
+	}
+	if opts.UnhealthyStatusCode == 0 {
+		opts.UnhealthyStatusCode = cfg.IntDefault("health.unhealthy_status_code", 0)
+	}
+	if opts.StartingStatusCode == 0 {
+		opts.StartingStatusCode = cfg.IntDefault("health.starting_status_code", 0)
+	}
+	if opts.ScoreThreshold == 0 {
+		opts.ScoreThreshold = cfg.Float64Default("health.score_threshold", 0)
+	}
+	opts.RetryAfter = opts.RetryAfter || cfg.BoolDefault("health.retry_after", false)
+	if opts.GzipMinSize == 0 {
+		opts.GzipMinSize = cfg.IntDefault("health.gzip_min_size", 0)
+	}
+	if len(opts.CORSAllowedOrigins) == 0 {
+		opts.CORSAllowedOrigins, _ = cfg.StringList("health.cors.allowed_origins")
+	}
+	if len(opts.CORSAllowedMethods) == 0 {
+		opts.CORSAllowedMethods, _ = cfg.StringList("health.cors.allowed_methods")
+	}
+	if len(opts.CORSAllowedHeaders) == 0 {
+		opts.CORSAllowedHeaders, _ = cfg.StringList("health.cors.allowed_headers")
+	}
+	if opts.CORSMaxAge <= 0 {
+		if opts.CORSMaxAge, err = configDuration(cfg, "health.cors.max_age"); err != nil {
+			return err
+		}
+	}
+	if len(opts.TagRoutes) == 0 {
+		opts.TagRoutes, _ = cfg.StringList("health.tag_routes")
+	}
+	opts.DisableLive = opts.DisableLive || !cfg.BoolDefault("health.routes.live", true)
+	opts.DisableReady = opts.DisableReady || !cfg.BoolDefault("health.routes.ready", true)
+	opts.DisableMetrics = opts.DisableMetrics || !cfg.BoolDefault("health.routes.metrics", true)
+	opts.DisableHistory = opts.DisableHistory || !cfg.BoolDefault("health.routes.history", true)
+	opts.DisableBadge = opts.DisableBadge || !cfg.BoolDefault("health.routes.badge", true)
+	opts.DisablePing = opts.DisablePing || !cfg.BoolDefault("health.routes.ping", true)
+	opts.EnableStream = opts.EnableStream || cfg.BoolDefault("health.routes.stream", false)
+	opts.EnableWebSocket = opts.EnableWebSocket || cfg.BoolDefault("health.routes.ws", false)
+	opts.EnableUI = opts.EnableUI || cfg.BoolDefault("health.routes.ui", false)
+	return nil
+}
+
+// configDuration method parses the duration value of given config key,
+// it returns 0 if key does not exist.
+func configDuration(cfg *config.Config, key string) (time.Duration, error) {
+	v := cfg.StringDefault(key, "")
+	if v == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("health: invalid duration value '%s' for config '%s'", v, key)
+	}
+	return d, nil
+}
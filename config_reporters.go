@@ -0,0 +1,207 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"fmt"
+	"time"
+
+	"aahframe.work/config"
+)
+
+// Reporter types which can be declared in the aah application config.
+const (
+	configReporterTCP  = "tcp"
+	configReporterHTTP = "http"
+	configReporterDNS  = "dns"
+	configReporterCmd  = "cmd"
+)
+
+// reportersSection returns the config section of the reporters of the
+// collector registered with given name, `health.reporters` for the unnamed
+// collector and `health.<name>.reporters` for the named one.
+func reportersSection(name string) string {
+	if len(name) == 0 {
+		return "health.reporters"
+	}
+	return "health." + name + ".reporters"
+}
+
+// reporterSpec struct holds the reporter declared in the reporters section
+// of aah application config, refer to `reportersSection`.
+type reporterSpec struct {
+	section        string
+	name           string
+	typ            string
+	address        string
+	url            string
+	method         string
+	expectedStatus []int
+	bodyContains   string
+	hostname       string
+	command        string
+	args           []string
+	timeout        time.Duration
+	severity       Severity
+	tags           []string
+	dependsOn      []string
+	weight         float64
+}
+
+// addConfigReporters method adds the reporters declared with `type` in the
+// given reporters section of aah application config, so that checks can be
+// added or tuned without code changes. Reporters added in the code take
+// precedence over the config ones of same name. They are added once per
+// collector, though it is registered for multiple domains.
+//
+//	reporters {
+//	  db {
+//	    type = "tcp"
+//	    address = "db:5432"
+//	    timeout = "3s"
+//	  }
+//	  payments {
+//	    type = "http"
+//	    url = "https://payments.internal/health"
+//	    method = "HEAD"
+//	    expected_status = [200, 204]
+//	    body_contains = "ok"
+//	    severity = "warning"
+//	    tags = ["external"]
+//	    depends_on = ["db"]
+//	    weight = 2
+//	  }
+//	  mail {
+//	    type = "dns"
+//	    hostname = "smtp.example.com"
+//	  }
+//	  disk {
+//	    type = "cmd"
+//	    command = "/usr/lib/nagios/plugins/check_disk"
+//	    args = ["-w", "20%", "-c", "10%", "-p", "/"]
+//	  }
+//	}
+func (c *Collector) addConfigReporters(cfg *config.Config, section string) error {
+	c.mu.Lock()
+	added := c.configAdded
+	c.configAdded = true
+	c.mu.Unlock()
+	if added {
+		return nil
+	}
+
+	for _, name := range cfg.KeysByPath(section) {
+		key := section + "." + name
+		if cfg.StringDefault(key+".type", "") == "" {
+			continue // settings of the reporter added in the code
+		}
+		c.mu.RLock()
+		_, exists := c.reporters[name]
+		c.mu.RUnlock()
+		if exists {
+			continue
+		}
+		spec, err := configReporterSpec(cfg, section, name)
+		if err != nil {
+			return err
+		}
+		rc, err := spec.config()
+		if err != nil {
+			return err
+		}
+		if err = c.AddReporter(rc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// configReporterSpec returns the reporter spec of given name from the
+// reporters section of aah application config.
+func configReporterSpec(cfg *config.Config, section, name string) (reporterSpec, error) {
+	key := section + "." + name
+	timeout, err := configDuration(cfg, key+".timeout")
+	if err != nil {
+		return reporterSpec{}, err
+	}
+	spec := reporterSpec{
+		section:      section,
+		name:         name,
+		typ:          cfg.StringDefault(key+".type", ""),
+		address:      cfg.StringDefault(key+".address", ""),
+		url:          cfg.StringDefault(key+".url", ""),
+		method:       cfg.StringDefault(key+".method", ""),
+		bodyContains: cfg.StringDefault(key+".body_contains", ""),
+		hostname:     cfg.StringDefault(key+".hostname", ""),
+		command:      cfg.StringDefault(key+".command", ""),
+		timeout:      timeout,
+		severity:     Severity(cfg.StringDefault(key+".severity", "")),
+		weight:       cfg.Float64Default(key+".weight", 0),
+	}
+	spec.expectedStatus, _ = cfg.IntList(key + ".expected_status")
+	spec.args, _ = cfg.StringList(key + ".args")
+	spec.tags, _ = cfg.StringList(key + ".tags")
+	spec.dependsOn, _ = cfg.StringList(key + ".depends_on")
+	return spec, nil
+}
+
+// config method returns the reporter config of the spec.
+func (s reporterSpec) config() (*Config, error) {
+	section := s.section
+	if section == "" {
+		section = reportersSection("")
+	}
+	key := section + "." + s.name
+	if _, found := severityRank[s.severity]; len(s.severity) > 0 && !found {
+		return nil, fmt.Errorf("health: unsupported severity '%s' for config '%s.severity'", s.severity, key)
+	}
+	rc := &Config{
+		Name:      s.name,
+		Timeout:   s.timeout,
+		Severity:  s.severity,
+		Tags:      s.tags,
+		DependsOn: s.dependsOn,
+		Weight:    s.weight,
+	}
+	switch s.typ {
+	case configReporterTCP:
+		if s.address == "" {
+			return nil, fmt.Errorf("health: config '%s.address' is required", key)
+		}
+		rc.Reporter = NewTCPReporter(s.address, s.timeout)
+	case configReporterHTTP:
+		if s.url == "" {
+			return nil, fmt.Errorf("health: config '%s.url' is required", key)
+		}
+		reporter, err := NewHTTPReporter(HTTPReporterOptions{
+			URL:            s.url,
+			Method:         s.method,
+			Timeout:        s.timeout,
+			ExpectedStatus: s.expectedStatus,
+			BodyContains:   s.bodyContains,
+		})
+		if err != nil {
+			return nil, err
+		}
+		rc.Reporter = reporter
+	case configReporterDNS:
+		if s.hostname == "" {
+			return nil, fmt.Errorf("health: config '%s.hostname' is required", key)
+		}
+		rc.Reporter = NewDNSReporter(s.hostname)
+	case configReporterCmd:
+		if s.command == "" {
+			return nil, fmt.Errorf("health: config '%s.command' is required", key)
+		}
+		rc.Reporter = NewCommandReporter(CommandReporterOptions{
+			Path:    s.command,
+			Args:    s.args,
+			Timeout: s.timeout,
+		})
+	default:
+		return nil, fmt.Errorf("health: unsupported reporter type '%s' for config '%s.type'", s.typ, key)
+	}
+	return rc, nil
+}
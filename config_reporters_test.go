@@ -0,0 +1,110 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthConfigReporterSpec(t *testing.T) {
+	testcases := []struct {
+		label string
+		spec  reporterSpec
+		err   string
+	}{
+		{
+			label: "tcp",
+			spec:  reporterSpec{name: "db", typ: "tcp", address: "db:5432", timeout: 3 * time.Second},
+		},
+		{
+			label: "tcp without address",
+			spec:  reporterSpec{name: "db", typ: "tcp"},
+			err:   "health: config 'health.reporters.db.address' is required",
+		},
+		{
+			label: "http",
+			spec:  reporterSpec{name: "api", typ: "http", url: "http://api/health", method: http.MethodHead, expectedStatus: []int{204}},
+		},
+		{
+			label: "http without url",
+			spec:  reporterSpec{name: "api", typ: "http"},
+			err:   "health: config 'health.reporters.api.url' is required",
+		},
+		{
+			label: "dns",
+			spec:  reporterSpec{name: "mail", typ: "dns", hostname: "smtp.example.com"},
+		},
+		{
+			label: "dns without hostname",
+			spec:  reporterSpec{name: "mail", typ: "dns"},
+			err:   "health: config 'health.reporters.mail.hostname' is required",
+		},
+		{
+			label: "cmd",
+			spec:  reporterSpec{name: "disk", typ: "cmd", command: "/usr/lib/nagios/plugins/check_disk", args: []string{"-w", "20%"}},
+		},
+		{
+			label: "cmd without command",
+			spec:  reporterSpec{name: "disk", typ: "cmd"},
+			err:   "health: config 'health.reporters.disk.command' is required",
+		},
+		{
+			label: "unsupported type",
+			spec:  reporterSpec{name: "queue", typ: "amqp"},
+			err:   "health: unsupported reporter type 'amqp' for config 'health.reporters.queue.type'",
+		},
+		{
+			label: "named collector",
+			spec:  reporterSpec{section: "health.payments.reporters", name: "db", typ: "tcp"},
+			err:   "health: config 'health.payments.reporters.db.address' is required",
+		},
+		{
+			label: "unsupported severity",
+			spec:  reporterSpec{name: "db", typ: "tcp", address: "db:5432", severity: "fatal"},
+			err:   "health: unsupported severity 'fatal' for config 'health.reporters.db.severity'",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.label, func(t *testing.T) {
+			rc, err := tc.spec.config()
+			if len(tc.err) > 0 {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.spec.name, rc.Name)
+		})
+	}
+
+	rc, _ := reporterSpec{
+		name:      "api",
+		typ:       "http",
+		url:       "http://api/health",
+		method:    http.MethodHead,
+		timeout:   2 * time.Second,
+		severity:  SeverityWarning,
+		tags:      []string{"external"},
+		dependsOn: []string{"db"},
+		weight:    2,
+	}.config()
+	assert.Equal(t, SeverityWarning, rc.Severity)
+	assert.Equal(t, []string{"external"}, rc.Tags)
+	assert.Equal(t, []string{"db"}, rc.DependsOn)
+	assert.Equal(t, 2.0, rc.Weight)
+	assert.Equal(t, 2*time.Second, rc.Timeout)
+	if r, ok := rc.Reporter.(*HTTPReporter); assert.True(t, ok) {
+		assert.Equal(t, http.MethodHead, r.opts.Method)
+		assert.Equal(t, 2*time.Second, r.opts.Timeout)
+	}
+}
+
+func TestHealthReportersSection(t *testing.T) {
+	assert.Equal(t, "health.reporters", reportersSection(""))
+	assert.Equal(t, "health.payments.reporters", reportersSection("payments"))
+}
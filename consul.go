@@ -0,0 +1,295 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Consul check modes of `ConsulOptions`.
+const (
+	// ConsulCheckTTL registers a TTL check and the collector pushes its
+	// aggregate status to it periodically.
+	ConsulCheckTTL = "ttl"
+
+	// ConsulCheckHTTP registers a HTTP check, Consul agent polls the given
+	// health check URL of the application.
+	ConsulCheckHTTP = "http"
+)
+
+// Consul check status values.
+const (
+	consulPassing  = "passing"
+	consulWarning  = "warning"
+	consulCritical = "critical"
+)
+
+// ConsulOptions struct holds the configuration of Consul service
+// registration, refer to `Collector.RegisterConsul`.
+type ConsulOptions struct {
+	// Addr of the Consul agent HTTP API, default is `http://127.0.0.1:8500`.
+	Addr string
+
+	// Token is the Consul ACL token, if set.
+	Token string
+
+	// ServiceName is required, ServiceID defaults to `<ServiceName>-<instance>`
+	// using `Collector.Instance`.
+	ServiceName string
+	ServiceID   string
+
+	// Address and Port of the service as advertised in Consul, default
+	// address is the address of the Consul agent's node.
+	Address string
+	Port    int
+
+	// Tags and Meta of the service.
+	Tags []string
+	Meta map[string]string
+
+	// CheckMode is either `ConsulCheckTTL` or `ConsulCheckHTTP`, default is
+	// `ConsulCheckTTL`.
+	CheckMode string
+
+	// TTL of the check for `ConsulCheckTTL`, default is 30 seconds. Status is
+	// pushed every one third of the TTL.
+	TTL time.Duration
+
+	// CheckURL is the health check URL polled by the Consul agent for
+	// `ConsulCheckHTTP` at CheckInterval, default is 10 seconds. It is
+	// required for that mode.
+	CheckURL      string
+	CheckInterval time.Duration
+
+	// DeregisterCriticalAfter makes Consul deregister the service once its
+	// check stays critical for the duration, for e.g. instance crashed
+	// without deregistration. 0 disables it.
+	DeregisterCriticalAfter time.Duration
+
+	// Timeout of the Consul API requests, default is 5 seconds.
+	Timeout time.Duration
+}
+
+// ConsulRegistration struct represents the service registered in Consul,
+// the check is kept in sync with the collector until `Deregister` is called
+// or the collector is stopped.
+type ConsulRegistration struct {
+	collector *Collector
+	opts      ConsulOptions
+	client    *http.Client
+	stop      chan struct{}
+	done      chan struct{}
+	once      sync.Once
+	err       error
+}
+
+// RegisterConsul method registers the application service in Consul as per
+// given options and mirrors the collector's aggregate status into its TTL
+// check, `Degraded` is reported as warning. While draining or shutting down
+// check is reported critical. Service is deregistered when the collector is
+// stopped, for e.g. aah application shutdown.
+//
+//	reg, err := collector.RegisterConsul(health.ConsulOptions{
+//	    ServiceName: "orders",
+//	    Port:        8080,
+//	})
+func (c *Collector) RegisterConsul(opts ConsulOptions) (*ConsulRegistration, error) {
+	if opts.ServiceName == "" {
+		return nil, errors.New("health: consul service name is required")
+	}
+	if opts.Addr == "" {
+		opts.Addr = "http://127.0.0.1:8500"
+	}
+	opts.Addr = strings.TrimSuffix(opts.Addr, "/")
+	if opts.ServiceID == "" {
+		opts.ServiceID = opts.ServiceName + "-" + c.Instance()
+	}
+	switch opts.CheckMode {
+	case "":
+		opts.CheckMode = ConsulCheckTTL
+	case ConsulCheckTTL:
+	case ConsulCheckHTTP:
+		if opts.CheckURL == "" {
+			return nil, errors.New("health: consul check url is required for http check mode")
+		}
+	default:
+		return nil, fmt.Errorf("health: unsupported consul check mode '%s'", opts.CheckMode)
+	}
+	if opts.TTL <= 0 {
+		opts.TTL = 30 * time.Second
+	}
+	if opts.CheckInterval <= 0 {
+		opts.CheckInterval = 10 * time.Second
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+
+	r := &ConsulRegistration{
+		collector: c,
+		opts:      opts,
+		client:    &http.Client{Timeout: opts.Timeout},
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	if err := r.register(); err != nil {
+		return nil, err
+	}
+	go r.sync()
+	return r, nil
+}
+
+// ServiceID method returns the Consul service ID of the registration.
+func (r *ConsulRegistration) ServiceID() string {
+	return r.opts.ServiceID
+}
+
+// Deregister method stops the check sync and deregisters the service from
+// Consul. It is safe to call multiple times.
+func (r *ConsulRegistration) Deregister() error {
+	r.once.Do(func() {
+		close(r.stop)
+		<-r.done
+		r.err = r.do(http.MethodPut, "/v1/agent/service/deregister/"+url.PathEscape(r.opts.ServiceID), nil)
+	})
+	return r.err
+}
+
+func (r *ConsulRegistration) register() error {
+	check := map[string]interface{}{
+		"CheckID": r.checkID(),
+		"Name":    "aah health",
+	}
+	if r.opts.CheckMode == ConsulCheckTTL {
+		check["TTL"] = r.opts.TTL.String()
+		check["Status"] = consulStatus(r.collector.currentStatus(true, nil))
+	} else {
+		check["HTTP"] = r.opts.CheckURL
+		check["Interval"] = r.opts.CheckInterval.String()
+		check["Timeout"] = r.opts.Timeout.String()
+	}
+	if r.opts.DeregisterCriticalAfter > 0 {
+		check["DeregisterCriticalServiceAfter"] = r.opts.DeregisterCriticalAfter.String()
+	}
+	service := map[string]interface{}{
+		"ID":    r.opts.ServiceID,
+		"Name":  r.opts.ServiceName,
+		"Tags":  r.opts.Tags,
+		"Meta":  r.opts.Meta,
+		"Check": check,
+	}
+	if len(r.opts.Address) > 0 {
+		service["Address"] = r.opts.Address
+	}
+	if r.opts.Port > 0 {
+		service["Port"] = r.opts.Port
+	}
+	return r.do(http.MethodPut, "/v1/agent/service/register", service)
+}
+
+// sync method pushes the collector status to the TTL check periodically
+// and deregisters the service once the collector is stopped.
+func (r *ConsulRegistration) sync() {
+	defer close(r.done)
+	var tick <-chan time.Time
+	if r.opts.CheckMode == ConsulCheckTTL {
+		t := time.NewTicker(r.opts.TTL / 3)
+		defer t.Stop()
+		tick = t.C
+	}
+	for {
+		select {
+		case <-tick:
+			if err := r.update(); err != nil {
+				if logger := r.collector.log(); logger != nil {
+					logger.Errorf("health: unable to update consul check: %v", err)
+				}
+			}
+		case <-r.collector.ctx.Done():
+			go func() { _ = r.Deregister() }()
+			return
+		case <-r.stop:
+			return
+		}
+	}
+}
+
+// update method pushes the current status of the collector to the TTL check.
+func (r *ConsulRegistration) update() error {
+	state, status := r.collector.currentStatus(true, nil)
+	return r.do(http.MethodPut, "/v1/agent/check/update/"+url.PathEscape(r.checkID()), map[string]string{
+		"Status": consulStatus(state, status),
+		"Output": consulOutput(state, status),
+	})
+}
+
+func (r *ConsulRegistration) checkID() string {
+	return "service:" + r.opts.ServiceID
+}
+
+func (r *ConsulRegistration) do(method, path string, v interface{}) error {
+	var body io.Reader
+	if v != nil {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequest(method, r.opts.Addr+path, body)
+	if err != nil {
+		return err
+	}
+	if len(r.opts.Token) > 0 {
+		req.Header.Set("X-Consul-Token", r.opts.Token)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("health: consul responded with status %d: %s",
+			resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+func consulStatus(_ *snapshot, status AggregateStatus) string {
+	switch status {
+	case Degraded:
+		return consulWarning
+	case Unhealthy:
+		return consulCritical
+	}
+	return consulPassing
+}
+
+// consulOutput function returns the check output listing the failing
+// reporters.
+func consulOutput(state *snapshot, status AggregateStatus) string {
+	var failing []string
+	for name, result := range state.results {
+		if !result.IsOK() && !result.Maintenance && !result.Muted {
+			failing = append(failing, name)
+		}
+	}
+	if len(failing) == 0 {
+		return string(status)
+	}
+	sort.Strings(failing)
+	return string(status) + ", failing reporters: " + strings.Join(failing, ", ")
+}
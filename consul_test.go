@@ -0,0 +1,137 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type consulAgent struct {
+	mu       sync.Mutex
+	requests []string
+	service  map[string]interface{}
+	updates  []map[string]string
+}
+
+func (a *consulAgent) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if r.Header.Get("X-Consul-Token") != "secret" {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("ACL not found"))
+		return
+	}
+	a.requests = append(a.requests, r.Method+" "+r.URL.Path)
+	switch {
+	case r.URL.Path == "/v1/agent/service/register":
+		_ = json.NewDecoder(r.Body).Decode(&a.service)
+	case r.URL.Path == "/v1/agent/check/update/service:orders-1":
+		var update map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&update)
+		a.updates = append(a.updates, update)
+	}
+}
+
+func (a *consulAgent) lastUpdate() map[string]string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.updates) == 0 {
+		return nil
+	}
+	return a.updates[len(a.updates)-1]
+}
+
+func TestHealthConsulTTL(t *testing.T) {
+	agent := &consulAgent{}
+	ts := httptest.NewServer(agent)
+	defer ts.Close()
+
+	collector := newCollector()
+	db := &toggleReporter{}
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: db})
+	collector.runChecks()
+
+	_, err := collector.RegisterConsul(ConsulOptions{Addr: ts.URL, ServiceName: "orders"})
+	assert.Equal(t, "health: consul responded with status 403: ACL not found", err.Error())
+
+	reg, err := collector.RegisterConsul(ConsulOptions{
+		Addr:        ts.URL,
+		Token:       "secret",
+		ServiceName: "orders",
+		ServiceID:   "orders-1",
+		Port:        8080,
+		Tags:        []string{"api"},
+		TTL:         30 * time.Millisecond,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "orders-1", reg.ServiceID())
+	agent.mu.Lock()
+	check := agent.service["Check"].(map[string]interface{})
+	assert.Equal(t, "30ms", check["TTL"])
+	assert.Equal(t, "passing", check["Status"])
+	assert.Equal(t, float64(8080), agent.service["Port"])
+	agent.mu.Unlock()
+
+	db.set(errors.New("down"))
+	collector.runChecks()
+	assert.Eventually(t, func() bool {
+		u := agent.lastUpdate()
+		return u != nil && u["Status"] == "critical"
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, "unhealthy, failing reporters: db", agent.lastUpdate()["Output"])
+
+	assert.Nil(t, reg.Deregister())
+	assert.Nil(t, reg.Deregister())
+	agent.mu.Lock()
+	assert.Equal(t, "PUT /v1/agent/service/deregister/orders-1", agent.requests[len(agent.requests)-1])
+	agent.mu.Unlock()
+}
+
+func TestHealthConsulHTTP(t *testing.T) {
+	agent := &consulAgent{}
+	ts := httptest.NewServer(agent)
+	defer ts.Close()
+
+	collector := newCollector()
+	_, err := collector.RegisterConsul(ConsulOptions{Addr: ts.URL, ServiceName: "orders", CheckMode: ConsulCheckHTTP})
+	assert.Equal(t, "health: consul check url is required for http check mode", err.Error())
+	_, err = collector.RegisterConsul(ConsulOptions{Addr: ts.URL, ServiceName: "orders", CheckMode: "grpc"})
+	assert.Equal(t, "health: unsupported consul check mode 'grpc'", err.Error())
+	_, err = collector.RegisterConsul(ConsulOptions{Addr: ts.URL})
+	assert.Equal(t, "health: consul service name is required", err.Error())
+
+	_, err = collector.RegisterConsul(ConsulOptions{
+		Addr:                    ts.URL,
+		Token:                   "secret",
+		ServiceName:             "orders",
+		CheckMode:               ConsulCheckHTTP,
+		CheckURL:                "http://10.0.0.5:8080/healthcheck/ready",
+		DeregisterCriticalAfter: time.Hour,
+	})
+	assert.Nil(t, err)
+	agent.mu.Lock()
+	check := agent.service["Check"].(map[string]interface{})
+	assert.Equal(t, "http://10.0.0.5:8080/healthcheck/ready", check["HTTP"])
+	assert.Equal(t, "10s", check["Interval"])
+	assert.Equal(t, "1h0m0s", check["DeregisterCriticalServiceAfter"])
+	agent.mu.Unlock()
+
+	// stopping the collector deregisters the service
+	collector.Stop()
+	assert.Eventually(t, func() bool {
+		agent.mu.Lock()
+		defer agent.mu.Unlock()
+		last := agent.requests[len(agent.requests)-1]
+		return last == "PUT /v1/agent/service/deregister/orders-"+collector.Instance()
+	}, time.Second, 5*time.Millisecond)
+}
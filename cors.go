@@ -0,0 +1,90 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"aahframe.work/router"
+)
+
+// corsExposeHeaders are the response headers readable by the cross-origin
+// dashboards, beyond the CORS-safelisted ones.
+var corsExposeHeaders = strings.Join([]string{HeaderHealthStatus, HeaderHealthScore, HeaderHealthPaused, "ETag", "Retry-After"}, ", ")
+
+// isCORSEnabled returns true if the CORS is configured, refer to
+// `RegisterOptions.CORSAllowedOrigins`.
+func isCORSEnabled(opts RegisterOptions) bool {
+	return len(opts.CORSAllowedOrigins) > 0
+}
+
+// corsAllowedOrigin returns the value of `Access-Control-Allow-Origin` for
+// given request origin, it returns false if the origin is not allowed.
+func corsAllowedOrigin(opts RegisterOptions, origin string) (string, bool) {
+	if len(origin) == 0 {
+		return "", false
+	}
+	for _, o := range opts.CORSAllowedOrigins {
+		if o == "*" {
+			return "*", true
+		}
+		if strings.EqualFold(o, origin) {
+			return origin, true
+		}
+	}
+	return "", false
+}
+
+// isCORSPreflight returns true if given request is the CORS preflight
+// request.
+func isCORSPreflight(r *http.Request) bool {
+	return r.Method == http.MethodOptions && len(r.Header.Get("Access-Control-Request-Method")) > 0
+}
+
+// writeCORSHeaders adds the CORS headers of given request to the response
+// headers as per options, preflight response carries the allowed methods,
+// headers and max age. Headers are not added for the disallowed origin, so
+// that browser blocks the response.
+func writeCORSHeaders(opts RegisterOptions, r *http.Request, hdr http.Header) {
+	if !isCORSEnabled(opts) {
+		return
+	}
+	hdr.Add("Vary", "Origin")
+	origin, allowed := corsAllowedOrigin(opts, r.Header.Get("Origin"))
+	if !allowed {
+		return
+	}
+	hdr.Set("Access-Control-Allow-Origin", origin)
+	if !isCORSPreflight(r) {
+		hdr.Set("Access-Control-Expose-Headers", corsExposeHeaders)
+		return
+	}
+	hdr.Add("Vary", "Access-Control-Request-Method")
+	hdr.Add("Vary", "Access-Control-Request-Headers")
+	hdr.Set("Access-Control-Allow-Methods", strings.Join(opts.CORSAllowedMethods, ", "))
+	if len(opts.CORSAllowedHeaders) > 0 {
+		hdr.Set("Access-Control-Allow-Headers", strings.Join(opts.CORSAllowedHeaders, ", "))
+	}
+	if opts.CORSMaxAge > 0 {
+		hdr.Set("Access-Control-Max-Age", strconv.Itoa(int(opts.CORSMaxAge.Seconds())))
+	}
+}
+
+// optionsRoutes returns the OPTIONS twin of given GET routes for the CORS
+// preflight requests, the request is answered by `Before` interceptor.
+func optionsRoutes(routes []*router.Route) []*router.Route {
+	var preflights []*router.Route
+	for _, r := range routes {
+		if r.Method == http.MethodGet {
+			preflight := *r
+			preflight.Name += "_options"
+			preflight.Method = http.MethodOptions
+			preflights = append(preflights, &preflight)
+		}
+	}
+	return preflights
+}
@@ -0,0 +1,72 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthCORS(t *testing.T) {
+	collector := newCollector()
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: &toggleReporter{}})
+	collector.runChecks()
+
+	h, _ := collector.HandlerWithOptions(RegisterOptions{
+		CORSAllowedOrigins: []string{"https://status.example.com"},
+		CORSAllowedHeaders: []string{"Authorization"},
+		CORSMaxAge:         time.Hour,
+	})
+
+	// actual request
+	req := httptest.NewRequest(http.MethodGet, "/healthcheck", nil)
+	req.Header.Set("Origin", "https://status.example.com")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "https://status.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "X-Health-Status, X-Health-Score, X-Health-Paused, ETag, Retry-After", w.Header().Get("Access-Control-Expose-Headers"))
+	assert.Contains(t, w.Header().Values("Vary"), "Origin")
+
+	// preflight request
+	req = httptest.NewRequest(http.MethodOptions, "/healthcheck/ready", nil)
+	req.Header.Set("Origin", "https://status.example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "https://status.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "GET, HEAD", w.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "Authorization", w.Header().Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "3600", w.Header().Get("Access-Control-Max-Age"))
+	assert.Empty(t, w.Body.String())
+
+	// disallowed origin
+	req = httptest.NewRequest(http.MethodGet, "/healthcheck", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+
+	// wildcard origin
+	h, _ = collector.HandlerWithOptions(RegisterOptions{CORSAllowedOrigins: []string{"*"}})
+	req = httptest.NewRequest(http.MethodGet, "/healthcheck", nil)
+	req.Header.Set("Origin", "https://any.example.com")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+
+	// OPTIONS is not allowed without CORS
+	w = httptest.NewRecorder()
+	collector.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodOptions, "/healthcheck", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Equal(t, "GET, HEAD", w.Header().Get("Allow"))
+	assert.Empty(t, w.Header().Get("Vary"))
+}
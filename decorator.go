@@ -0,0 +1,147 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"aahframe.work/log"
+)
+
+var _ ReporterDetails = (reporterFunc)(nil)
+
+// Decorator func type wraps a reporter to layer a cross-cutting behavior
+// onto it, refer to `Wrap`.
+type Decorator func(r Reporter) Reporter
+
+// Wrap method returns the reporter wrapped with given decorators, the first
+// decorator is the outermost one, e.g.:
+//
+//	health.Wrap(dbReporter,
+//	    health.LoggingDecorator("db", logger),
+//	    health.RetryDecorator(2, 100*time.Millisecond),
+//	    health.TimeoutDecorator(3*time.Second),
+//	)
+//
+// Here every attempt is bounded by 3s timeout and logged once after retries.
+func Wrap(r Reporter, decorators ...Decorator) Reporter {
+	for i := len(decorators) - 1; i >= 0; i-- {
+		r = decorators[i](r)
+	}
+	return r
+}
+
+// TimeoutDecorator method returns the decorator which fails the check once
+// given timeout elapses. The context is cancelled on timeout, check of the
+// reporter which does not implement `ReporterContext` keeps running in the
+// background until it returns. Panic raised by the reporter is recovered
+// and returned as an error.
+func TimeoutDecorator(timeout time.Duration) Decorator {
+	return func(r Reporter) Reporter {
+		return reporterFunc(func(ctx context.Context) (map[string]interface{}, error) {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			type outcome struct {
+				details map[string]interface{}
+				err     error
+			}
+			done := make(chan outcome, 1)
+			go func() {
+				details, err := checkDetails(ctx, r)
+				done <- outcome{details: details, err: err}
+			}()
+			select {
+			case o := <-done:
+				return o.details, o.err
+			case <-ctx.Done():
+				return nil, fmt.Errorf("check timed out after %s", timeout)
+			}
+		})
+	}
+}
+
+// RetryDecorator method returns the decorator which retries the failing
+// check up to given retries. Backoff is the delay before the first retry,
+// it doubles on every subsequent retry. Refer to `Config.Retries` to retry
+// the check at collector level.
+func RetryDecorator(retries int, backoff time.Duration) Decorator {
+	return func(r Reporter) Reporter {
+		return reporterFunc(func(ctx context.Context) (map[string]interface{}, error) {
+			details, err := callReporter(ctx, r)
+			delay := backoff
+			for i := 0; err != nil && i < retries; i++ {
+				if delay > 0 {
+					t := time.NewTimer(delay)
+					select {
+					case <-t.C:
+					case <-ctx.Done():
+						t.Stop()
+						return details, err
+					}
+					delay *= 2
+				}
+				details, err = callReporter(ctx, r)
+			}
+			return details, err
+		})
+	}
+}
+
+// LoggingDecorator method returns the decorator which logs the outcome of
+// the check with given name, failures are logged as warning and successes
+// as debug.
+func LoggingDecorator(name string, logger log.Loggerer) Decorator {
+	return func(r Reporter) Reporter {
+		return reporterFunc(func(ctx context.Context) (map[string]interface{}, error) {
+			start := time.Now()
+			details, err := callReporter(ctx, r)
+			if err != nil {
+				logger.Warnf("health: reporter '%s' check failed in %s: %v", name, time.Since(start), err)
+			} else {
+				logger.Debugf("health: reporter '%s' check passed in %s", name, time.Since(start))
+			}
+			return details, err
+		})
+	}
+}
+
+// MetricsDecorator method returns the decorator which calls given observe
+// func with the duration and error of every check, for e.g. to record it
+// into application metrics.
+func MetricsDecorator(observe func(d time.Duration, err error)) Decorator {
+	return func(r Reporter) Reporter {
+		return reporterFunc(func(ctx context.Context) (map[string]interface{}, error) {
+			start := time.Now()
+			details, err := callReporter(ctx, r)
+			observe(time.Since(start), err)
+			return details, err
+		})
+	}
+}
+
+// reporterFunc func type adapts a check func to `Reporter`,
+// `ReporterContext` and `ReporterDetails`.
+type reporterFunc func(ctx context.Context) (map[string]interface{}, error)
+
+// Check method performs the check with background context.
+func (f reporterFunc) Check() error {
+	_, err := f(context.Background())
+	return err
+}
+
+// CheckContext method performs the check with given context.
+func (f reporterFunc) CheckContext(ctx context.Context) error {
+	_, err := f(ctx)
+	return err
+}
+
+// CheckDetails method performs the check with given context and returns
+// its details.
+func (f reporterFunc) CheckDetails(ctx context.Context) (map[string]interface{}, error) {
+	return f(ctx)
+}
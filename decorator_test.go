@@ -0,0 +1,59 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthWrapDecorators(t *testing.T) {
+	logger := &warnLogger{}
+	reporter := &flakyReporter{failures: 5}
+	var observed []error
+	r := Wrap(reporter,
+		LoggingDecorator("db", logger),
+		MetricsDecorator(func(_ time.Duration, err error) { observed = append(observed, err) }),
+		RetryDecorator(2, time.Millisecond),
+	)
+
+	err := r.Check()
+	assert.Equal(t, "connection reset", err.Error())
+	assert.Equal(t, int32(3), atomic.LoadInt32(&reporter.calls))
+	assert.Len(t, observed, 1)
+	assert.Len(t, logger.warnings, 1)
+	assert.Contains(t, logger.warnings[0], "health: reporter 'db' check failed in")
+
+	collector := newCollector()
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: Wrap(&flakyReporter{failures: 1}, RetryDecorator(1, 0))})
+	collector.runChecks()
+	assert.True(t, collector.Results()["db"].IsOK())
+}
+
+func TestHealthTimeoutDecorator(t *testing.T) {
+	r := Wrap(&ctxReporter{delay: time.Second}, TimeoutDecorator(10*time.Millisecond))
+	err := r.Check()
+	assert.Equal(t, "check timed out after 10ms", err.Error())
+
+	r = Wrap(&ctxReporter{}, TimeoutDecorator(time.Second))
+	assert.Nil(t, r.Check())
+
+	r = Wrap(panicReporter{}, TimeoutDecorator(time.Second))
+	assert.Equal(t, "panic: boom", r.Check().Error())
+
+	details, err := Wrap(AllOf(&toggleReporter{}), TimeoutDecorator(time.Second)).(ReporterDetails).
+		CheckDetails(newCollector().ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"0"}, details["passed"])
+
+	failing := &toggleReporter{}
+	failing.set(errors.New("unreachable"))
+	r = Wrap(failing, TimeoutDecorator(time.Second))
+	assert.Equal(t, "unreachable", r.Check().Error())
+}
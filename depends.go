@@ -0,0 +1,121 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"fmt"
+	"sort"
+)
+
+// checkDependencies method returns an error if given reporter config depends
+// on itself directly or through other reporters. Dependencies which are not
+// added yet are allowed. Caller must hold the lock.
+func (c *Collector) checkDependencies(config *Config) error {
+	visited := make(map[string]bool)
+	var visit func(name string) bool
+	visit = func(name string) bool {
+		if name == config.Name {
+			return true
+		}
+		if visited[name] {
+			return false
+		}
+		visited[name] = true
+		if rc, found := c.reporters[name]; found {
+			for _, parent := range rc.DependsOn {
+				if visit(parent) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	for _, parent := range config.DependsOn {
+		if visit(parent) {
+			return fmt.Errorf("health: reporter '%s' has circular dependency on '%s'", config.Name, parent)
+		}
+	}
+	return nil
+}
+
+// dependencyLevels method returns the indexes of given reporters grouped by
+// dependency level, reporters of a level depend only on the reporters of
+// previous levels. Dependencies outside of given reporters are not considered.
+func dependencyLevels(reporters []*Config) [][]int {
+	index := make(map[string]int, len(reporters))
+	for i, rc := range reporters {
+		index[rc.Name] = i
+	}
+	levels := make([]int, len(reporters))
+	for i := range levels {
+		levels[i] = -1
+	}
+	var level func(i int, visiting map[int]bool) int
+	level = func(i int, visiting map[int]bool) int {
+		if levels[i] >= 0 {
+			return levels[i]
+		}
+		visiting[i] = true
+		l := 0
+		for _, parent := range reporters[i].DependsOn {
+			j, found := index[parent]
+			if !found || visiting[j] {
+				continue
+			}
+			if pl := level(j, visiting) + 1; pl > l {
+				l = pl
+			}
+		}
+		delete(visiting, i)
+		levels[i] = l
+		return l
+	}
+
+	var groups [][]int
+	for i := range reporters {
+		l := level(i, make(map[int]bool))
+		for len(groups) <= l {
+			groups = append(groups, nil)
+		}
+		groups[l] = append(groups[l], i)
+	}
+	for _, g := range groups {
+		sort.Ints(g)
+	}
+	return groups
+}
+
+// downDependency method returns the name of the reporter's dependency
+// which is unhealthy, it is looked up in given results of the current check
+// run first and then in the last results. It returns empty if none.
+func (c *Collector) downDependency(rc *Config, index map[string]int, results []*CheckResult) string {
+	last := c.load().results
+	for _, parent := range rc.DependsOn {
+		if i, found := index[parent]; found {
+			if results[i] != nil && !results[i].IsOK() {
+				return parent
+			}
+			continue
+		}
+		if result, found := last[parent]; found && !result.IsOK() {
+			return parent
+		}
+	}
+	return ""
+}
+
+// skippedResult method returns the result of the reporter which is not
+// checked because of its unhealthy dependency.
+func (c *Collector) skippedResult(rc *Config, parent string) *CheckResult {
+	return &CheckResult{
+		Status:      StatusKO,
+		Error:       fmt.Sprintf("skipped: upstream dependency %s down", parent),
+		ErrorType:   ErrorTypeDependency,
+		LastChecked: c.clock.Now(),
+		SoftFail:    rc.severity() != SeverityCritical,
+		Severity:    rc.severity(),
+		Skipped:     true,
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type countReporter struct {
+	toggleReporter
+	calls int32
+}
+
+func (r *countReporter) Check() error {
+	atomic.AddInt32(&r.calls, 1)
+	return r.toggleReporter.Check()
+}
+
+func TestHealthDependsOn(t *testing.T) {
+	collector := newCollector()
+	vpn, db, api := &countReporter{}, &countReporter{}, &countReporter{}
+	_ = collector.AddReporter(&Config{Name: "api", Reporter: api, DependsOn: []string{"db"}})
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: db, DependsOn: []string{"vpn"}})
+	_ = collector.AddReporter(&Config{Name: "vpn", Reporter: vpn})
+
+	collector.runChecks()
+	assert.True(t, collector.IsHealthy())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&api.calls))
+
+	vpn.set(errors.New("link down"))
+	collector.runChecks()
+	results := collector.Results()
+	assert.Equal(t, "link down", results["vpn"].Error)
+	assert.False(t, results["vpn"].Skipped)
+	assert.Equal(t, StatusKO, results["db"].Status)
+	assert.True(t, results["db"].Skipped)
+	assert.Equal(t, "skipped: upstream dependency vpn down", results["db"].Error)
+	assert.True(t, results["api"].Skipped)
+	assert.Equal(t, "skipped: upstream dependency db down", results["api"].Error)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&db.calls))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&api.calls))
+
+	vpn.set(nil)
+	collector.runChecks()
+	assert.True(t, collector.IsHealthy())
+	assert.False(t, collector.Results()["api"].Skipped)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&api.calls))
+}
+
+func TestHealthDependsOnCycle(t *testing.T) {
+	collector := newCollector()
+	assert.Nil(t, collector.AddReporter(&Config{Name: "a", Reporter: &toggleReporter{}, DependsOn: []string{"b"}}))
+	assert.Nil(t, collector.AddReporter(&Config{Name: "b", Reporter: &toggleReporter{}, DependsOn: []string{"c"}}))
+
+	err := collector.AddReporter(&Config{Name: "c", Reporter: &toggleReporter{}, DependsOn: []string{"a"}})
+	assert.Equal(t, "health: reporter 'c' has circular dependency on 'a'", err.Error())
+
+	err = collector.UpdateReporter(&Config{Name: "a", Reporter: &toggleReporter{}, DependsOn: []string{"a"}})
+	assert.Equal(t, "health: reporter 'a' has circular dependency on 'a'", err.Error())
+}
+
+func TestHealthDependencyLevels(t *testing.T) {
+	reporters := []*Config{
+		{Name: "api", DependsOn: []string{"db", "cache"}},
+		{Name: "db", DependsOn: []string{"vpn"}},
+		{Name: "vpn"},
+		{Name: "cache", DependsOn: []string{"unknown"}},
+	}
+	assert.Equal(t, [][]int{{2, 3}, {1}, {0}}, dependencyLevels(reporters))
+}
@@ -0,0 +1,82 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"context"
+	"time"
+)
+
+// MetricsEmitter interface pushes the check run metrics to a metrics
+// backend, for the environments which cannot be scraped by Prometheus
+// (refer to `Collector.WriteMetrics`). Refer to `WithMetricsEmitter`,
+// `StatsDEmitter` and package `healthotel` for OpenTelemetry implementation.
+type MetricsEmitter interface {
+	// Emit pushes the metrics of a check run, returned error is logged by
+	// the collector. It is called after every check run, so it must not
+	// block for long.
+	Emit(ctx context.Context, m *CycleMetrics) error
+}
+
+// CycleMetrics struct holds the metrics of a check run.
+type CycleMetrics struct {
+	// Time of the check run completion.
+	Time time.Time
+
+	// Status is the aggregate status of the collector after the check run.
+	Status AggregateStatus
+
+	// Checks holds the metrics of the reporters checked in the run, skipped
+	// reporters are excluded.
+	Checks []CheckMetrics
+}
+
+// CheckMetrics struct holds the metrics of a reporter's check.
+type CheckMetrics struct {
+	Name     string
+	Status   Status
+	Severity Severity
+	Duration time.Duration
+}
+
+// Healthy method returns true if the aggregate status is not `Unhealthy`.
+func (m *CycleMetrics) Healthy() bool {
+	return m.Status != Unhealthy
+}
+
+// emitMetrics method pushes the metrics of given check results to the
+// emitters of the collector.
+func (c *Collector) emitMetrics(ctx context.Context, reporters []*Config, results []*CheckResult) {
+	c.mu.RLock()
+	emitters := c.emitters
+	c.mu.RUnlock()
+	if len(emitters) == 0 {
+		return
+	}
+
+	m := &CycleMetrics{
+		Time:   c.clock.Now(),
+		Status: c.load().status,
+		Checks: make([]CheckMetrics, 0, len(results)),
+	}
+	for i, result := range results {
+		if result == nil || result.Skipped {
+			continue
+		}
+		m.Checks = append(m.Checks, CheckMetrics{
+			Name:     reporters[i].Name,
+			Status:   result.Status,
+			Severity: result.Severity,
+			Duration: result.Duration,
+		})
+	}
+	for _, e := range emitters {
+		if err := e.Emit(ctx, m); err != nil {
+			if logger := c.log(); logger != nil {
+				logger.Errorf("health: unable to emit metrics: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxStatsDPacketSize keeps the UDP datagram within the common network MTU.
+const maxStatsDPacketSize = 1432
+
+var _ MetricsEmitter = (*StatsDEmitter)(nil)
+
+var statsdNameReplacer = strings.NewReplacer(":", "_", "|", "_", "@", "_", "#", "_", ",", "_", " ", "_", "\n", "_")
+
+// StatsDEmitterOptions struct holds the configuration of `StatsDEmitter`.
+type StatsDEmitterOptions struct {
+	// Addr of the StatsD server or Datadog agent, default is
+	// `127.0.0.1:8125`.
+	Addr string
+
+	// Prefix of the metric names, default is `health.`.
+	Prefix string
+
+	// Datadog enables DogStatsD tags, reporter name is sent as tag
+	// `reporter:<name>` instead of the metric name segment.
+	Datadog bool
+
+	// Tags added to all the metrics, for e.g. `env:prod`. DogStatsD only.
+	Tags []string
+}
+
+// StatsDEmitter struct pushes the check run metrics to StatsD or Datadog
+// agent over UDP.
+//
+// Emitted metrics (plain StatsD):
+//
+//	health.up                      - global health status (gauge)
+//	health.degraded                - global degraded status (gauge)
+//	health.check.<name>.up         - reporter health status (gauge)
+//	health.check.<name>.duration   - reporter check duration (timing)
+//	health.check.<name>.success    - reporter successful checks (counter)
+//	health.check.<name>.failure    - reporter failed checks (counter)
+//
+// With DogStatsD reporter metrics are named `health.check.up` etc. and
+// tagged with `reporter:<name>`.
+type StatsDEmitter struct {
+	opts StatsDEmitterOptions
+	tags string
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// NewStatsDEmitter method returns a `StatsDEmitter` instance for given
+// options.
+//
+//	emitter, err := health.NewStatsDEmitter(health.StatsDEmitterOptions{
+//	    Datadog: true,
+//	    Tags:    []string{"service:orders"},
+//	})
+//	collector := health.NewCollector(health.WithMetricsEmitter(emitter))
+func NewStatsDEmitter(opts StatsDEmitterOptions) (*StatsDEmitter, error) {
+	if opts.Addr == "" {
+		opts.Addr = "127.0.0.1:8125"
+	}
+	if opts.Prefix == "" {
+		opts.Prefix = "health."
+	}
+	conn, err := net.Dial("udp", opts.Addr)
+	if err != nil {
+		return nil, err
+	}
+	e := &StatsDEmitter{opts: opts, conn: conn}
+	if opts.Datadog && len(opts.Tags) > 0 {
+		e.tags = strings.Join(opts.Tags, ",")
+	}
+	return e, nil
+}
+
+// Emit method sends the metrics of the check run, metrics are batched into
+// as few datagrams as possible.
+func (e *StatsDEmitter) Emit(_ context.Context, m *CycleMetrics) error {
+	lines := make([]string, 0, 2+4*len(m.Checks))
+	lines = append(lines,
+		e.line("up", "", boolToMetric(m.Healthy()), "g"),
+		e.line("degraded", "", boolToMetric(m.Status == Degraded), "g"),
+	)
+	for _, cm := range m.Checks {
+		outcome := "success"
+		if cm.Status != StatusOK {
+			outcome = "failure"
+		}
+		lines = append(lines,
+			e.line("up", cm.Name, boolToMetric(cm.Status == StatusOK), "g"),
+			e.line("duration", cm.Name, formatFloat(float64(cm.Duration)/float64(time.Millisecond)), "ms"),
+			e.line(outcome, cm.Name, "1", "c"),
+		)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var packet []byte
+	for _, l := range lines {
+		if len(packet) > 0 && len(packet)+1+len(l) > maxStatsDPacketSize {
+			if _, err := e.conn.Write(packet); err != nil {
+				return err
+			}
+			packet = packet[:0]
+		}
+		if len(packet) > 0 {
+			packet = append(packet, '\n')
+		}
+		packet = append(packet, l...)
+	}
+	_, err := e.conn.Write(packet)
+	return err
+}
+
+// Close method closes the UDP connection of the emitter.
+func (e *StatsDEmitter) Close() error {
+	return e.conn.Close()
+}
+
+// line method returns the StatsD line of given metric, reporter metrics are
+// prefixed with `check.`.
+func (e *StatsDEmitter) line(metric, reporter, value, typ string) string {
+	name, tags := e.opts.Prefix+metric, e.tags
+	if len(reporter) > 0 {
+		if e.opts.Datadog {
+			name = e.opts.Prefix + "check." + metric
+			tag := "reporter:" + statsdNameReplacer.Replace(reporter)
+			if len(tags) > 0 {
+				tags += "," + tag
+			} else {
+				tags = tag
+			}
+		} else {
+			name = e.opts.Prefix + "check." + statsdNameReplacer.Replace(reporter) + "." + metric
+		}
+	}
+	l := name + ":" + value + "|" + typ
+	if len(tags) > 0 {
+		l += "|#" + tags
+	}
+	return l
+}
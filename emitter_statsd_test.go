@@ -0,0 +1,102 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func readStatsD(t *testing.T, conn net.PacketConn) []string {
+	buf := make([]byte, 65536)
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(string(buf[:n]), "\n")
+}
+
+func TestHealthStatsDEmitter(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	m := &CycleMetrics{
+		Status: Degraded,
+		Checks: []CheckMetrics{
+			{Name: "db", Status: StatusOK, Duration: 1500 * time.Microsecond},
+			{Name: "cache:redis", Status: StatusKO, Severity: SeverityWarning, Duration: 3 * time.Millisecond},
+		},
+	}
+
+	e, err := NewStatsDEmitter(StatsDEmitterOptions{Addr: conn.LocalAddr().String()})
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer e.Close()
+	assert.NoError(t, e.Emit(context.Background(), m))
+	assert.Equal(t, []string{
+		"health.up:1|g",
+		"health.degraded:1|g",
+		"health.check.db.up:1|g",
+		"health.check.db.duration:1.5|ms",
+		"health.check.db.success:1|c",
+		"health.check.cache_redis.up:0|g",
+		"health.check.cache_redis.duration:3|ms",
+		"health.check.cache_redis.failure:1|c",
+	}, readStatsD(t, conn))
+
+	dd, err := NewStatsDEmitter(StatsDEmitterOptions{
+		Addr:    conn.LocalAddr().String(),
+		Prefix:  "orders.health.",
+		Datadog: true,
+		Tags:    []string{"env:prod"},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer dd.Close()
+	m.Checks = m.Checks[:1]
+	assert.NoError(t, dd.Emit(context.Background(), m))
+	assert.Equal(t, []string{
+		"orders.health.up:1|g|#env:prod",
+		"orders.health.degraded:1|g|#env:prod",
+		"orders.health.check.up:1|g|#env:prod,reporter:db",
+		"orders.health.check.duration:1.5|ms|#env:prod,reporter:db",
+		"orders.health.check.success:1|c|#env:prod,reporter:db",
+	}, readStatsD(t, conn))
+}
+
+func TestHealthStatsDEmitterBatch(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	m := &CycleMetrics{Status: Healthy}
+	for i := 0; i < 40; i++ {
+		m.Checks = append(m.Checks, CheckMetrics{Name: strings.Repeat("r", 20) + string(rune('a'+i%26)), Status: StatusOK})
+	}
+	e, _ := NewStatsDEmitter(StatsDEmitterOptions{Addr: conn.LocalAddr().String()})
+	defer e.Close()
+	assert.NoError(t, e.Emit(context.Background(), m))
+
+	var lines int
+	for lines < 2+3*len(m.Checks) {
+		packet := readStatsD(t, conn)
+		assert.True(t, len(strings.Join(packet, "\n")) <= maxStatsDPacketSize)
+		lines += len(packet)
+	}
+	assert.Equal(t, 2+3*len(m.Checks), lines)
+}
@@ -0,0 +1,52 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordEmitter struct {
+	metrics []*CycleMetrics
+}
+
+func (e *recordEmitter) Emit(_ context.Context, m *CycleMetrics) error {
+	e.metrics = append(e.metrics, m)
+	return errors.New("backend unavailable") // logged only
+}
+
+func TestHealthMetricsEmitter(t *testing.T) {
+	emitter := &recordEmitter{}
+	collector := newCollector()
+	WithMetricsEmitter(emitter)(collector)
+	WithMetricsEmitter(nil)(collector)
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: &toggleReporter{}})
+	_ = collector.AddReporter(&Config{Name: "cache", Reporter: &toggleReporter{err: errors.New("down")}})
+	_ = collector.AddReporter(&Config{Name: "search", Reporter: &toggleReporter{}, DependsOn: []string{"cache"}})
+	collector.runChecks()
+
+	if !assert.Len(t, emitter.metrics, 1) {
+		return
+	}
+	m := emitter.metrics[0]
+	assert.Equal(t, Unhealthy, m.Status)
+	assert.False(t, m.Healthy())
+	assert.False(t, m.Time.IsZero())
+
+	// skipped reporter is not checked, so not emitted
+	sort.Slice(m.Checks, func(i, j int) bool { return m.Checks[i].Name < m.Checks[j].Name })
+	if assert.Len(t, m.Checks, 2) {
+		assert.Equal(t, "cache", m.Checks[0].Name)
+		assert.Equal(t, StatusKO, m.Checks[0].Status)
+		assert.Equal(t, SeverityCritical, m.Checks[0].Severity)
+		assert.Equal(t, "db", m.Checks[1].Name)
+		assert.Equal(t, StatusOK, m.Checks[1].Status)
+	}
+}
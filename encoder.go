@@ -0,0 +1,231 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	_ ResponseEncoder = JSONEncoder{}
+	_ ResponseEncoder = HealthJSONEncoder{}
+)
+
+// ResponseEncoder interface customizes the wire format of the health check
+// response, such as field names, envelope and content type, for e.g. to
+// match a corporate health schema. Collector supplies the structured report
+// and encoder writes it. Refer to `RegisterOptions.Encoder`.
+//
+//	type statusEncoder struct{}
+//
+//	func (statusEncoder) ContentType() string { return "application/json" }
+//
+//	func (statusEncoder) Encode(w io.Writer, r *health.HealthReport) error {
+//	    return json.NewEncoder(w).Encode(map[string]interface{}{
+//	        "up":   r.Status != health.Unhealthy,
+//	        "host": r.Instance,
+//	    })
+//	}
+type ResponseEncoder interface {
+	// ContentType returns the content type of the encoded response.
+	ContentType() string
+
+	// Encode writes the health check response of given report.
+	Encode(w io.Writer, report *HealthReport) error
+}
+
+// HealthReport struct is the structured snapshot of the health check
+// response supplied to `ResponseEncoder`. Checks are nil for
+// `ExposureSummary`, stale results are flagged.
+type HealthReport struct {
+	Status    AggregateStatus
+	Score     float64
+	Checks    map[string]CheckResult
+	Exposure  string
+	Service   string
+	Version   string
+	BuildTime string
+	Instance  string
+	Uptime    time.Duration
+	Timestamp time.Time
+	Metadata  map[string]string
+
+	// Paused is true while the periodic checks are paused, refer to
+	// `Collector.Pause`.
+	Paused bool
+
+	// Pretty is true if human readable response is requested, that is
+	// query parameter `pretty=true`.
+	Pretty bool
+}
+
+// JSONEncoder struct encodes the `FormatJSON` response, reporter's check
+// results keyed by name. Envelope wraps them with the metadata of the
+// application, refer to `RegisterOptions.Envelope`.
+type JSONEncoder struct {
+	Envelope bool
+}
+
+// ContentType method returns the JSON content type.
+func (e JSONEncoder) ContentType() string {
+	return jsonContentType
+}
+
+// Encode method writes the report as JSON.
+func (e JSONEncoder) Encode(w io.Writer, report *HealthReport) error {
+	return encodeJSON(w, jsonValue(report, e.Envelope), report.Pretty)
+}
+
+// jsonValue returns the value of `FormatJSON` response for given report.
+func jsonValue(report *HealthReport, envelope bool) interface{} {
+	switch {
+	case envelope:
+		return &envelopeJSON{
+			Status:    report.Status,
+			Score:     report.Score,
+			Service:   report.Service,
+			Version:   report.Version,
+			BuildTime: report.BuildTime,
+			Instance:  report.Instance,
+			Uptime:    report.Uptime.Seconds(),
+			Timestamp: report.Timestamp,
+			Metadata:  report.Metadata,
+			Paused:    report.Paused,
+			Checks:    report.Checks,
+		}
+	case report.Exposure == ExposureSummary:
+		return &summaryJSON{Status: report.Status, Paused: report.Paused}
+	}
+	return report.Checks
+}
+
+// HealthJSONEncoder struct encodes the `FormatHealthJSON` response.
+type HealthJSONEncoder struct{}
+
+// ContentType method returns the `application/health+json` content type.
+func (e HealthJSONEncoder) ContentType() string {
+	return HealthJSONContentType
+}
+
+// Encode method writes the report as per IETF draft "Health Check Response
+// Format for HTTP APIs".
+func (e HealthJSONEncoder) Encode(w io.Writer, report *HealthReport) error {
+	return encodeJSON(w, newHealthJSON(report), report.Pretty)
+}
+
+// encoderOf returns the built-in encoder of the response format.
+func encoderOf(opts RegisterOptions) ResponseEncoder {
+	switch opts.Format {
+	case FormatHealthJSON:
+		return HealthJSONEncoder{}
+	case FormatXML:
+		return XMLEncoder{}
+	case FormatYAML:
+		return YAMLEncoder{Envelope: opts.Envelope}
+	}
+	return JSONEncoder{Envelope: opts.Envelope}
+}
+
+// mediaTypeFormats maps the media types of `Accept` header to the response
+// formats.
+var mediaTypeFormats = map[string]string{
+	"application/json":        FormatJSON,
+	"application/health+json": FormatHealthJSON,
+	"application/xml":         FormatXML,
+	"text/xml":                FormatXML,
+	"application/yaml":        FormatYAML,
+	"application/x-yaml":      FormatYAML,
+	"text/yaml":               FormatYAML,
+	"text/x-yaml":             FormatYAML,
+}
+
+// negotiateEncoder returns the encoder of the format requested by query
+// parameter `format` or else the `Accept` header, otherwise the configured
+// encoder. Configured encoder is preferred when its content type is
+// accepted. Unknown `format` value is an error, whereas unknown media types
+// of `Accept` header are ignored.
+func negotiateEncoder(opts RegisterOptions, r *http.Request) (ResponseEncoder, error) {
+	if format := r.URL.Query().Get("format"); len(format) > 0 {
+		switch format {
+		case FormatJSON, FormatHealthJSON, FormatXML, FormatYAML:
+			opts.Format = format
+			return encoderOf(opts), nil
+		}
+		return nil, fmt.Errorf("health: unsupported response format '%s'", format)
+	}
+
+	configured := mediaType(opts.Encoder.ContentType())
+	for _, mt := range acceptedMediaTypes(r.Header) {
+		switch {
+		case mt == configured, mt == "*/*", mt == "application/*":
+			return opts.Encoder, nil
+		case len(mediaTypeFormats[mt]) > 0:
+			opts.Format = mediaTypeFormats[mt]
+			return encoderOf(opts), nil
+		}
+	}
+	return opts.Encoder, nil
+}
+
+// acceptedMediaTypes returns the media types of `Accept` header in the
+// order of preference, media types of q=0 are excluded.
+func acceptedMediaTypes(hdr http.Header) []string {
+	type accepted struct {
+		mediaType string
+		q         float64
+	}
+	var all []accepted
+	for _, v := range hdr.Values("Accept") {
+		for _, mr := range strings.Split(v, ",") {
+			mt, params, _ := strings.Cut(mr, ";")
+			a := accepted{mediaType: strings.ToLower(strings.TrimSpace(mt)), q: 1}
+			for _, p := range strings.Split(params, ";") {
+				if name, value, found := strings.Cut(p, "="); found && strings.TrimSpace(name) == "q" {
+					if q, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+						a.q = q
+					}
+				}
+			}
+			if len(a.mediaType) > 0 && a.q > 0 {
+				all = append(all, a)
+			}
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool { return all[i].q > all[j].q })
+	types := make([]string, len(all))
+	for i, a := range all {
+		types[i] = a.mediaType
+	}
+	return types
+}
+
+// mediaType returns the media type of given content type without its
+// parameters.
+func mediaType(contentType string) string {
+	mt, _, _ := strings.Cut(contentType, ";")
+	return strings.ToLower(strings.TrimSpace(mt))
+}
+
+func encodeJSON(w io.Writer, v interface{}, pretty bool) error {
+	var b []byte
+	var err error
+	if pretty {
+		b, err = json.MarshalIndent(v, "", "  ")
+	} else {
+		b, err = json.Marshal(v)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
@@ -0,0 +1,199 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// lineEncoder writes the report as `key=value` lines.
+type lineEncoder struct{}
+
+func (lineEncoder) ContentType() string { return "text/x-health" }
+
+func (lineEncoder) Encode(w io.Writer, r *HealthReport) error {
+	lines := []string{"service=" + r.Service, "state=" + string(r.Status)}
+	for name, result := range r.Checks {
+		lines = append(lines, "check."+name+"="+string(result.Status))
+	}
+	sort.Strings(lines)
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return err
+}
+
+func TestHealthResponseEncoder(t *testing.T) {
+	collector := newCollector()
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: &toggleReporter{err: errors.New("down")}})
+	collector.runChecks()
+
+	h, err := collector.HandlerWithOptions(RegisterOptions{Encoder: lineEncoder{}, ServiceID: "orders"})
+	assert.Nil(t, err)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthcheck", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "text/x-health", w.Header().Get("Content-Type"))
+	assert.Equal(t, "check.db=KO\nservice=orders\nstate=unhealthy\n", w.Body.String())
+
+	// checks are not supplied for summary exposure
+	h, _ = collector.HandlerWithOptions(RegisterOptions{Encoder: lineEncoder{}, Exposure: ExposureSummary})
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthcheck", nil))
+	assert.Equal(t, "service=\nstate=unhealthy\n", w.Body.String())
+}
+
+func TestHealthBuiltinEncoders(t *testing.T) {
+	report := &HealthReport{
+		Status: Healthy,
+		Checks: map[string]CheckResult{"db": {Status: StatusOK}},
+	}
+
+	buf := new(bytes.Buffer)
+	assert.Nil(t, JSONEncoder{}.Encode(buf, report))
+	assert.JSONEq(t, `{"db": {"status": "OK", "lastChecked": "0001-01-01T00:00:00Z", "duration": 0, "softFail": false}}`, buf.String())
+	assert.Equal(t, jsonContentType, JSONEncoder{}.ContentType())
+
+	buf.Reset()
+	report.Pretty = true
+	assert.Nil(t, HealthJSONEncoder{}.Encode(buf, report))
+	assert.Contains(t, buf.String(), "\n  \"status\": \"pass\"")
+	assert.Equal(t, HealthJSONContentType, HealthJSONEncoder{}.ContentType())
+
+	buf.Reset()
+	report.Pretty, report.Exposure, report.Checks = false, ExposureSummary, nil
+	assert.Nil(t, JSONEncoder{}.Encode(buf, report))
+	assert.Equal(t, `{"status":"healthy"}`, buf.String())
+
+	assert.Equal(t, HealthJSONEncoder{}, encoderOf(RegisterOptions{Format: FormatHealthJSON}))
+	assert.Equal(t, JSONEncoder{Envelope: true}, encoderOf(RegisterOptions{Format: FormatJSON, Envelope: true}))
+}
+
+func TestHealthXMLEncoder(t *testing.T) {
+	checked := time.Date(2019, 3, 1, 10, 0, 0, 0, time.UTC)
+	report := &HealthReport{
+		Status:    Degraded,
+		Score:     50,
+		Instance:  "web-1",
+		Timestamp: checked,
+		Metadata:  map[string]string{"region": "us-east-1"},
+		Checks: map[string]CheckResult{
+			"db": {Status: StatusOK, Duration: 1500 * time.Microsecond, LastChecked: checked,
+				Metadata: map[string]string{"owner": "payments"}},
+			"cache": {Status: StatusKO, Error: "refused <tcp>", LastChecked: checked, SoftFail: true,
+				Details: map[string]interface{}{"host": "cache-1"}},
+		},
+	}
+	buf := new(bytes.Buffer)
+	assert.Nil(t, XMLEncoder{}.Encode(buf, report))
+	assert.Equal(t, xml.Header+`<health status="degraded" score="50" instance="web-1" uptimeSeconds="0" timestamp="2019-03-01T10:00:00Z">`+
+		`<metadata><entry key="region">us-east-1</entry></metadata>`+
+		`<check name="cache" status="KO" softFail="true" durationMs="0" lastChecked="2019-03-01T10:00:00Z">`+
+		`<error>refused &lt;tcp&gt;</error><detail key="host">cache-1</detail></check>`+
+		`<check name="db" status="OK" softFail="false" durationMs="1.5" lastChecked="2019-03-01T10:00:00Z">`+
+		`<metadata><entry key="owner">payments</entry></metadata></check>`+
+		`</health>`, buf.String())
+}
+
+func TestHealthYAMLEncoder(t *testing.T) {
+	checked := time.Date(2019, 3, 1, 10, 0, 0, 0, time.UTC)
+	report := &HealthReport{
+		Status:    Healthy,
+		Score:     100,
+		Instance:  "web-1",
+		Timestamp: checked,
+		Checks: map[string]CheckResult{
+			"db": {Status: StatusOK, LastChecked: checked, Details: map[string]interface{}{
+				"hosts": []string{"db-1", "db-2"},
+				"note":  "yes",
+			}},
+		},
+	}
+	buf := new(bytes.Buffer)
+	assert.Nil(t, YAMLEncoder{}.Encode(buf, report))
+	assert.Equal(t, `---
+db:
+  details:
+    hosts:
+      - db-1
+      - db-2
+    note: "yes"
+  duration: 0
+  lastChecked: "2019-03-01T10:00:00Z"
+  softFail: false
+  status: OK
+`, buf.String())
+
+	buf.Reset()
+	report.Exposure, report.Checks = ExposureSummary, nil
+	assert.Nil(t, YAMLEncoder{Envelope: true}.Encode(buf, report))
+	assert.Equal(t, `---
+instance: web-1
+score: 100
+status: healthy
+timestamp: "2019-03-01T10:00:00Z"
+uptimeSeconds: 0
+`, buf.String())
+}
+
+func TestHealthFormatNegotiation(t *testing.T) {
+	collector := newCollector()
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: &toggleReporter{}})
+	collector.runChecks()
+	h := collector.Handler()
+
+	get := func(p, accept string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, p, nil)
+		if len(accept) > 0 {
+			r.Header.Set("Accept", accept)
+		}
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	w := get("/healthcheck", "")
+	assert.Equal(t, jsonContentType, w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Values("Vary"), "Accept")
+
+	w = get("/healthcheck?format=xml", "application/json")
+	assert.Equal(t, XMLContentType, w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `<check name="db" status="OK"`)
+
+	w = get("/healthcheck", "text/html, application/xml;q=0.9, application/yaml")
+	assert.Equal(t, YAMLContentType, w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "\n  status: OK\n")
+
+	w = get("/healthcheck", "application/health+json")
+	assert.Equal(t, HealthJSONContentType, w.Header().Get("Content-Type"))
+
+	w = get("/healthcheck", "text/html, */*;q=0.8")
+	assert.Equal(t, jsonContentType, w.Header().Get("Content-Type"))
+
+	w = get("/healthcheck?format=csv", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "health: unsupported response format 'csv'\n", w.Body.String())
+
+	// configured encoder is kept for its own media type
+	h, _ = collector.HandlerWithOptions(RegisterOptions{Encoder: lineEncoder{}})
+	w = get("/healthcheck", "text/x-health, application/json;q=0.5")
+	assert.Equal(t, "text/x-health", w.Header().Get("Content-Type"))
+	w = get("/healthcheck", "application/json")
+	assert.Equal(t, jsonContentType, w.Header().Get("Content-Type"))
+
+	// validators differ by format
+	h = collector.Handler()
+	assert.NotEqual(t, get("/healthcheck", "").Header().Get("ETag"), get("/healthcheck?format=yaml", "").Header().Get("ETag"))
+}
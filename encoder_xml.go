@@ -0,0 +1,158 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+var _ ResponseEncoder = XMLEncoder{}
+
+// XMLContentType is the content type of `FormatXML` response.
+const XMLContentType = "application/xml; charset=utf-8"
+
+// XMLEncoder struct encodes the `FormatXML` response for the monitoring
+// tools which speak only XML. Root element `health` carries the aggregate
+// status and application metadata as attributes and a `check` element per
+// reporter, e.g.:
+//
+//	<health status="degraded" instance="web-1" timestamp="...">
+//	  <check name="cache" status="KO" softFail="true" durationMs="1.5" lastChecked="...">
+//	    <error>connection refused</error>
+//	  </check>
+//	</health>
+type XMLEncoder struct{}
+
+// xmlHealth struct represents the root element of `FormatXML` response.
+type xmlHealth struct {
+	XMLName   xml.Name        `xml:"health"`
+	Status    AggregateStatus `xml:"status,attr"`
+	Score     float64         `xml:"score,attr"`
+	Service   string          `xml:"service,attr,omitempty"`
+	Version   string          `xml:"version,attr,omitempty"`
+	BuildTime string          `xml:"buildTime,attr,omitempty"`
+	Instance  string          `xml:"instance,attr,omitempty"`
+	Uptime    float64         `xml:"uptimeSeconds,attr"`
+	Timestamp time.Time       `xml:"timestamp,attr"`
+	Paused    bool            `xml:"paused,attr,omitempty"`
+	Metadata  *xmlMetadata    `xml:"metadata,omitempty"`
+	Checks    []xmlCheck      `xml:"check"`
+}
+
+// xmlCheck struct represents the check result of a reporter.
+type xmlCheck struct {
+	Name         string        `xml:"name,attr"`
+	Status       Status        `xml:"status,attr"`
+	Severity     Severity      `xml:"severity,attr,omitempty"`
+	SoftFail     bool          `xml:"softFail,attr"`
+	Duration     float64       `xml:"durationMs,attr"`
+	LastChecked  time.Time     `xml:"lastChecked,attr"`
+	Slow         bool          `xml:"slow,attr,omitempty"`
+	Maintenance  bool          `xml:"maintenance,attr,omitempty"`
+	Muted        bool          `xml:"muted,attr,omitempty"`
+	Flapping     bool          `xml:"flapping,attr,omitempty"`
+	Skipped      bool          `xml:"skipped,attr,omitempty"`
+	CircuitOpen  bool          `xml:"circuitOpen,attr,omitempty"`
+	Stale        bool          `xml:"stale,attr,omitempty"`
+	ErrorType    ErrorType     `xml:"errorType,attr,omitempty"`
+	Error        string        `xml:"error,omitempty"`
+	Availability *Availability `xml:"availability,omitempty"`
+	Metadata     *xmlMetadata  `xml:"metadata,omitempty"`
+	Details      []xmlEntry    `xml:"detail"`
+}
+
+// xmlMetadata struct represents the metadata element.
+type xmlMetadata struct {
+	Entries []xmlEntry `xml:"entry"`
+}
+
+// xmlEntry struct represents a key value pair, value is formatted with
+// `fmt.Sprint`.
+type xmlEntry struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// ContentType method returns the XML content type.
+func (e XMLEncoder) ContentType() string {
+	return XMLContentType
+}
+
+// Encode method writes the report as XML, checks are sorted by name.
+func (e XMLEncoder) Encode(w io.Writer, report *HealthReport) error {
+	h := &xmlHealth{
+		Status:    report.Status,
+		Score:     report.Score,
+		Service:   report.Service,
+		Version:   report.Version,
+		BuildTime: report.BuildTime,
+		Instance:  report.Instance,
+		Uptime:    report.Uptime.Seconds(),
+		Timestamp: report.Timestamp,
+		Paused:    report.Paused,
+		Checks:    make([]xmlCheck, 0, len(report.Checks)),
+	}
+	h.Metadata = newXMLMetadata(report.Metadata)
+	for name, result := range report.Checks {
+		h.Checks = append(h.Checks, xmlCheck{
+			Name:         name,
+			Status:       result.Status,
+			Severity:     result.Severity,
+			SoftFail:     result.SoftFail,
+			Duration:     float64(result.Duration) / float64(time.Millisecond),
+			LastChecked:  result.LastChecked,
+			Slow:         result.Slow,
+			Maintenance:  result.Maintenance,
+			Muted:        result.Muted,
+			Flapping:     result.Flapping,
+			Skipped:      result.Skipped,
+			CircuitOpen:  result.CircuitOpen,
+			Stale:        result.Stale,
+			ErrorType:    result.ErrorType,
+			Error:        result.Error,
+			Availability: result.Availability,
+			Metadata:     newXMLMetadata(result.Metadata),
+			Details:      xmlEntries(result.Details),
+		})
+	}
+	sort.Slice(h.Checks, func(i, j int) bool { return h.Checks[i].Name < h.Checks[j].Name })
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	if report.Pretty {
+		enc.Indent("", "  ")
+	}
+	return enc.Encode(h)
+}
+
+// xmlEntries returns the entries of given details sorted by key.
+func xmlEntries(details map[string]interface{}) []xmlEntry {
+	entries := make([]xmlEntry, 0, len(details))
+	for k, v := range details {
+		entries = append(entries, xmlEntry{Key: k, Value: fmt.Sprint(v)})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	return entries
+}
+
+// newXMLMetadata returns the element of given metadata with entries sorted
+// by key, it returns nil if metadata is empty.
+func newXMLMetadata(metadata map[string]string) *xmlMetadata {
+	if len(metadata) == 0 {
+		return nil
+	}
+	m := &xmlMetadata{Entries: make([]xmlEntry, 0, len(metadata))}
+	for k, v := range metadata {
+		m.Entries = append(m.Entries, xmlEntry{Key: k, Value: v})
+	}
+	sort.Slice(m.Entries, func(i, j int) bool { return m.Entries[i].Key < m.Entries[j].Key })
+	return m
+}
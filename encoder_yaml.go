@@ -0,0 +1,156 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+)
+
+var _ ResponseEncoder = YAMLEncoder{}
+
+// YAMLContentType is the content type of `FormatYAML` response.
+const YAMLContentType = "application/yaml; charset=utf-8"
+
+// YAMLEncoder struct encodes the `FormatYAML` response, its structure and
+// field names are same as `FormatJSON` response. Mappings are sorted by key
+// and strings are double quoted unless plain scalar is unambiguous.
+type YAMLEncoder struct {
+	Envelope bool
+}
+
+// ContentType method returns the YAML content type.
+func (e YAMLEncoder) ContentType() string {
+	return YAMLContentType
+}
+
+// Encode method writes the report as YAML document.
+func (e YAMLEncoder) Encode(w io.Writer, report *HealthReport) error {
+	// JSON round trip gives the field names and values of JSON response
+	b, err := json.Marshal(jsonValue(report, e.Envelope))
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v interface{}
+	if err = dec.Decode(&v); err != nil {
+		return err
+	}
+	buf := new(bytes.Buffer)
+	buf.WriteString("---\n")
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+		writeYAML(buf, v, 0)
+	default:
+		buf.WriteString(yamlScalar(v) + "\n")
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// writeYAML writes given JSON value as YAML block collection at indent.
+func writeYAML(buf *bytes.Buffer, v interface{}, indent int) {
+	pad := strings.Repeat(" ", indent)
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 {
+			buf.WriteString(pad + "{}\n")
+			return
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			buf.WriteString(pad + yamlString(k) + ":")
+			writeYAMLValue(buf, v[k], indent)
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			buf.WriteString(pad + "[]\n")
+			return
+		}
+		for _, item := range v {
+			buf.WriteString(pad + "-")
+			writeYAMLValue(buf, item, indent)
+		}
+	}
+}
+
+// writeYAMLValue writes the value of a mapping key or sequence item, scalar
+// and empty collection stay on the same line.
+func writeYAMLValue(buf *bytes.Buffer, v interface{}, indent int) {
+	switch c := v.(type) {
+	case map[string]interface{}:
+		if len(c) > 0 {
+			buf.WriteString("\n")
+			writeYAML(buf, c, indent+2)
+			return
+		}
+		buf.WriteString(" {}\n")
+	case []interface{}:
+		if len(c) > 0 {
+			buf.WriteString("\n")
+			writeYAML(buf, c, indent+2)
+			return
+		}
+		buf.WriteString(" []\n")
+	default:
+		buf.WriteString(" " + yamlScalar(v) + "\n")
+	}
+}
+
+// yamlScalar returns the YAML scalar of given JSON value.
+func yamlScalar(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		if v {
+			return "true"
+		}
+		return "false"
+	case json.Number:
+		return v.String()
+	case string:
+		return yamlString(v)
+	}
+	return "null"
+}
+
+// yamlString returns the plain scalar of given string if it is not
+// ambiguous, otherwise double quoted scalar. JSON string escapes are valid
+// in YAML double quoted scalar.
+func yamlString(s string) string {
+	if isYAMLPlain(s) {
+		return s
+	}
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+func isYAMLPlain(s string) bool {
+	if len(s) == 0 {
+		return false
+	}
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "y", "n", "null", "~":
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_':
+		case i > 0 && (r >= '0' && r <= '9' || r == '-' || r == '.' || r == '/'):
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,146 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"syscall"
+)
+
+// ErrorType type is the machine-readable class of the check error, so that
+// alert routing can distinguish for e.g. dependency down from credentials
+// rotated. Refer to `CheckResult.ErrorType`.
+type ErrorType string
+
+// Error types of the check result.
+const (
+	// ErrorTypeTimeout means check or the underlying I/O timed out.
+	ErrorTypeTimeout ErrorType = "timeout"
+
+	// ErrorTypeConnectionRefused means dependency is not accepting the
+	// connections.
+	ErrorTypeConnectionRefused ErrorType = "connection_refused"
+
+	// ErrorTypeDNS means host name of the dependency could not be resolved.
+	ErrorTypeDNS ErrorType = "dns"
+
+	// ErrorTypeAuth means dependency rejected the credentials.
+	ErrorTypeAuth ErrorType = "auth"
+
+	// ErrorTypeTLS means TLS handshake or certificate verification failed.
+	ErrorTypeTLS ErrorType = "tls"
+
+	// ErrorTypeDependency means check is skipped because of its unhealthy
+	// dependency, refer to `Config.DependsOn`.
+	ErrorTypeDependency ErrorType = "dependency"
+
+	// ErrorTypePanic means reporter panicked during the check.
+	ErrorTypePanic ErrorType = "panic"
+
+	// ErrorTypeUnknown is the error type of the unclassified errors.
+	ErrorTypeUnknown ErrorType = "unknown"
+)
+
+// TypedError struct is returned by the reporter to classify a particular
+// failure which cannot be inferred from the error itself, for e.g. the
+// rejected credentials of a custom protocol.
+//
+//	return &health.TypedError{Type: health.ErrorTypeAuth, Err: err}
+type TypedError struct {
+	Type ErrorType
+	Err  error
+}
+
+// Error method returns the error message of underlying error.
+func (e *TypedError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap method returns the underlying error.
+func (e *TypedError) Unwrap() error {
+	return e.Err
+}
+
+// classifyError returns the error type of given check error by inspecting
+// the wrapped errors, it returns empty for nil error.
+func classifyError(err error) ErrorType {
+	if err == nil {
+		return ""
+	}
+
+	var te *TypedError
+	if errors.As(err, &te) && len(te.Type) > 0 {
+		return te.Type
+	}
+	var pe *panicError
+	if errors.As(err, &pe) {
+		return ErrorTypePanic
+	}
+
+	// DNS error implements `net.Error` too, so it goes before timeout
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return ErrorTypeDNS
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, os.ErrDeadlineExceeded) {
+		return ErrorTypeTimeout
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return ErrorTypeTimeout
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return ErrorTypeConnectionRefused
+	}
+
+	if isTLSError(err) {
+		return ErrorTypeTLS
+	}
+	return ErrorTypeUnknown
+}
+
+// isTLSError returns true if given error is the certificate verification or
+// TLS handshake error.
+func isTLSError(err error) bool {
+	var (
+		unknownAuthority x509.UnknownAuthorityError
+		invalidCert      x509.CertificateInvalidError
+		hostname         x509.HostnameError
+		systemRoots      x509.SystemRootsError
+		recordHeader     tls.RecordHeaderError
+	)
+	switch {
+	case errors.As(err, &unknownAuthority),
+		errors.As(err, &invalidCert),
+		errors.As(err, &hostname),
+		errors.As(err, &systemRoots),
+		errors.As(err, &recordHeader):
+		return true
+	}
+
+	// TLS alerts of the peer are not exported, for e.g.
+	// "remote error: tls: bad certificate"
+	return strings.Contains(err.Error(), "tls: ")
+}
+
+// statusCodeError returns the error of unexpected HTTP status code, 401 and
+// 403 are classified as `ErrorTypeAuth`.
+func statusCodeError(code int) error {
+	err := fmt.Errorf("unexpected status code %d", code)
+	if code == http.StatusUnauthorized || code == http.StatusForbidden {
+		return &TypedError{Type: ErrorTypeAuth, Err: err}
+	}
+	return err
+}
@@ -0,0 +1,75 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthClassifyError(t *testing.T) {
+	testcases := []struct {
+		label string
+		err   error
+		want  ErrorType
+	}{
+		{label: "nil", err: nil, want: ""},
+		{label: "plain", err: errors.New("boom"), want: ErrorTypeUnknown},
+		{label: "deadline", err: fmt.Errorf("query: %w", context.DeadlineExceeded), want: ErrorTypeTimeout},
+		{label: "io deadline", err: &net.OpError{Op: "read", Net: "tcp", Err: os.ErrDeadlineExceeded}, want: ErrorTypeTimeout},
+		{label: "refused", err: &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}, want: ErrorTypeConnectionRefused},
+		{label: "dns", err: &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "db.invalid", IsNotFound: true}}, want: ErrorTypeDNS},
+		{label: "dns timeout", err: &net.DNSError{Err: "i/o timeout", Name: "db.internal", IsTimeout: true}, want: ErrorTypeDNS},
+		{label: "unknown authority", err: fmt.Errorf("get: %w", x509.UnknownAuthorityError{}), want: ErrorTypeTLS},
+		{label: "hostname", err: x509.HostnameError{Certificate: &x509.Certificate{}, Host: "db"}, want: ErrorTypeTLS},
+		{label: "tls alert", err: errors.New("remote error: tls: bad certificate"), want: ErrorTypeTLS},
+		{label: "typed", err: fmt.Errorf("login: %w", &TypedError{Type: ErrorTypeAuth, Err: errors.New("denied")}), want: ErrorTypeAuth},
+		{label: "panic", err: &panicError{value: "oops"}, want: ErrorTypePanic},
+		{label: "unauthorized", err: statusCodeError(http.StatusUnauthorized), want: ErrorTypeAuth},
+		{label: "forbidden", err: statusCodeError(http.StatusForbidden), want: ErrorTypeAuth},
+		{label: "not found", err: statusCodeError(http.StatusNotFound), want: ErrorTypeUnknown},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.label, func(t *testing.T) {
+			assert.Equal(t, tc.want, classifyError(tc.err))
+		})
+	}
+}
+
+func TestHealthResultErrorType(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer ts.Close()
+
+	collector := newCollector()
+	db := &toggleReporter{}
+	api, _ := NewHTTPReporter(HTTPReporterOptions{URL: ts.URL})
+	_ = collector.AddReporter(&Config{Name: "api", Reporter: api})
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: db})
+	_ = collector.AddReporter(&Config{Name: "cache", Reporter: &toggleReporter{}, DependsOn: []string{"db"}})
+	collector.runChecks()
+
+	results := collector.Results()
+	assert.Equal(t, ErrorTypeAuth, results["api"].ErrorType)
+	assert.Equal(t, "unexpected status code 401", results["api"].Error)
+	assert.Empty(t, results["db"].ErrorType)
+
+	db.set(fmt.Errorf("dial: %w", context.DeadlineExceeded))
+	collector.runChecks()
+	results = collector.Results()
+	assert.Equal(t, ErrorTypeTimeout, results["db"].ErrorType)
+	assert.Equal(t, ErrorTypeDependency, results["cache"].ErrorType)
+}
@@ -0,0 +1,299 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Eureka instance status values.
+const (
+	EurekaUp           = "UP"
+	EurekaDown         = "DOWN"
+	EurekaOutOfService = "OUT_OF_SERVICE"
+)
+
+// errEurekaNotFound is returned when the heartbeat is rejected, for e.g.
+// server evicted the instance, so that it gets registered again.
+var errEurekaNotFound = errors.New("health: eureka instance not found")
+
+// EurekaOptions struct holds the configuration of Eureka registration,
+// refer to `Collector.RegisterEureka`.
+type EurekaOptions struct {
+	// ServiceURL of the Eureka server, default is
+	// `http://localhost:8761/eureka`.
+	ServiceURL string
+
+	// Username and Password of the Eureka server basic auth, if set.
+	Username string
+	Password string
+
+	// App name of the service, it is required. Eureka upper cases it.
+	App string
+
+	// HostName and IPAddr of the instance, default is `Collector.Instance`
+	// for both.
+	HostName string
+	IPAddr   string
+
+	// Port of the instance, default is 8080. SecurePort is enabled if it is
+	// greater than 0.
+	Port       int
+	SecurePort int
+
+	// InstanceID of the instance, default is `<HostName>:<App>:<Port>`.
+	InstanceID string
+
+	// VIPAddress and SecureVIPAddress of the instance, default is App in
+	// lower case.
+	VIPAddress       string
+	SecureVIPAddress string
+
+	// HomePageURL, StatusPageURL and HealthCheckURL advertised to Eureka.
+	HomePageURL    string
+	StatusPageURL  string
+	HealthCheckURL string
+
+	// Metadata of the instance.
+	Metadata map[string]string
+
+	// RenewalInterval is the heartbeat interval, default is 30 seconds.
+	// LeaseDuration after which server evicts the instance without
+	// heartbeat, default is 90 seconds.
+	RenewalInterval time.Duration
+	LeaseDuration   time.Duration
+
+	// Timeout of the Eureka API requests, default is 5 seconds.
+	Timeout time.Duration
+}
+
+// EurekaRegistration struct represents the instance registered in Eureka,
+// heartbeats are sent until `Deregister` is called or the collector is
+// stopped.
+type EurekaRegistration struct {
+	collector *Collector
+	opts      EurekaOptions
+	client    *http.Client
+	stop      chan struct{}
+	done      chan struct{}
+	once      sync.Once
+	err       error
+	status    string
+	dirty     int64 // last dirty timestamp in milliseconds
+}
+
+// RegisterEureka method registers the application instance in Eureka as
+// per given options and sends heartbeats with the status derived from the
+// collector, so that Spring Cloud clients discover only the healthy
+// instances. Status is `OUT_OF_SERVICE` while draining, `DOWN` while
+// unhealthy or shutting down otherwise `UP`. Status change is sent with the
+// next heartbeat. Instance is deregistered when the collector is stopped,
+// for e.g. aah application shutdown.
+//
+//	reg, err := collector.RegisterEureka(health.EurekaOptions{
+//	    ServiceURL: "http://eureka:8761/eureka",
+//	    App:        "orders",
+//	    Port:       8080,
+//	})
+func (c *Collector) RegisterEureka(opts EurekaOptions) (*EurekaRegistration, error) {
+	if opts.App == "" {
+		return nil, errors.New("health: eureka app name is required")
+	}
+	if opts.ServiceURL == "" {
+		opts.ServiceURL = "http://localhost:8761/eureka"
+	}
+	opts.ServiceURL = strings.TrimSuffix(opts.ServiceURL, "/")
+	opts.App = strings.ToUpper(opts.App)
+	if opts.HostName == "" {
+		opts.HostName = c.Instance()
+	}
+	if opts.IPAddr == "" {
+		opts.IPAddr = opts.HostName
+	}
+	if opts.Port <= 0 {
+		opts.Port = 8080
+	}
+	if opts.InstanceID == "" {
+		opts.InstanceID = opts.HostName + ":" + strings.ToLower(opts.App) + ":" + strconv.Itoa(opts.Port)
+	}
+	if opts.VIPAddress == "" {
+		opts.VIPAddress = strings.ToLower(opts.App)
+	}
+	if opts.SecureVIPAddress == "" {
+		opts.SecureVIPAddress = strings.ToLower(opts.App)
+	}
+	if opts.RenewalInterval <= 0 {
+		opts.RenewalInterval = 30 * time.Second
+	}
+	if opts.LeaseDuration <= 0 {
+		opts.LeaseDuration = 90 * time.Second
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+
+	r := &EurekaRegistration{
+		collector: c,
+		opts:      opts,
+		client:    &http.Client{Timeout: opts.Timeout},
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	if err := r.register(r.currentStatus()); err != nil {
+		return nil, err
+	}
+	go r.heartbeat()
+	return r, nil
+}
+
+// InstanceID method returns the Eureka instance ID of the registration.
+func (r *EurekaRegistration) InstanceID() string {
+	return r.opts.InstanceID
+}
+
+// Deregister method stops the heartbeats and deregisters the instance from
+// Eureka. It is safe to call multiple times.
+func (r *EurekaRegistration) Deregister() error {
+	r.once.Do(func() {
+		close(r.stop)
+		<-r.done
+		r.err = r.do(http.MethodDelete, r.instancePath(), nil)
+	})
+	return r.err
+}
+
+// currentStatus method returns the Eureka status of the collector.
+func (r *EurekaRegistration) currentStatus() string {
+	_, status := r.collector.currentStatus(true, nil)
+	switch {
+	case r.collector.IsDraining():
+		return EurekaOutOfService
+	case status == Unhealthy:
+		return EurekaDown
+	}
+	return EurekaUp
+}
+
+func (r *EurekaRegistration) register(status string) error {
+	r.status, r.dirty = status, r.collector.clock.Now().UnixNano()/int64(time.Millisecond)
+	port := func(p int) map[string]interface{} {
+		return map[string]interface{}{"$": p, "@enabled": strconv.FormatBool(p > 0)}
+	}
+	instance := map[string]interface{}{
+		"instanceId":         r.opts.InstanceID,
+		"hostName":           r.opts.HostName,
+		"app":                r.opts.App,
+		"ipAddr":             r.opts.IPAddr,
+		"vipAddress":         r.opts.VIPAddress,
+		"secureVipAddress":   r.opts.SecureVIPAddress,
+		"status":             status,
+		"port":               port(r.opts.Port),
+		"securePort":         port(r.opts.SecurePort),
+		"homePageUrl":        r.opts.HomePageURL,
+		"statusPageUrl":      r.opts.StatusPageURL,
+		"healthCheckUrl":     r.opts.HealthCheckURL,
+		"lastDirtyTimestamp": strconv.FormatInt(r.dirty, 10),
+		"dataCenterInfo": map[string]string{
+			"@class": "com.netflix.appinfo.InstanceInfo$DefaultDataCenterInfo",
+			"name":   "MyOwn",
+		},
+		"leaseInfo": map[string]int{
+			"renewalIntervalInSecs": int(r.opts.RenewalInterval / time.Second),
+			"durationInSecs":        int(r.opts.LeaseDuration / time.Second),
+		},
+	}
+	if len(r.opts.Metadata) > 0 {
+		instance["metadata"] = r.opts.Metadata
+	}
+	return r.do(http.MethodPost, "/apps/"+url.PathEscape(r.opts.App),
+		map[string]interface{}{"instance": instance})
+}
+
+// heartbeat method renews the lease periodically, instance is registered
+// again with the new status on status change or once evicted by the server.
+// Instance is deregistered once the collector is stopped.
+func (r *EurekaRegistration) heartbeat() {
+	defer close(r.done)
+	t := time.NewTicker(r.opts.RenewalInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			if err := r.renew(); err != nil {
+				if logger := r.collector.log(); logger != nil {
+					logger.Errorf("health: unable to renew eureka lease: %v", err)
+				}
+			}
+		case <-r.collector.ctx.Done():
+			go func() { _ = r.Deregister() }()
+			return
+		case <-r.stop:
+			return
+		}
+	}
+}
+
+func (r *EurekaRegistration) renew() error {
+	if status := r.currentStatus(); status != r.status {
+		return r.register(status)
+	}
+	query := url.Values{}
+	query.Set("status", r.status)
+	query.Set("lastDirtyTimestamp", strconv.FormatInt(r.dirty, 10))
+	err := r.do(http.MethodPut, r.instancePath()+"?"+query.Encode(), nil)
+	if err == errEurekaNotFound {
+		return r.register(r.status)
+	}
+	return err
+}
+
+func (r *EurekaRegistration) instancePath() string {
+	return "/apps/" + url.PathEscape(r.opts.App) + "/" + url.PathEscape(r.opts.InstanceID)
+}
+
+func (r *EurekaRegistration) do(method, path string, v interface{}) error {
+	var body io.Reader
+	if v != nil {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequest(method, r.opts.ServiceURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if v != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if len(r.opts.Username) > 0 {
+		req.SetBasicAuth(r.opts.Username, r.opts.Password)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxHTTPBodySize))
+	_ = resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return errEurekaNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("health: eureka responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
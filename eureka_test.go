@@ -0,0 +1,103 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type eurekaServer struct {
+	mu        sync.Mutex
+	requests  []string
+	instances []map[string]interface{}
+	evicted   bool
+}
+
+func (s *eurekaServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+	switch r.Method {
+	case http.MethodPost:
+		var body struct {
+			Instance map[string]interface{} `json:"instance"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		s.instances = append(s.instances, body.Instance)
+		s.evicted = false
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodPut:
+		if s.evicted {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func (s *eurekaServer) lastStatus() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.instances[len(s.instances)-1]["status"].(string)
+}
+
+func (s *eurekaServer) registrations() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.instances)
+}
+
+func TestHealthEureka(t *testing.T) {
+	server := &eurekaServer{}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	collector := newCollector()
+	_, err := collector.RegisterEureka(EurekaOptions{ServiceURL: ts.URL})
+	assert.Equal(t, "health: eureka app name is required", err.Error())
+
+	reg, err := collector.RegisterEureka(EurekaOptions{
+		ServiceURL:      ts.URL + "/",
+		App:             "orders",
+		HostName:        "app-1",
+		RenewalInterval: 10 * time.Millisecond,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "app-1:orders:8080", reg.InstanceID())
+	server.mu.Lock()
+	assert.Equal(t, "POST /apps/ORDERS", server.requests[0])
+	instance := server.instances[0]
+	assert.Equal(t, "UP", instance["status"])
+	assert.Equal(t, "orders", instance["vipAddress"])
+	assert.Equal(t, map[string]interface{}{"$": float64(8080), "@enabled": "true"}, instance["port"])
+	server.mu.Unlock()
+
+	// status change registers the instance again
+	collector.SetDraining(true)
+	assert.Eventually(t, func() bool { return server.lastStatus() == EurekaOutOfService },
+		time.Second, 5*time.Millisecond)
+	collector.SetDraining(false)
+	assert.Eventually(t, func() bool { return server.lastStatus() == EurekaUp },
+		time.Second, 5*time.Millisecond)
+
+	// evicted instance is registered again
+	n := server.registrations()
+	server.mu.Lock()
+	server.evicted = true
+	server.mu.Unlock()
+	assert.Eventually(t, func() bool { return server.registrations() > n },
+		time.Second, 5*time.Millisecond)
+
+	assert.Nil(t, reg.Deregister())
+	assert.Nil(t, reg.Deregister())
+	server.mu.Lock()
+	assert.Equal(t, "DELETE /apps/ORDERS/app-1:orders:8080", server.requests[len(server.requests)-1])
+	server.mu.Unlock()
+}
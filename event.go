@@ -0,0 +1,51 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+// StatusChangeFunc is a callback invoked when a reporter's status changes.
+type StatusChangeFunc func(name string, old, new Status)
+
+// HealthChangeFunc is a callback invoked when the collector's global health
+// status changes.
+type HealthChangeFunc func(healthy bool)
+
+// OnStatusChange method registers a callback which is invoked whenever a
+// reporter flips its status, for e.g. `OK` to `KO`. Callbacks are invoked
+// synchronously from the check goroutine, so keep them quick.
+//
+// Collector assumes reporter is healthy until its first check, so first
+// check reporting `KO` is notified as well.
+func (c *Collector) OnStatusChange(fn StatusChangeFunc) {
+	c.mu.Lock()
+	c.statusHooks = append(c.statusHooks, fn)
+	c.mu.Unlock()
+}
+
+// OnHealthChange method registers a callback which is invoked whenever the
+// collector's global health status flips. Callbacks are invoked
+// synchronously, so keep them quick.
+func (c *Collector) OnHealthChange(fn HealthChangeFunc) {
+	c.mu.Lock()
+	c.healthHooks = append(c.healthHooks, fn)
+	c.mu.Unlock()
+}
+
+func (c *Collector) fireStatusChange(name string, old, new Status) {
+	c.mu.RLock()
+	hooks := c.statusHooks
+	c.mu.RUnlock()
+	for _, fn := range hooks {
+		fn(name, old, new)
+	}
+}
+
+func (c *Collector) fireHealthChange(healthy bool) {
+	c.mu.RLock()
+	hooks := c.healthHooks
+	c.mu.RUnlock()
+	for _, fn := range hooks {
+		fn(healthy)
+	}
+}
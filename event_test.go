@@ -0,0 +1,51 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthOnStatusChange(t *testing.T) {
+	collector := newCollector()
+	reporter := &toggleReporter{}
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: reporter})
+
+	var (
+		mu          sync.Mutex
+		transitions []string
+		health      []bool
+	)
+	collector.OnStatusChange(func(name string, old, new Status) {
+		mu.Lock()
+		transitions = append(transitions, name+":"+string(old)+"->"+string(new))
+		mu.Unlock()
+	})
+	collector.OnHealthChange(func(healthy bool) {
+		mu.Lock()
+		health = append(health, healthy)
+		mu.Unlock()
+	})
+
+	collector.runChecks() // OK, no transition
+	reporter.set(errors.New("down"))
+	collector.runChecks() // OK -> KO
+	collector.runChecks() // KO, no transition
+	reporter.set(nil)
+	collector.runChecks() // KO -> OK
+
+	assert.Equal(t, []string{"db:OK->KO", "db:KO->OK"}, transitions)
+	assert.Equal(t, []bool{false, true}, health)
+
+	// removing unhealthy reporter flips global health
+	reporter.set(errors.New("down"))
+	collector.runChecks()
+	_ = collector.RemoveReporter("db")
+	assert.Equal(t, []bool{false, true, false, true}, health)
+}
@@ -0,0 +1,92 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"expvar"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// expvarCollectors holds the collectors published via expvar keyed by name,
+// since expvar names are process wide.
+var (
+	expvarMu         sync.Mutex
+	expvarCollectors = make(map[string]*Collector)
+)
+
+// expvarHealth struct is the health state published via expvar.
+type expvarHealth struct {
+	Status        AggregateStatus        `json:"status"`
+	Healthy       bool                   `json:"healthy"`
+	Draining      bool                   `json:"draining"`
+	ChecksTotal   uint64                 `json:"checksTotal"`
+	FailuresTotal uint64                 `json:"failuresTotal"`
+	Checks        map[string]expvarCheck `json:"checks"`
+}
+
+// expvarCheck struct is the reporter state published via expvar.
+type expvarCheck struct {
+	Status      Status    `json:"status"`
+	Error       string    `json:"error,omitempty"`
+	Severity    Severity  `json:"severity,omitempty"`
+	DurationMs  float64   `json:"durationMs"`
+	LastChecked time.Time `json:"lastChecked"`
+	Checks      uint64    `json:"checks"`
+	Failures    uint64    `json:"failures"`
+}
+
+// PublishExpvar method publishes the collector health state via `expvar`
+// under given name, so that `/debug/vars` tooling picks it up. It holds
+// the aggregate status, per reporter status and the check and failure
+// counters. Name must be unique since expvar names cannot be published
+// twice, publishing the collector again under same name is a no-op. It can
+// also be enabled with `health.expvar` of aah application config.
+//
+//	_ = collector.PublishExpvar("health")
+func (c *Collector) PublishExpvar(name string) error {
+	expvarMu.Lock()
+	defer expvarMu.Unlock()
+	if expvarCollectors[name] == c {
+		return nil
+	}
+	if expvar.Get(name) != nil {
+		return fmt.Errorf("health: expvar name '%s' is already published", name)
+	}
+	expvar.Publish(name, expvar.Func(c.expvarState))
+	expvarCollectors[name] = c
+	return nil
+}
+
+// expvarState method returns the current health state for expvar.
+func (c *Collector) expvarState() interface{} {
+	state := c.load()
+	h := expvarHealth{
+		Status:   state.status,
+		Healthy:  state.healthy,
+		Draining: c.IsDraining(),
+		Checks:   make(map[string]expvarCheck, len(state.results)),
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for name, result := range state.results {
+		check := expvarCheck{
+			Status:      result.Status,
+			Error:       result.Error,
+			Severity:    result.Severity,
+			DurationMs:  float64(result.Duration) / float64(time.Millisecond),
+			LastChecked: result.LastChecked,
+			Failures:    c.failures[name],
+		}
+		if d, found := c.durations[name]; found {
+			check.Checks = d.count
+		}
+		h.ChecksTotal += check.Checks
+		h.FailuresTotal += check.Failures
+		h.Checks[name] = check
+	}
+	return h
+}
@@ -0,0 +1,58 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthPublishExpvar(t *testing.T) {
+	collector := newCollector()
+	cache := &toggleReporter{}
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: &toggleReporter{}})
+	_ = collector.AddReporter(&Config{Name: "cache", Reporter: cache, SoftFail: true})
+	collector.runChecks()
+	cache.set(errors.New("connection refused"))
+	collector.runChecks()
+
+	// expvar names are process wide, so unique per test run
+	name := fmt.Sprintf("health_expvar_test_%d", time.Now().UnixNano())
+	assert.NoError(t, collector.PublishExpvar(name))
+	assert.NoError(t, collector.PublishExpvar(name)) // registered for another domain
+	err := newCollector().PublishExpvar(name)
+	assert.EqualError(t, err, "health: expvar name '"+name+"' is already published")
+
+	var h expvarHealth
+	assert.NoError(t, json.Unmarshal([]byte(expvar.Get(name).String()), &h))
+	assert.Equal(t, Degraded, h.Status)
+	assert.True(t, h.Healthy)
+	assert.False(t, h.Draining)
+	assert.Equal(t, uint64(4), h.ChecksTotal)
+	assert.Equal(t, uint64(1), h.FailuresTotal)
+
+	assert.Equal(t, StatusOK, h.Checks["db"].Status)
+	assert.Equal(t, uint64(2), h.Checks["db"].Checks)
+	assert.Equal(t, uint64(0), h.Checks["db"].Failures)
+	assert.Equal(t, StatusKO, h.Checks["cache"].Status)
+	assert.Equal(t, "connection refused", h.Checks["cache"].Error)
+	assert.Equal(t, SeverityWarning, h.Checks["cache"].Severity)
+	assert.Equal(t, uint64(1), h.Checks["cache"].Failures)
+
+	// published state is live
+	collector.SetDraining(true)
+	_ = collector.RemoveReporter("cache")
+	h = expvarHealth{}
+	assert.NoError(t, json.Unmarshal([]byte(expvar.Get(name).String()), &h))
+	assert.True(t, h.Draining)
+	assert.Equal(t, Healthy, h.Status)
+	assert.Len(t, h.Checks, 1)
+}
@@ -0,0 +1,81 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+// EventFlapping is the `StatusChangeEvent.NewStatus` value notified once
+// the reporter starts flapping, refer to `Config.FlapWindow`.
+const EventFlapping = "FLAPPING"
+
+// defaultFlapThreshold is the default state change ratio of the flap window
+// above which the reporter is considered as flapping.
+const defaultFlapThreshold = 0.5
+
+// flapState struct holds the recent statuses of the reporter for flap
+// detection.
+type flapState struct {
+	statuses []Status
+	flapping bool
+	held     Status
+}
+
+// updateFlapping method detects the flapping of the reporter as per Nagios
+// semantics, the weighted ratio of state changes within the last
+// `Config.FlapWindow` checks. Reporter starts flapping when the ratio reaches
+// `Config.FlapThreshold` and stops when it drops below half of it. While
+// flapping, result status is held at the oldest status of the window. It
+// returns true when the reporter starts flapping. Caller must hold the lock.
+func (c *Collector) updateFlapping(rc *Config, result *CheckResult) bool {
+	if rc.FlapWindow < 3 {
+		return false
+	}
+	fs, found := c.flaps[rc.Name]
+	if !found {
+		fs = &flapState{statuses: make([]Status, 0, rc.FlapWindow)}
+		c.flaps[rc.Name] = fs
+	}
+	if len(fs.statuses) == rc.FlapWindow {
+		fs.statuses = append(fs.statuses[:0], fs.statuses[1:]...)
+	}
+	fs.statuses = append(fs.statuses, result.Status)
+
+	threshold := rc.FlapThreshold
+	if threshold <= 0 {
+		threshold = defaultFlapThreshold
+	}
+	if len(fs.statuses) < rc.FlapWindow {
+		return false
+	}
+	ratio := fs.changeRatio()
+	started := false
+	switch {
+	case !fs.flapping && ratio >= threshold:
+		fs.flapping, fs.held, started = true, fs.statuses[0], true
+	case fs.flapping && ratio < threshold/2:
+		fs.flapping = false
+	}
+	if fs.flapping {
+		result.Status = fs.held
+		result.Flapping = true
+	}
+	return started
+}
+
+// changeRatio method returns the weighted ratio of state changes, recent
+// changes weigh more (1.2) than the oldest ones (0.8).
+func (fs *flapState) changeRatio() float64 {
+	n := len(fs.statuses) - 1
+	if n < 2 {
+		return 0
+	}
+	var changed, total float64
+	for i := 1; i <= n; i++ {
+		weight := 0.8 + 0.4*float64(i-1)/float64(n-1)
+		total += weight
+		if fs.statuses[i] != fs.statuses[i-1] {
+			changed += weight
+		}
+	}
+	return changed / total
+}
@@ -0,0 +1,79 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthFlapDetection(t *testing.T) {
+	collector := newCollector()
+	defer collector.Stop()
+	notifier := &recordNotifier{}
+	collector.AddNotifier(notifier, OnlyReporters("db"))
+	var changes int
+	collector.OnStatusChange(func(name string, old, new Status) { changes++ })
+
+	reporter := &toggleReporter{}
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: reporter, FlapWindow: 5})
+
+	// OK, OK, KO, OK, KO -> flapping once window is full
+	for _, err := range []error{nil, nil, errors.New("down"), nil, errors.New("down")} {
+		reporter.set(err)
+		collector.runChecks()
+	}
+	result := collector.Results()["db"]
+	assert.True(t, result.Flapping)
+	assert.Equal(t, StatusOK, result.Status)
+	assert.True(t, collector.IsHealthy())
+	changesBefore := changes
+
+	// held steady while flapping
+	reporter.set(nil)
+	collector.runChecks()
+	reporter.set(errors.New("down"))
+	collector.runChecks()
+	assert.True(t, collector.Results()["db"].Flapping)
+	assert.Equal(t, StatusOK, collector.Results()["db"].Status)
+	assert.Equal(t, changesBefore, changes)
+
+	// stable failure stops flapping
+	for i := 0; i < 5; i++ {
+		collector.runChecks()
+	}
+	result = collector.Results()["db"]
+	assert.False(t, result.Flapping)
+	assert.Equal(t, StatusKO, result.Status)
+	assert.False(t, collector.IsHealthy())
+
+	assert.Eventually(t, func() bool {
+		for _, e := range notifier.recorded() {
+			if e.NewStatus == EventFlapping {
+				return true
+			}
+		}
+		return false
+	}, time.Second, time.Millisecond)
+	var flapEvents int
+	for _, e := range notifier.recorded() {
+		if e.NewStatus == EventFlapping {
+			flapEvents++
+		}
+	}
+	assert.Equal(t, 1, flapEvents)
+}
+
+func TestHealthFlapChangeRatio(t *testing.T) {
+	fs := &flapState{statuses: []Status{StatusOK, StatusOK, StatusOK}}
+	assert.Equal(t, 0.0, fs.changeRatio())
+	fs.statuses = []Status{StatusOK, StatusKO, StatusOK}
+	assert.Equal(t, 1.0, fs.changeRatio())
+	fs.statuses = []Status{StatusOK, StatusKO, StatusKO}
+	assert.InDelta(t, 0.4, fs.changeRatio(), 0.0001)
+}
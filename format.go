@@ -0,0 +1,130 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import "time"
+
+// processStart is the start time of the process, it is used to report the
+// uptime in response envelope.
+var processStart = time.Now()
+
+// Health check response formats.
+const (
+	// FormatJSON responds with reporter's check results keyed by name.
+	FormatJSON = "json"
+
+	// FormatHealthJSON responds as per IETF draft "Health Check Response
+	// Format for HTTP APIs" (https://tools.ietf.org/html/draft-inadarei-api-health-check).
+	FormatHealthJSON = "health+json"
+
+	// FormatXML responds with the check results as XML, refer to
+	// `XMLEncoder`.
+	FormatXML = "xml"
+
+	// FormatYAML responds with the check results as YAML, structure is same
+	// as `FormatJSON`.
+	FormatYAML = "yaml"
+)
+
+// Exposure levels of the health check response.
+const (
+	// ExposureVerbose responds with per reporter check results.
+	ExposureVerbose = "verbose"
+
+	// ExposureSummary responds with only the aggregate status, so that
+	// error details of the reporters are not exposed.
+	ExposureSummary = "summary"
+)
+
+// HeaderHealthStatus is the response header which carries the aggregate
+// health status of the collector, refer to `AggregateStatus`.
+const HeaderHealthStatus = "X-Health-Status"
+
+// HealthJSONContentType is the content type of `FormatHealthJSON` response.
+const HealthJSONContentType = "application/health+json"
+
+// Health check status values of `FormatHealthJSON` response.
+const (
+	healthJSONPass = "pass"
+	healthJSONWarn = "warn"
+	healthJSONFail = "fail"
+)
+
+// healthJSON struct represents the `application/health+json` response.
+type healthJSON struct {
+	Status    string                        `json:"status"`
+	ReleaseID string                        `json:"releaseId,omitempty"`
+	ServiceID string                        `json:"serviceId,omitempty"`
+	Checks    map[string][]healthJSONResult `json:"checks,omitempty"`
+}
+
+// healthJSONResult struct represents a check entry in `checks` object.
+type healthJSONResult struct {
+	ComponentType string  `json:"componentType"`
+	ObservedValue float64 `json:"observedValue"`
+	ObservedUnit  string  `json:"observedUnit"`
+	Status        string  `json:"status"`
+	Time          string  `json:"time"`
+	Output        string  `json:"output,omitempty"`
+}
+
+// envelopeJSON struct represents the `FormatJSON` response along with the
+// application metadata, refer to `RegisterOptions.Envelope`.
+type envelopeJSON struct {
+	Status    AggregateStatus        `json:"status"`
+	Score     float64                `json:"score"`
+	Service   string                 `json:"service,omitempty"`
+	Version   string                 `json:"version,omitempty"`
+	BuildTime string                 `json:"buildTime,omitempty"`
+	Instance  string                 `json:"instance"`
+	Uptime    float64                `json:"uptimeSeconds"`
+	Timestamp time.Time              `json:"timestamp"`
+	Metadata  map[string]string      `json:"metadata,omitempty"`
+	Paused    bool                   `json:"paused,omitempty"`
+	Checks    map[string]CheckResult `json:"checks,omitempty"`
+}
+
+// summaryJSON struct represents `ExposureSummary` response of `FormatJSON`.
+type summaryJSON struct {
+	Status AggregateStatus `json:"status"`
+	Paused bool            `json:"paused,omitempty"`
+}
+
+func newHealthJSON(report *HealthReport) *healthJSON {
+	hj := &healthJSON{
+		Status:    healthJSONPass,
+		ReleaseID: report.Version,
+		ServiceID: report.Service,
+		Checks:    make(map[string][]healthJSONResult, len(report.Checks)),
+	}
+	for name, result := range report.Checks {
+		hj.Checks[name+":responseTime"] = []healthJSONResult{{
+			ComponentType: "component",
+			ObservedValue: float64(result.Duration) / float64(time.Millisecond),
+			ObservedUnit:  "ms",
+			Status:        healthJSONStatus(result),
+			Time:          result.LastChecked.Format(time.RFC3339),
+			Output:        result.Error,
+		}}
+	}
+	switch report.Status {
+	case Degraded, Starting:
+		hj.Status = healthJSONWarn
+	case Unhealthy:
+		hj.Status = healthJSONFail
+	}
+	return hj
+}
+
+func healthJSONStatus(result CheckResult) string {
+	switch {
+	case result.IsOK():
+		return healthJSONPass
+	case result.severity() != SeverityCritical, result.Maintenance, result.Muted:
+		return healthJSONWarn
+	default:
+		return healthJSONFail
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthJSONFormat(t *testing.T) {
+	checked := time.Date(2019, 3, 1, 10, 0, 0, 0, time.UTC)
+	state := &snapshot{
+		healthy: true,
+		results: map[string]CheckResult{
+			"db":    {Status: StatusOK, Duration: 1500 * time.Microsecond, LastChecked: checked},
+			"cache": {Status: StatusKO, Error: "connection refused", LastChecked: checked, SoftFail: true},
+		},
+	}
+	report := &HealthReport{Status: Degraded, Version: "1.0.0", Service: "sample", Checks: state.results}
+
+	body, err := json.Marshal(newHealthJSON(report))
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"status": "warn",
+		"releaseId": "1.0.0",
+		"serviceId": "sample",
+		"checks": {
+			"cache:responseTime": [{"componentType": "component", "observedValue": 0, "observedUnit": "ms",
+				"status": "warn", "time": "2019-03-01T10:00:00Z", "output": "connection refused"}],
+			"db:responseTime": [{"componentType": "component", "observedValue": 1.5, "observedUnit": "ms",
+				"status": "pass", "time": "2019-03-01T10:00:00Z"}]
+		}
+	}`, string(body))
+
+	report.Status = Healthy
+	assert.Equal(t, healthJSONPass, newHealthJSON(report).Status)
+	report.Status = Unhealthy
+	assert.Equal(t, healthJSONFail, newHealthJSON(report).Status)
+}
+
+func TestHealthEnvelope(t *testing.T) {
+	collector := newCollector()
+	WithInstance("web-1")(collector)
+	WithClock(fixedClock(time.Date(2019, 3, 1, 10, 0, 0, 0, time.UTC)))(collector)
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: &toggleReporter{}, DeferInitialCheck: true})
+	collector.runChecks()
+
+	opts := RegisterOptions{
+		Envelope:  true,
+		ReleaseID: "1.0.0",
+		ServiceID: "sample",
+		BuildTime: "2019-03-01T10:00:00Z",
+		Metadata:  map[string]string{"region": "us-east-1"},
+	}
+	assert.Nil(t, opts.normalize())
+	state, status := collector.currentStatus(false, nil)
+	code, contentType, body, err := collector.healthResponse(state, status, opts)
+	assert.Nil(t, err)
+	assert.Equal(t, 200, code)
+	assert.Equal(t, jsonContentType, contentType)
+
+	env := make(map[string]interface{})
+	assert.Nil(t, json.Unmarshal(body, &env))
+	assert.Equal(t, "healthy", env["status"])
+	assert.Equal(t, "sample", env["service"])
+	assert.Equal(t, "1.0.0", env["version"])
+	assert.Equal(t, "2019-03-01T10:00:00Z", env["buildTime"])
+	assert.Equal(t, "web-1", env["instance"])
+	assert.Equal(t, "2019-03-01T10:00:00Z", env["timestamp"])
+	assert.Greater(t, env["uptimeSeconds"], 0.0)
+	assert.Equal(t, map[string]interface{}{"region": "us-east-1"}, env["metadata"])
+	assert.Contains(t, env["checks"], "db")
+
+	opts.Exposure = ExposureSummary
+	_, _, body, _ = collector.healthResponse(state, status, opts)
+	assert.NotContains(t, string(body), "checks")
+}
+
+// fixedClock always reports the same time, its timers are real.
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time                 { return time.Time(c) }
+func (c fixedClock) NewTimer(d time.Duration) Timer { return realClock{}.NewTimer(d) }
@@ -0,0 +1,628 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	jsonContentType = "application/json; charset=utf-8"
+	textContentType = "text/plain; charset=utf-8"
+	htmlContentType = "text/html; charset=utf-8"
+	svgContentType  = "image/svg+xml; charset=utf-8"
+
+	eventStreamContentType = "text/event-stream; charset=utf-8"
+)
+
+var gzipWriters = sync.Pool{New: func() interface{} { return gzip.NewWriter(nil) }}
+
+// Handler method returns the `http.Handler` of health endpoints, so that it
+// can be mounted on any `net/http` mux or a separate admin server without
+// aah router. It responds to the health check path `/healthcheck` and its
+// sub-paths `/live`, `/ready`, `/metrics`, `/history`, `/stream`, `/ws`,
+// `/ui`, `/badge.svg` and `/tags/<tag>` for the tag routes, other paths are
+// not found, e.g.:
+//
+//	mux.Handle("/healthcheck", collector.Handler())
+//	mux.Handle("/healthcheck/", collector.Handler())
+//	mux.Handle("/ping", collector.PingHandler())
+func (c *Collector) Handler() http.Handler {
+	h, _ := c.HandlerWithOptions(RegisterOptions{})
+	return h
+}
+
+// HandlerWithOptions method returns the `http.Handler` of health endpoints
+// customized by given options same as aah routes, the health check path is
+// `Path` or composed of `BasePath` and `Name`. `Domain`, route names,
+// `PingPath`, `DrainAuth`, `MuteAuth`, `ReportAuth` and `ReportersAuth` are
+// not applicable. Refer to `Collector.Handler`.
+func (c *Collector) HandlerWithOptions(opts RegisterOptions) (http.Handler, error) {
+	if err := opts.normalize(); err != nil {
+		return nil, err
+	}
+	return &httpHandler{collector: c, opts: opts}, nil
+}
+
+// PingHandler method returns the `http.Handler` which responds with static
+// text response as `pong!` with status `200 OK`.
+func (c *Collector) PingHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeText(w, http.StatusOK, "pong!\n")
+	})
+}
+
+// httpHandler struct serves the health endpoints for `net/http`.
+type httpHandler struct {
+	collector *Collector
+	opts      RegisterOptions
+}
+
+func (h *httpHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	preflight := r.Method == http.MethodOptions && isCORSEnabled(h.opts)
+	if r.Method != http.MethodGet && r.Method != http.MethodHead && !preflight {
+		if isCORSEnabled(h.opts) {
+			w.Header().Set("Allow", "GET, HEAD, OPTIONS")
+		} else {
+			w.Header().Set("Allow", "GET, HEAD")
+		}
+		writeText(w, http.StatusMethodNotAllowed, "405 Method Not Allowed\n")
+		return
+	}
+	if !isClientAllowed(h.opts, r) {
+		code := h.opts.DeniedStatusCode
+		writeText(w, code, fmt.Sprintf("%d %s\n", code, http.StatusText(code)))
+		return
+	}
+	writeCORSHeaders(h.opts, r, w.Header())
+	if preflight {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	sub, found := h.subPath(r.URL.Path)
+	if !found {
+		http.NotFound(w, r)
+		return
+	}
+	switch sub {
+	case "live":
+		if h.opts.DisableLive {
+			http.NotFound(w, r)
+		} else if h.collector.isShuttingDown() {
+			writeText(w, http.StatusServiceUnavailable, "shutting down\n")
+		} else {
+			writeText(w, http.StatusOK, "alive\n")
+		}
+	case "ready":
+		if h.opts.DisableReady {
+			http.NotFound(w, r)
+		} else if h.authorize(w, r) {
+			h.writeHealth(w, r, true, parseTags(r.URL.Query().Get("tag")))
+		}
+	case "metrics":
+		if h.opts.DisableMetrics {
+			http.NotFound(w, r)
+			return
+		}
+		if !h.authorize(w, r) {
+			return
+		}
+		buf := new(bytes.Buffer)
+		if err := h.collector.WriteMetrics(buf); err != nil {
+			writeText(w, http.StatusInternalServerError, "unable to write metrics\n")
+			return
+		}
+		w.Header().Set("Content-Type", MetricsContentType)
+		_, _ = w.Write(buf.Bytes())
+	case "history":
+		if h.opts.DisableHistory {
+			http.NotFound(w, r)
+			return
+		}
+		if !h.authorize(w, r) {
+			return
+		}
+		opts := exposureOptions(h.opts, r.URL.Query().Get("verbose"))
+		body, err := json.Marshal(historyResponse(h.collector, opts))
+		if err != nil {
+			writeText(w, http.StatusInternalServerError, "unable to marshal response\n")
+			return
+		}
+		w.Header().Set("Content-Type", jsonContentType)
+		_, _ = w.Write(body)
+	case "badge.svg":
+		if h.opts.DisableBadge {
+			http.NotFound(w, r)
+			return
+		}
+		_, status := h.collector.currentStatus(false, parseTags(r.URL.Query().Get("tag")))
+		w.Header().Set(HeaderHealthStatus, string(status))
+		w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+		w.Header().Set("Content-Type", svgContentType)
+		_, _ = w.Write(badgeSVG(r.URL.Query().Get("label"), status))
+	case "stream":
+		if !h.opts.EnableStream {
+			http.NotFound(w, r)
+		} else if h.authorize(w, r) {
+			h.collector.serveStream(w, r, h.opts)
+		}
+	case "ws":
+		if !h.opts.EnableWebSocket {
+			http.NotFound(w, r)
+		} else if h.authorize(w, r) {
+			h.collector.serveWebSocket(w, r, h.opts)
+		}
+	case "ui":
+		if !h.opts.EnableUI {
+			http.NotFound(w, r)
+			return
+		}
+		if !h.authorize(w, r) {
+			return
+		}
+		state, status := h.collector.currentStatus(false, parseTags(r.URL.Query().Get("tag")))
+		body, err := h.collector.statusPage(state, status, exposureOptions(h.opts, r.URL.Query().Get("verbose")))
+		if err != nil {
+			writeText(w, http.StatusInternalServerError, "unable to render status page\n")
+			return
+		}
+		w.Header().Set(HeaderHealthStatus, string(status))
+		w.Header().Set("Content-Type", htmlContentType)
+		_, _ = w.Write(body)
+	case "":
+		if !h.authorize(w, r) {
+			return
+		}
+		if r.URL.Query().Get("force") == "true" {
+			if !h.opts.ForceCheck || !isForceCheckAuthorized(h.opts, r.Header) {
+				writeText(w, http.StatusForbidden, "force check is not allowed\n")
+				return
+			}
+			h.collector.CheckNow(h.opts.ForceCheckTimeout)
+		}
+		h.writeHealth(w, r, false, parseTags(r.URL.Query().Get("tag")))
+	default:
+		tag, ok := h.tagRoute(sub)
+		if !ok {
+			http.NotFound(w, r)
+		} else if h.authorize(w, r) {
+			h.writeHealth(w, r, false, []string{tag})
+		}
+	}
+}
+
+// subPath method returns given request path relative to the health check
+// path, it returns false if the request path is not under it.
+func (h *httpHandler) subPath(p string) (string, bool) {
+	p, base := path.Clean("/"+p), path.Clean(h.opts.Path)
+	if p == base {
+		return "", true
+	}
+	if base != "/" {
+		base += "/"
+	}
+	if !strings.HasPrefix(p, base) {
+		return "", false
+	}
+	return p[len(base):], true
+}
+
+// tagRoute method returns the tag if given sub-path is one of the tag
+// routes, refer to `RegisterOptions.TagRoutes`.
+func (h *httpHandler) tagRoute(sub string) (string, bool) {
+	if !strings.HasPrefix(sub, tagRoutePrefix+"/") {
+		return "", false
+	}
+	tag := sub[len(tagRoutePrefix)+1:]
+	for _, t := range h.opts.TagRoutes {
+		if t == tag {
+			return tag, true
+		}
+	}
+	return "", false
+}
+
+func (h *httpHandler) authorize(w http.ResponseWriter, r *http.Request) bool {
+	if isRequestAuthorized(h.opts, r) {
+		return true
+	}
+	w.Header().Set("WWW-Authenticate", authChallenge(h.opts))
+	writeText(w, http.StatusUnauthorized, "401 Unauthorized\n")
+	return false
+}
+
+func (h *httpHandler) writeHealth(w http.ResponseWriter, r *http.Request, ready bool, tags []string) {
+	state, status := h.collector.currentStatus(ready, tags)
+	opts, err := responseOptions(h.opts, r)
+	if err != nil {
+		writeText(w, http.StatusBadRequest, err.Error()+"\n")
+		return
+	}
+	w.Header().Add("Vary", "Accept")
+	if etag, lastModified := h.collector.healthValidators(state, status, opts); len(etag) > 0 {
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+		if isNotModified(r.Header, etag, lastModified) {
+			w.Header().Set(HeaderHealthStatus, string(status))
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+	if r.Method == http.MethodHead {
+		// status only, response is not marshaled
+		code := healthStatusCode(state, status, opts)
+		h.writeHealthHeader(w, state, status, code, healthContentType(opts), opts)
+		return
+	}
+	code, contentType, body, err := h.collector.healthResponse(state, status, opts)
+	if err != nil {
+		writeText(w, http.StatusInternalServerError, "unable to marshal response\n")
+		return
+	}
+	if opts.GzipMinSize > 0 {
+		w.Header().Add("Vary", "Accept-Encoding")
+	}
+	if gz, ok := gzipBody(body, r.Header, opts); ok {
+		w.Header().Set("Content-Encoding", "gzip")
+		body = gz
+	}
+	h.writeHealthHeader(w, state, status, code, contentType, opts)
+	_, _ = w.Write(body)
+}
+
+func (h *httpHandler) writeHealthHeader(w http.ResponseWriter, state *snapshot, status AggregateStatus, code int, contentType string, opts RegisterOptions) {
+	w.Header().Set(HeaderHealthStatus, string(status))
+	w.Header().Set(HeaderHealthScore, formatFloat(state.score))
+	if h.collector.IsPaused() {
+		w.Header().Set(HeaderHealthPaused, "true")
+	}
+	w.Header().Set("Content-Type", contentType)
+	if retryAfter := h.collector.retryAfter(opts, code); len(retryAfter) > 0 {
+		w.Header().Set("Retry-After", retryAfter)
+	}
+	w.WriteHeader(code)
+}
+
+func writeText(w http.ResponseWriter, code int, text string) {
+	w.Header().Set("Content-Type", textContentType)
+	w.WriteHeader(code)
+	_, _ = w.Write([]byte(text))
+}
+
+//‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Response methods shared by aah controller and net/http handler
+//______________________________________________________________________________
+
+// currentStatus method returns the latest snapshot and its aggregate status,
+// narrowed to the reporters tagged with any of the given tags. Status is
+// `Unhealthy` while draining. For readiness it is `Unhealthy` once the
+// application begins to shutdown too. Except readiness, it is `Starting`
+// within the startup grace period.
+func (c *Collector) currentStatus(ready bool, tags []string) (*snapshot, AggregateStatus) {
+	state := c.tagged(c.load(), tags)
+	status := state.status
+	if !ready && c.IsStarting() {
+		status = Starting
+	}
+	if c.IsDraining() || (ready && c.isShuttingDown()) {
+		status = Unhealthy
+	}
+	return state, status
+}
+
+// healthResponse method returns the status code, content type and body of
+// the health check response as per given options.
+func (c *Collector) healthResponse(state *snapshot, status AggregateStatus, opts RegisterOptions) (int, string, []byte, error) {
+	buf := new(bytes.Buffer)
+	err := opts.Encoder.Encode(buf, c.healthReport(state, status, opts))
+	return healthStatusCode(state, status, opts), healthContentType(opts), buf.Bytes(), err
+}
+
+// healthReport method returns the structured health check report of given
+// snapshot for the response encoder.
+func (c *Collector) healthReport(state *snapshot, status AggregateStatus, opts RegisterOptions) *HealthReport {
+	report := &HealthReport{
+		Status:    status,
+		Score:     state.score,
+		Exposure:  opts.Exposure,
+		Service:   opts.ServiceID,
+		Version:   opts.ReleaseID,
+		BuildTime: opts.BuildTime,
+		Instance:  c.Instance(),
+		Uptime:    time.Since(processStart),
+		Timestamp: c.clock.Now(),
+		Metadata:  opts.Metadata,
+		Paused:    c.IsPaused(),
+		Pretty:    opts.pretty,
+	}
+	if opts.Exposure != ExposureSummary {
+		report.Checks = c.withAvailability(c.resultsWithStaleness(state.results))
+	}
+	return report
+}
+
+// healthContentType returns the content type of the health check response
+// for given options.
+func healthContentType(opts RegisterOptions) string {
+	return opts.Encoder.ContentType()
+}
+
+// healthStatusCode returns the HTTP status code of the health check
+// response for given status, or the snapshot's score if score threshold is
+// set. Status differs from the snapshot's status only when it is forced
+// unhealthy, e.g. draining, which the score does not reflect.
+func healthStatusCode(state *snapshot, status AggregateStatus, opts RegisterOptions) int {
+	if status == Starting {
+		return opts.StartingStatusCode
+	}
+	if opts.ScoreThreshold > 0 {
+		if state.score < opts.ScoreThreshold || status != state.status {
+			return opts.UnhealthyStatusCode
+		}
+		return http.StatusOK
+	}
+	switch status {
+	case Degraded:
+		return opts.DegradedStatusCode
+	case Unhealthy:
+		return opts.UnhealthyStatusCode
+	}
+	return http.StatusOK
+}
+
+// healthValidators method returns the weak ETag and Last-Modified of the
+// health check response, derived from the snapshot version and response
+// options without marshaling the response. Envelope timestamp and uptime
+// are not part of it. They are empty if the response status code is not 2xx,
+// since conditional requests apply only to successful responses.
+func (c *Collector) healthValidators(state *snapshot, status AggregateStatus, opts RegisterOptions) (string, time.Time) {
+	if code := healthStatusCode(state, status, opts); code < 200 || code > 299 {
+		return "", time.Time{}
+	}
+	names := make([]string, 0, len(state.results))
+	for name := range state.results {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// result turns stale without a new snapshot, so its staleness is part of
+	// the validators
+	lastModified := state.updated
+	period := time.Duration(atomic.LoadInt64(&c.period))
+	now := c.clock.Now()
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%d|%s|%T|%s|%s|%t|%t", state.version, status, opts.Encoder,
+		opts.Encoder.ContentType(), opts.Exposure, opts.Envelope, c.IsPaused())
+	for _, name := range names {
+		h.Write([]byte("|" + name))
+		result := state.results[name]
+		if staleAt := result.LastChecked.Add(2 * period); period > 0 && !result.CircuitOpen && staleAt.Before(now) {
+			h.Write([]byte("!"))
+			if staleAt.After(lastModified) {
+				lastModified = staleAt
+			}
+		}
+	}
+	return fmt.Sprintf(`W/"%016x"`, h.Sum64()), lastModified
+}
+
+// isNotModified returns true if the conditional request headers match given
+// validators. `If-None-Match` decides whenever the ETag is present, since
+// Last-Modified is of second precision and the snapshots within a second
+// share it, `If-Modified-Since` is evaluated only without the ETag.
+func isNotModified(hdr http.Header, etag string, lastModified time.Time) bool {
+	if len(etag) > 0 {
+		for _, tag := range strings.Split(hdr.Get("If-None-Match"), ",") {
+			tag = strings.TrimSpace(tag)
+			if tag == "*" || (len(tag) > 0 && strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(etag, "W/")) {
+				return true
+			}
+		}
+		return false
+	}
+	ims, err := http.ParseTime(hdr.Get("If-Modified-Since"))
+	return err == nil && !lastModified.Truncate(time.Second).After(ims)
+}
+
+// retryAfter method returns the `Retry-After` header value for the health
+// check response of given status code, it is the check interval in seconds
+// rounded up. It returns empty if not enabled or status code is not an error.
+func (c *Collector) retryAfter(opts RegisterOptions, code int) string {
+	if !opts.RetryAfter || code < 400 {
+		return ""
+	}
+	interval := time.Duration(atomic.LoadInt64(&c.period))
+	return strconv.Itoa(int((interval + time.Second - 1) / time.Second))
+}
+
+// historyResponse returns the reporters history as per exposure level.
+func historyResponse(c *Collector, opts RegisterOptions) map[string][]HistoryEntry {
+	histories := c.Histories()
+	if opts.Exposure == ExposureSummary {
+		for _, entries := range histories {
+			for i := range entries {
+				entries[i].Error = ""
+			}
+		}
+	}
+	return histories
+}
+
+// responseOptions returns the options of health check response as per
+// query parameters `verbose`, `pretty` and `format` along with `Accept`
+// header of the request.
+func responseOptions(opts RegisterOptions, r *http.Request) (RegisterOptions, error) {
+	query := r.URL.Query()
+	opts = exposureOptions(opts, query.Get("verbose"))
+	opts.pretty = query.Get("pretty") == "true"
+	encoder, err := negotiateEncoder(opts, r)
+	if err != nil {
+		return opts, err
+	}
+	opts.Encoder = encoder
+	return opts, nil
+}
+
+// gzipBody returns the gzip compressed body if the client accepts gzip
+// encoding and body size is at least `RegisterOptions.GzipMinSize`.
+func gzipBody(body []byte, hdr http.Header, opts RegisterOptions) ([]byte, bool) {
+	if opts.GzipMinSize <= 0 || len(body) < opts.GzipMinSize || !acceptsGzip(hdr) {
+		return nil, false
+	}
+	buf := new(bytes.Buffer)
+	gw := gzipWriters.Get().(*gzip.Writer)
+	defer gzipWriters.Put(gw)
+	gw.Reset(buf)
+	if _, err := gw.Write(body); err != nil {
+		return nil, false
+	}
+	if err := gw.Close(); err != nil {
+		return nil, false
+	}
+	return buf.Bytes(), true
+}
+
+// acceptsGzip returns true if `Accept-Encoding` header allows gzip, i.e.
+// `gzip` or `*` with non-zero quality, `gzip` takes precedence over `*`.
+func acceptsGzip(hdr http.Header) bool {
+	gzipQ, anyQ := -1.0, -1.0
+	for _, v := range hdr.Values("Accept-Encoding") {
+		for _, enc := range strings.Split(v, ",") {
+			params := strings.Split(enc, ";")
+			switch strings.ToLower(strings.TrimSpace(params[0])) {
+			case "gzip":
+				gzipQ = encodingQuality(params[1:])
+			case "*":
+				anyQ = encodingQuality(params[1:])
+			}
+		}
+	}
+	if gzipQ >= 0 {
+		return gzipQ > 0
+	}
+	return anyQ > 0
+}
+
+// encodingQuality returns the quality value `q` of the content coding
+// parameters, default is 1 and invalid value is 0.
+func encodingQuality(params []string) float64 {
+	for _, param := range params {
+		name, value, _ := strings.Cut(param, "=")
+		if strings.TrimSpace(name) != "q" {
+			continue
+		}
+		q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return 0
+		}
+		return q
+	}
+	return 1
+}
+
+// exposureOptions returns the options with `ExposureVerbose` if query
+// parameter `verbose=true` is allowed to upgrade the exposure level.
+func exposureOptions(opts RegisterOptions, verbose string) RegisterOptions {
+	if opts.AllowVerboseQuery && verbose == "true" {
+		opts.Exposure = ExposureVerbose
+	}
+	return opts
+}
+
+func isForceCheckAuthorized(opts RegisterOptions, hdr http.Header) bool {
+	if len(opts.ForceCheckToken) == 0 {
+		return true
+	}
+	token := strings.TrimPrefix(hdr.Get("Authorization"), "Bearer ")
+	return subtle.ConstantTimeCompare([]byte(token), []byte(opts.ForceCheckToken)) == 1
+}
+
+// isRequestAuthorized returns true if auth is not configured or the request
+// satisfies either one of the configured auth settings.
+func isRequestAuthorized(opts RegisterOptions, r *http.Request) bool {
+	if len(opts.AuthToken) == 0 && len(opts.BasicAuthUsername) == 0 && opts.AuthValidator == nil {
+		return true
+	}
+	if len(opts.AuthToken) > 0 {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(opts.AuthToken)) == 1 {
+			return true
+		}
+	}
+	if len(opts.BasicAuthUsername) > 0 {
+		if username, password, ok := r.BasicAuth(); ok &&
+			subtle.ConstantTimeCompare([]byte(username), []byte(opts.BasicAuthUsername)) == 1 &&
+			subtle.ConstantTimeCompare([]byte(password), []byte(opts.BasicAuthPassword)) == 1 {
+			return true
+		}
+	}
+	return opts.AuthValidator != nil && opts.AuthValidator(r)
+}
+
+// authChallenge returns the `WWW-Authenticate` header value for the
+// configured auth settings.
+func authChallenge(opts RegisterOptions) string {
+	if len(opts.BasicAuthUsername) > 0 {
+		return `Basic realm="health"`
+	}
+	return "Bearer"
+}
+
+// isClientAllowed returns true if allowlist is not configured or the remote
+// address of the request belongs to one of the allowed networks.
+func isClientAllowed(opts RegisterOptions, r *http.Request) bool {
+	if len(opts.allowedNets) == 0 {
+		return true
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, n := range opts.allowedNets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseCIDR parses given CIDR range, a plain IP address is treated as
+// single host range.
+func parseCIDR(cidr string) (*net.IPNet, error) {
+	if !strings.Contains(cidr, "/") {
+		ip := net.ParseIP(cidr)
+		if ip == nil {
+			return nil, fmt.Errorf("health: invalid allowed CIDR '%s'", cidr)
+		}
+		bits := 8 * net.IPv6len
+		if ip4 := ip.To4(); ip4 != nil {
+			ip, bits = ip4, 8*net.IPv4len
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("health: invalid allowed CIDR '%s'", cidr)
+	}
+	return ipNet, nil
+}
@@ -0,0 +1,405 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthHTTPHandler(t *testing.T) {
+	collector := newCollector()
+	reporter := &toggleReporter{}
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: reporter})
+	collector.runChecks()
+
+	mux := http.NewServeMux()
+	mux.Handle("/healthcheck", collector.Handler())
+	mux.Handle("/healthcheck/", collector.Handler())
+	mux.Handle("/ping", collector.PingHandler())
+
+	testcases := []struct {
+		method, path string
+		code         int
+		contains     string
+	}{
+		{method: http.MethodGet, path: "/healthcheck", code: http.StatusOK, contains: `"db":{"status":"OK"`},
+		{method: http.MethodGet, path: "/healthcheck/live", code: http.StatusOK, contains: "alive"},
+		{method: http.MethodGet, path: "/healthcheck/ready", code: http.StatusOK, contains: `"db"`},
+		{method: http.MethodGet, path: "/healthcheck/metrics", code: http.StatusOK, contains: "health_up 1"},
+		{method: http.MethodGet, path: "/healthcheck?force=true", code: http.StatusForbidden},
+		{method: http.MethodPost, path: "/healthcheck", code: http.StatusMethodNotAllowed},
+		{method: http.MethodGet, path: "/ping", code: http.StatusOK, contains: "pong!"},
+		{method: http.MethodGet, path: "/healthcheck/", code: http.StatusOK, contains: `"db"`},
+		{method: http.MethodGet, path: "/healthcheck/unknown", code: http.StatusNotFound},
+		{method: http.MethodGet, path: "/healthcheck/db/live", code: http.StatusNotFound},
+		{method: http.MethodGet, path: "/healthcheck/tags/core", code: http.StatusNotFound},
+	}
+	for _, tc := range testcases {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(tc.method, tc.path, nil))
+		assert.Equal(t, tc.code, w.Code, tc.path)
+		assert.Contains(t, w.Body.String(), tc.contains, tc.path)
+	}
+
+	reporter.set(errors.New("down"))
+	collector.runChecks()
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthcheck", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, string(Unhealthy), w.Header().Get(HeaderHealthStatus))
+}
+
+func TestHealthHTTPHandlerWithOptions(t *testing.T) {
+	collector := newCollector()
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: &toggleReporter{}})
+	collector.runChecks()
+
+	_, err := collector.HandlerWithOptions(RegisterOptions{Format: "csv"})
+	assert.NotNil(t, err)
+
+	h, err := collector.HandlerWithOptions(RegisterOptions{
+		Format:      FormatHealthJSON,
+		ForceCheck:  true,
+		DisableLive: true,
+	})
+	assert.Nil(t, err)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthcheck?force=true", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, HealthJSONContentType, w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `"status":"pass"`)
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthcheck/live", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// sub-paths of the configured health check path
+	h, _ = collector.HandlerWithOptions(RegisterOptions{Path: "/internal/health"})
+	for p, code := range map[string]int{
+		"/internal/health":       http.StatusOK,
+		"/internal/health/live":  http.StatusOK,
+		"/internal/health/ready": http.StatusOK,
+		"/healthcheck":           http.StatusNotFound,
+		"/healthcheck/live":      http.StatusNotFound,
+		"/internal/healthz/live": http.StatusNotFound,
+	} {
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, p, nil))
+		assert.Equal(t, code, w.Code, p)
+	}
+}
+
+func TestHealthHTTPHandlerUnhealthyStatusCode(t *testing.T) {
+	collector := newCollector()
+	collector.SetInterval(1500 * time.Millisecond)
+	reporter := &toggleReporter{}
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: reporter})
+	collector.runChecks()
+
+	_, err := collector.HandlerWithOptions(RegisterOptions{UnhealthyStatusCode: http.StatusOK})
+	assert.EqualError(t, err, "health: unhealthy status code must be 4xx or 5xx, got 200")
+
+	h, err := collector.HandlerWithOptions(RegisterOptions{
+		UnhealthyStatusCode: http.StatusTooManyRequests,
+		RetryAfter:          true,
+	})
+	assert.Nil(t, err)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthcheck", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Retry-After"))
+
+	reporter.set(errors.New("down"))
+	collector.runChecks()
+	for _, p := range []string{"/healthcheck", "/healthcheck/ready"} {
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, p, nil))
+		assert.Equal(t, http.StatusTooManyRequests, w.Code, p)
+		assert.Equal(t, "2", w.Header().Get("Retry-After"), p)
+	}
+
+	// Retry-After is not added by default
+	w = httptest.NewRecorder()
+	collector.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthcheck", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Empty(t, w.Header().Get("Retry-After"))
+}
+
+func TestHealthHTTPHandlerConditional(t *testing.T) {
+	collector := newCollector()
+	reporter := &toggleReporter{}
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: reporter})
+	collector.runChecks()
+	h := collector.Handler()
+
+	get := func(p string, hdr map[string]string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, p, nil)
+		for k, v := range hdr {
+			r.Header.Set(k, v)
+		}
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	w := get("/healthcheck", nil)
+	etag, lastModified := w.Header().Get("ETag"), w.Header().Get("Last-Modified")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, strings.HasPrefix(etag, `W/"`))
+	assert.NotEmpty(t, lastModified)
+
+	w = get("/healthcheck", map[string]string{"If-None-Match": `"other", ` + etag})
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+	assert.Equal(t, etag, w.Header().Get("ETag"))
+	assert.Equal(t, string(Healthy), w.Header().Get(HeaderHealthStatus))
+
+	// ETag decides, Last-Modified is of second precision
+	w = get("/healthcheck", map[string]string{"If-Modified-Since": lastModified})
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = get("/healthcheck", map[string]string{"If-None-Match": `"other"`, "If-Modified-Since": lastModified})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// response options are part of the ETag
+	w = get("/healthcheck?tag=core", map[string]string{"If-None-Match": etag})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEqual(t, etag, w.Header().Get("ETag"))
+
+	// new check results
+	collector.runChecks()
+	w = get("/healthcheck", map[string]string{"If-None-Match": etag})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEqual(t, etag, w.Header().Get("ETag"))
+
+	// conditional request is not applicable to unhealthy response
+	reporter.set(errors.New("down"))
+	collector.runChecks()
+	w = get("/healthcheck", map[string]string{"If-None-Match": "*"})
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Empty(t, w.Header().Get("ETag"))
+}
+
+func TestHealthIsNotModified(t *testing.T) {
+	lastModified := time.Date(2026, 1, 2, 10, 0, 0, 500, time.UTC)
+	hdr := func(k, v string) http.Header { return http.Header{k: []string{v}} }
+	assert.True(t, isNotModified(hdr("If-None-Match", `"abc"`), `W/"abc"`, lastModified))
+	assert.False(t, isNotModified(hdr("If-None-Match", `"abd"`), `W/"abc"`, lastModified))
+	assert.False(t, isNotModified(hdr("If-Modified-Since", "Fri, 02 Jan 2026 10:00:00 GMT"), `W/"abc"`, lastModified))
+	assert.True(t, isNotModified(hdr("If-Modified-Since", "Fri, 02 Jan 2026 10:00:00 GMT"), "", lastModified))
+	assert.False(t, isNotModified(hdr("If-Modified-Since", "Fri, 02 Jan 2026 09:59:59 GMT"), "", lastModified))
+	assert.False(t, isNotModified(hdr("If-Modified-Since", "invalid"), "", lastModified))
+	assert.False(t, isNotModified(http.Header{}, `W/"abc"`, lastModified))
+}
+
+func TestHealthHTTPHandlerHead(t *testing.T) {
+	collector := newCollector()
+	reporter := &toggleReporter{}
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: reporter})
+	collector.runChecks()
+
+	h, _ := collector.HandlerWithOptions(RegisterOptions{Format: FormatHealthJSON})
+	head := func(p string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodHead, p, nil))
+		return w
+	}
+
+	w := head("/healthcheck")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, HealthJSONContentType, w.Header().Get("Content-Type"))
+	assert.Equal(t, string(Healthy), w.Header().Get(HeaderHealthStatus))
+	assert.NotEmpty(t, w.Header().Get("ETag"))
+	assert.Empty(t, w.Body.String())
+
+	reporter.set(errors.New("down"))
+	collector.runChecks()
+	for _, p := range []string{"/healthcheck", "/healthcheck/ready"} {
+		w = head(p)
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code, p)
+		assert.Equal(t, string(Unhealthy), w.Header().Get(HeaderHealthStatus), p)
+		assert.Empty(t, w.Body.String(), p)
+	}
+
+	w = httptest.NewRecorder()
+	collector.PingHandler().ServeHTTP(w, httptest.NewRequest(http.MethodHead, "/ping", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestHealthHTTPHandlerPrettyAndGzip(t *testing.T) {
+	collector := newCollector()
+	for i := 0; i < 30; i++ {
+		_ = collector.AddReporter(&Config{Name: fmt.Sprintf("reporter-%02d", i), Reporter: &toggleReporter{}})
+	}
+	collector.runChecks()
+	h := collector.Handler()
+
+	get := func(p, acceptEncoding string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, p, nil)
+		if len(acceptEncoding) > 0 {
+			r.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	compact := get("/healthcheck", "")
+	assert.Empty(t, compact.Header().Get("Content-Encoding"))
+	assert.Equal(t, []string{"Accept", "Accept-Encoding"}, compact.Header().Values("Vary"))
+	assert.False(t, strings.Contains(compact.Body.String(), "\n"))
+
+	pretty := get("/healthcheck?pretty=true", "")
+	assert.Contains(t, pretty.Body.String(), "\n  \"reporter-00\": {\n")
+
+	w := get("/healthcheck", "br, gzip;q=0.8")
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	gr, err := gzip.NewReader(w.Body)
+	if assert.NoError(t, err) {
+		body, _ := io.ReadAll(gr)
+		assert.Equal(t, compact.Body.String(), string(body))
+	}
+
+	// small response is not compressed
+	w = get("/healthcheck?tag=none", "gzip")
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+
+	// disabled
+	h, _ = collector.HandlerWithOptions(RegisterOptions{GzipMinSize: -1})
+	w = get("/healthcheck", "gzip")
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Equal(t, []string{"Accept"}, w.Header().Values("Vary"))
+}
+
+func TestHealthAcceptsGzip(t *testing.T) {
+	for v, expected := range map[string]bool{
+		"":                  false,
+		"gzip":              true,
+		"GZIP":              true,
+		"deflate, gzip":     true,
+		"*":                 true,
+		"gzip;q=0":          false,
+		"gzip; q=0.000":     false,
+		"gzip;q=0.5":        true,
+		"br, deflate":       false,
+		"gzip;q=invalid":    false,
+		"identity, *;q=0.1": true,
+		"*, gzip;q=0":       false,
+		"gzip;q=0, *":       false,
+		"*;q=0, gzip":       true,
+		"gzip;level=1;q=0":  false,
+		"gzip;level=1":      true,
+	} {
+		assert.Equal(t, expected, acceptsGzip(http.Header{"Accept-Encoding": []string{v}}), v)
+	}
+}
+
+func TestHealthHTTPHandlerAuth(t *testing.T) {
+	collector := newCollector()
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: &toggleReporter{}})
+	collector.runChecks()
+
+	h, err := collector.HandlerWithOptions(RegisterOptions{
+		AuthToken:         "secret",
+		BasicAuthUsername: "monitor",
+		BasicAuthPassword: "pass",
+		AuthValidator: func(r *http.Request) bool {
+			return r.Header.Get("X-Internal") == "yes"
+		},
+	})
+	assert.Nil(t, err)
+
+	testcases := []struct {
+		path   string
+		header http.Header
+		code   int
+	}{
+		{path: "/healthcheck", code: http.StatusUnauthorized},
+		{path: "/healthcheck/ready", code: http.StatusUnauthorized},
+		{path: "/healthcheck/metrics", code: http.StatusUnauthorized},
+		{path: "/healthcheck/live", code: http.StatusOK},
+		{path: "/healthcheck", header: http.Header{"Authorization": {"Bearer wrong"}}, code: http.StatusUnauthorized},
+		{path: "/healthcheck", header: http.Header{"Authorization": {"Bearer secret"}}, code: http.StatusOK},
+		{path: "/healthcheck", header: http.Header{"Authorization": {"Basic bW9uaXRvcjpwYXNz"}}, code: http.StatusOK},
+		{path: "/healthcheck", header: http.Header{"X-Internal": {"yes"}}, code: http.StatusOK},
+	}
+	for _, tc := range testcases {
+		r := httptest.NewRequest(http.MethodGet, tc.path, nil)
+		for k, v := range tc.header {
+			r.Header[k] = v
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		assert.Equal(t, tc.code, w.Code, tc.path)
+		if tc.code == http.StatusUnauthorized {
+			assert.Equal(t, `Basic realm="health"`, w.Header().Get("WWW-Authenticate"))
+		}
+	}
+}
+
+func TestHealthHTTPHandlerAllowedCIDRs(t *testing.T) {
+	collector := newCollector()
+
+	_, err := collector.HandlerWithOptions(RegisterOptions{AllowedCIDRs: []string{"10.0.0.0/33"}})
+	assert.NotNil(t, err)
+
+	h, err := collector.HandlerWithOptions(RegisterOptions{
+		AllowedCIDRs:     []string{"10.0.0.0/8", "192.168.1.10", "fd00::/8"},
+		DeniedStatusCode: http.StatusNotFound,
+	})
+	assert.Nil(t, err)
+
+	testcases := []struct {
+		remoteAddr string
+		code       int
+	}{
+		{remoteAddr: "10.1.2.3:5000", code: http.StatusOK},
+		{remoteAddr: "192.168.1.10:5000", code: http.StatusOK},
+		{remoteAddr: "192.168.1.11:5000", code: http.StatusNotFound},
+		{remoteAddr: "[fd00::1]:5000", code: http.StatusOK},
+		{remoteAddr: "[::1]:5000", code: http.StatusNotFound},
+		{remoteAddr: "invalid", code: http.StatusNotFound},
+	}
+	for _, tc := range testcases {
+		r := httptest.NewRequest(http.MethodGet, "/healthcheck/live", nil)
+		r.RemoteAddr = tc.remoteAddr
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		assert.Equal(t, tc.code, w.Code, tc.remoteAddr)
+	}
+}
+
+func TestHealthHTTPHandlerExposure(t *testing.T) {
+	collector := newCollector()
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: &toggleReporter{err: errors.New("dial tcp db.internal:5432")}})
+	collector.runChecks()
+
+	for _, allow := range []bool{false, true} {
+		h, err := collector.HandlerWithOptions(RegisterOptions{
+			Exposure:          ExposureSummary,
+			AllowVerboseQuery: allow,
+		})
+		assert.Nil(t, err)
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthcheck", nil))
+		assert.JSONEq(t, `{"status":"unhealthy"}`, w.Body.String())
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthcheck?verbose=true", nil))
+		assert.Equal(t, allow, strings.Contains(w.Body.String(), "db.internal"))
+	}
+}
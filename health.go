@@ -6,21 +6,26 @@
 package health
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"math/rand"
+	"net"
 	"net/http"
 	"path"
+	"runtime/debug"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	aah "aahframe.work"
 	"aahframe.work/ainsp"
+	"aahframe.work/log"
 	"aahframe.work/router"
 )
 
-var (
-	defaultCollector *Collector
-)
-
 // Reporter interface for a dependency that can be health-checked.
 type Reporter interface {
 	// Check will return nil if dependency is reachable/healthy
@@ -28,111 +33,1451 @@ type Reporter interface {
 	Check() error
 }
 
+// ReporterContext interface for a dependency that can be health-checked
+// with a context. Collector prefers it over `Reporter.Check` when implemented,
+// the context gets cancelled once check deadline is exceeded.
+type ReporterContext interface {
+	// CheckContext will return nil if dependency is reachable/healthy,
+	// it should return promptly when given context is done.
+	CheckContext(ctx context.Context) error
+}
+
+// ReporterDetails interface for a dependency that reports additional details
+// along with its health check, for e.g. members of `AllOf`. Collector prefers
+// it over `ReporterContext` and `Reporter.Check` when implemented, details
+// are reported in `CheckResult.Details`.
+type ReporterDetails interface {
+	// CheckDetails will return nil error if dependency is reachable/healthy,
+	// details are reported regardless of the error.
+	CheckDetails(ctx context.Context) (map[string]interface{}, error)
+}
+
 // Config struct contains a Reporter configuration
 type Config struct {
 	Name     string
 	Reporter Reporter
-	SoftFail bool // if true it will allow errors so won't report unhealthy
+	Timeout  time.Duration // deadline for `ReporterContext` and `ReporterDetails` checks, 0 means no deadline
+
+	// Severity of the reporter's failure, default is `SeverityCritical`.
+	Severity Severity
+
+	// SoftFail is same as `SeverityWarning`, it is considered only if
+	// Severity is not set.
+	//
+	// Deprecated: use Severity instead.
+	SoftFail bool
+
+	// FailureThreshold is the number of consecutive failures required to
+	// mark a healthy reporter as unhealthy, default is 1.
+	FailureThreshold int
+
+	// SuccessThreshold is the number of consecutive successes required to
+	// mark an unhealthy reporter as healthy, default is 1.
+	SuccessThreshold int
+
+	// DeferInitialCheck skips the immediate check on `AddReporter` and
+	// `UpdateReporter`, reporter is checked on next periodic check.
+	DeferInitialCheck bool
+
+	// SlowThreshold marks the check result as slow and logs a warning when
+	// the check takes longer than it, 0 means collector's slow threshold.
+	SlowThreshold time.Duration
+
+	// Retries is the number of times a failing check is retried within the
+	// same check cycle before the failure is recorded. RetryBackoff is the
+	// delay before the first retry, it doubles on every subsequent retry.
+	Retries      int
+	RetryBackoff time.Duration
+
+	// CircuitOpenAfter opens the circuit of the reporter which has been
+	// failing continuously for the given period, its checks are backed off
+	// exponentially up to CircuitMaxBackoff (default is 5 minutes) and the
+	// result is flagged with `CheckResult.CircuitOpen`. Circuit is closed on
+	// first successful check, 0 disables it.
+	CircuitOpenAfter  time.Duration
+	CircuitMaxBackoff time.Duration
+
+	// FlapWindow is the number of recent checks considered for flap
+	// detection, it is disabled if less than 3. Reporter is flapping when
+	// the weighted ratio of status changes within the window reaches
+	// FlapThreshold (default is 0.5), its status is held at the oldest
+	// status within the window and a single `EventFlapping` notification is
+	// emitted.
+	FlapWindow    int
+	FlapThreshold float64
+
+	// Maintenance windows of the reporter, failures within the window do
+	// not affect the global health and are flagged with
+	// `CheckResult.Maintenance`, status change notifications are skipped.
+	Maintenance []MaintenanceWindow
+
+	// Tags groups the reporters, e.g. `core`, `external`. Health check
+	// response can be narrowed to the tagged reporters using query parameter
+	// `tag` or `RegisterOptions.TagRoutes`, aggregate status is computed
+	// only over them.
+	Tags []string
+
+	// DependsOn is the names of reporters this reporter depends on, it is
+	// checked after them and skipped while any of them is unhealthy. Skipped
+	// result is reported as `StatusKO` with `CheckResult.Skipped` flag.
+	DependsOn []string
+
+	// Weight of the reporter in the health score, default is 1. Refer to
+	// `Collector.Score`.
+	Weight float64
+
+	// Metadata of the reporter, such as owner team, runbook URL and
+	// environment, echoed in its check result so that on-call engineers
+	// find them right in the health check response. It is copied when the
+	// reporter is added or updated.
+	Metadata map[string]string
 }
 
+var (
+	jitterMu   sync.Mutex
+	jitterRand = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
 //‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
 // Collector struct and its methods
 //______________________________________________________________________________
 
 // Collector contains the health reporters to check and its responded
 // data for the JSON response.
+//
+// Check results are published as an immutable snapshot, so that read path
+// such as health check response never contends with running checks.
 type Collector struct {
-	globalHealth  bool
-	reporters     map[string]*Config
-	reportersData map[string]string
-	mu            sync.RWMutex
+	ctx          context.Context
+	cancel       context.CancelFunc
+	state        atomic.Value // *snapshot
+	shuttingDown int32
+	draining     int32
+	started      int32
+	paused       int32
+	startupGrace time.Duration
+	createdAt    time.Time
+	interval     chan time.Duration
+	intervalSet  bool
+	period       int64 // current check interval in nanoseconds
+	jitter       int64 // maximum random delay added to the interval in nanoseconds
+	clock        Clock
+	initialDelay time.Duration
+	checkOnAdd   bool
+	forceMu      sync.Mutex
+	forceDone    chan struct{}
+	cycleMu      sync.Mutex
+	cycleDone    chan struct{} // closed when the running check cycle ends
+	skipped      uint64        // check cycles not started due to the running one
+	timeout      time.Duration
+	timeouts     map[string]time.Duration
+	slow         time.Duration
+	cycleTimeout time.Duration
+	inflight     map[string]bool
+	maxChecks    int
+	logger       log.Loggerer
+	reporters    map[string]*Config
+	circuits     map[string]*circuit
+	flaps        map[string]*flapState
+	muted        map[string]bool
+	durations    map[string]*histogram
+	failures     map[string]uint64
+	history      map[string]*historyRing
+	availability map[string]*availabilityRing
+	historySize  int
+	store        Store
+	instance     string
+	restore      bool
+	restoreAge   time.Duration
+	restored     map[string]CheckResult
+	statusHooks  []StatusChangeFunc
+	healthHooks  []HealthChangeFunc
+	notifiers    []*notifierEntry
+	dropped      uint64 // notification events dropped due to the full queue
+	notifyPolicy NotifyPolicy
+	alerts       map[string]*alertState
+	trace        Tracer
+	emitters     []MetricsEmitter
+	logChecks    bool
+	configAdded  bool // reporters of app config are added
+	streamMu     sync.Mutex
+	subscribers  map[*subscriber]struct{}
+	mu           sync.RWMutex
+}
+
+// circuit struct holds the circuit breaker state of a failing reporter.
+type circuit struct {
+	failingSince time.Time
+	backoff      time.Duration
+	nextCheck    time.Time
+}
+
+// snapshot struct is an immutable view of the collector's check results.
+type snapshot struct {
+	healthy bool
+	status  AggregateStatus
+	score   float64
+	results map[string]CheckResult
+
+	// version is incremented on every publish and updated is its time, they
+	// validate the conditional health check requests.
+	version uint64
+	updated time.Time
 }
 
 // NewCollector method returns a `Collector` instance. It periodically checks
-// all its registered reporters.
-func NewCollector(interval time.Duration) *Collector {
-	defaultCollector = &Collector{
-		reporters:     make(map[string]*Config),
-		reportersData: make(map[string]string),
-		globalHealth:  true,
+// all its registered reporters until `Collector.Stop` is called. Reporters
+// are checked right away when added, unless `Config.DeferInitialCheck` is set.
+//
+// By default it checks every 10s or `health.interval` from aah application
+// config once registered and the first periodic check runs after 5s, refer
+// to `WithInterval` and `WithInitialDelay`.
+//
+//	collector := health.NewCollector(
+//	    health.WithInterval(30*time.Second),
+//	    health.WithMaxConcurrency(20),
+//	)
+func NewCollector(opts ...Option) *Collector {
+	c := newCollector()
+	c.checkOnAdd = true
+	c.initialDelay = 5 * time.Second
+	atomic.StoreInt64(&c.period, int64(10*time.Second))
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.createdAt = c.clock.Now()
+	if c.restore {
+		c.restoreFromStore()
 	}
 
+	interval := time.Duration(atomic.LoadInt64(&c.period))
+	go func(c *Collector, interval time.Duration) {
+		// initial delay + do initial runChecks, so we don't wait interval when app starts
+		delay := c.clock.NewTimer(c.withJitter(c.initialDelay))
+		select {
+		case <-delay.C():
+		case <-c.ctx.Done():
+			delay.Stop()
+			return
+		}
+		c.runChecks()
+
+		// timer to check reporters periodically using specified interval,
+		// it is reset with jitter on every tick
+		t := c.clock.NewTimer(c.withJitter(interval))
+		defer t.Stop()
+		for {
+			select {
+			case <-t.C():
+				if !c.IsPaused() {
+					c.tickChecks()
+				}
+				t.Reset(c.withJitter(time.Duration(atomic.LoadInt64(&c.period))))
+			case d := <-c.interval:
+				if !t.Stop() {
+					<-t.C()
+				}
+				t.Reset(c.withJitter(d))
+			case <-c.ctx.Done():
+				return
+			}
+		}
+	}(c, interval)
+
+	return c
+}
+
+func newCollector() *Collector {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &Collector{
+		ctx:          ctx,
+		cancel:       cancel,
+		clock:        realClock{},
+		interval:     make(chan time.Duration, 1),
+		timeouts:     make(map[string]time.Duration),
+		inflight:     make(map[string]bool),
+		alerts:       make(map[string]*alertState),
+		reporters:    make(map[string]*Config),
+		circuits:     make(map[string]*circuit),
+		flaps:        make(map[string]*flapState),
+		muted:        make(map[string]bool),
+		durations:    make(map[string]*histogram),
+		failures:     make(map[string]uint64),
+		history:      make(map[string]*historyRing),
+		availability: make(map[string]*availabilityRing),
+		subscribers:  make(map[*subscriber]struct{}),
+		historySize:  defaultHistorySize,
+		store:        NewMemoryStore(),
+		instance:     defaultInstance(),
+		createdAt:    time.Now(),
+	}
+	c.state.Store(&snapshot{healthy: true, status: Healthy, score: 100, results: make(map[string]CheckResult), updated: time.Now()})
+	return c
+}
+
+// Stop method stops the periodic health checks and cancels the in-flight
+// `ReporterContext` checks. Stopped collector cannot be started again.
+func (c *Collector) Stop() {
+	c.cancel()
+}
+
+// SetInterval method changes the periodic check interval of the collector
+// started by `NewCollector`, it takes effect from the next tick.
+func (c *Collector) SetInterval(interval time.Duration) {
 	if interval <= 0 {
-		// if interval is negative or 0, default to 10s interval checks
-		interval = 10
+		return
+	}
+	atomic.StoreInt64(&c.period, int64(interval))
+	select {
+	case <-c.interval: // drop pending change
+	default:
 	}
-	go func(interval time.Duration) {
-		//sleep 5s + do initial runChecks, so we don't wait 10s when app starts
-		time.Sleep(5 * time.Second)
-		defaultCollector.runChecks()
+	c.interval <- interval
+}
 
-		// ticker to check reporters periodically using specified interval
-		t := time.NewTicker(interval * time.Second)
-		for range t.C {
-			defaultCollector.runChecks()
-		}
-	}(interval)
+// SetJitter method sets the maximum random delay added to the initial delay
+// and every check interval, so that the application instances don't check
+// the shared dependencies at the same time. It is 0 by default.
+func (c *Collector) SetJitter(jitter time.Duration) {
+	if jitter < 0 {
+		jitter = 0
+	}
+	atomic.StoreInt64(&c.jitter, int64(jitter))
+}
 
-	return defaultCollector
+// withJitter method returns given duration plus random delay up to the
+// collector's jitter.
+func (c *Collector) withJitter(d time.Duration) time.Duration {
+	jitter := atomic.LoadInt64(&c.jitter)
+	if jitter <= 0 {
+		return d
+	}
+	jitterMu.Lock()
+	defer jitterMu.Unlock()
+	return d + time.Duration(jitterRand.Int63n(jitter+1))
+}
+
+// resultsWithStaleness method returns a copy of given results with
+// `CheckResult.Stale` flag set on the results older than twice the check
+// interval, for e.g. check is hung.
+func (c *Collector) resultsWithStaleness(results map[string]CheckResult) map[string]CheckResult {
+	period := time.Duration(atomic.LoadInt64(&c.period))
+	if period <= 0 {
+		return results
+	}
+	staleBefore := c.clock.Now().Add(-2 * period)
+	marked := make(map[string]CheckResult, len(results))
+	for name, result := range results {
+		// checks are backed off deliberately while circuit is open
+		result.Stale = !result.CircuitOpen && result.LastChecked.Before(staleBefore)
+		marked[name] = result
+	}
+	return marked
 }
 
 // AddReporter method adds a dependency to health check reporter
 // that will be called per interval to get health report.
 func (c *Collector) AddReporter(config *Config) error {
 	c.mu.Lock()
-	defer c.mu.Unlock()
 	if _, exists := c.reporters[config.Name]; exists {
+		c.mu.Unlock()
 		return fmt.Errorf("health: reporter name '%s' already exists", config.Name)
 	}
+	if err := c.checkDependencies(config); err != nil {
+		c.mu.Unlock()
+		return err
+	}
+	config.Metadata = copyMetadata(config.Metadata)
 	c.reporters[config.Name] = config
+	wasHealthy, healthy := c.publishRestored(config.Name)
+	c.mu.Unlock()
+
+	if wasHealthy != healthy {
+		c.fireHealthChange(healthy)
+	}
+	c.initialCheck(config)
 	return nil
 }
 
-// RunChecks method performs a check in all the dependencies and update the global status
+// RemoveReporter method removes the health check reporter for given name,
+// its last health report is discarded and global health gets recomputed.
+func (c *Collector) RemoveReporter(name string) error {
+	c.mu.Lock()
+	if _, exists := c.reporters[name]; !exists {
+		c.mu.Unlock()
+		return fmt.Errorf("health: reporter name '%s' does not exist", name)
+	}
+	delete(c.reporters, name)
+	delete(c.circuits, name)
+	delete(c.flaps, name)
+	delete(c.muted, name)
+	delete(c.durations, name)
+	delete(c.failures, name)
+	delete(c.history, name)
+	delete(c.availability, name)
+	wasHealthy, healthy := c.publish(c.load().without(name))
+	c.mu.Unlock()
+
+	if wasHealthy != healthy {
+		c.fireHealthChange(healthy)
+	}
+	return nil
+}
+
+// UpdateReporter method replaces the existing health check reporter which
+// has the same name as given config. Its last health report is discarded
+// and global health gets recomputed.
+func (c *Collector) UpdateReporter(config *Config) error {
+	c.mu.Lock()
+	if _, exists := c.reporters[config.Name]; !exists {
+		c.mu.Unlock()
+		return fmt.Errorf("health: reporter name '%s' does not exist", config.Name)
+	}
+	if err := c.checkDependencies(config); err != nil {
+		c.mu.Unlock()
+		return err
+	}
+	config.Metadata = copyMetadata(config.Metadata)
+	c.reporters[config.Name] = config
+	delete(c.circuits, config.Name)
+	delete(c.flaps, config.Name)
+	delete(c.durations, config.Name)
+	delete(c.failures, config.Name)
+	delete(c.history, config.Name)
+	delete(c.availability, config.Name)
+	wasHealthy, healthy := c.publish(c.load().without(config.Name))
+	c.mu.Unlock()
+
+	if wasHealthy != healthy {
+		c.fireHealthChange(healthy)
+	}
+	c.initialCheck(config)
+	return nil
+}
+
+// initialCheck method checks the newly added reporter in the background,
+// so that its result is available before the next periodic check.
+func (c *Collector) initialCheck(config *Config) {
+	if c.checkOnAdd && !config.DeferInitialCheck && c.ctx.Err() == nil {
+		go c.checkReporters([]*Config{config})
+	}
+}
+
+// CheckNow method checks all the reporters right away and waits until the
+// checks are completed or given timeout elapses. It returns true if checks
+// are completed in time. Concurrent calls share the same check run.
+func (c *Collector) CheckNow(timeout time.Duration) bool {
+	c.forceMu.Lock()
+	done := c.forceDone
+	if done == nil {
+		done = make(chan struct{})
+		c.forceDone = done
+		go func() {
+			c.runChecks()
+			c.forceMu.Lock()
+			c.forceDone = nil
+			c.forceMu.Unlock()
+			close(done)
+		}()
+	}
+	c.forceMu.Unlock()
+
+	t := c.clock.NewTimer(timeout)
+	defer t.Stop()
+	select {
+	case <-done:
+		return true
+	case <-t.C():
+		return false
+	}
+}
+
+// tickChecks method performs the periodic check cycle, it is skipped if the
+// previous cycle is still running, so that slow reporters do not pile up
+// overlapping cycles.
+func (c *Collector) tickChecks() {
+	done, started := c.startCycle()
+	if !started {
+		c.skipCycle("previous check cycle is still running")
+		return
+	}
+	defer c.endCycle(done)
+	c.checkAll()
+}
+
+// runChecks method performs a check in all the dependencies and update the
+// global status. If a check cycle is already running, it waits for that
+// cycle instead of starting an overlapping one.
 func (c *Collector) runChecks() {
-	//create syncgroup and check all dependencies
-	var wg sync.WaitGroup
-	wg.Add(len(c.reporters))
-
-	globalHealthy := true
-	for _, cfg := range c.reporters {
-		go func(rc *Config) {
-			defer wg.Done()
-			//change the dependency health values
-			if err := rc.Reporter.Check(); err != nil {
-				if !rc.SoftFail {
-					c.mu.Lock()
-					globalHealthy = false
-					c.mu.Unlock()
-				}
-				c.mu.Lock()
-				c.reportersData[rc.Name] = "KO: " + err.Error()
-				c.mu.Unlock()
+	done, started := c.startCycle()
+	if !started {
+		c.skipCycle("waiting for the running check cycle")
+		<-done
+		return
+	}
+	defer c.endCycle(done)
+	c.checkAll()
+}
+
+// startCycle method marks the check cycle as running and returns true, if a
+// cycle is already running it returns false along with its done channel.
+func (c *Collector) startCycle() (chan struct{}, bool) {
+	c.cycleMu.Lock()
+	defer c.cycleMu.Unlock()
+	if c.cycleDone != nil {
+		return c.cycleDone, false
+	}
+	c.cycleDone = make(chan struct{})
+	return c.cycleDone, true
+}
+
+// endCycle method marks the running check cycle as ended.
+func (c *Collector) endCycle(done chan struct{}) {
+	c.cycleMu.Lock()
+	c.cycleDone = nil
+	c.cycleMu.Unlock()
+	close(done)
+}
+
+// skipCycle method counts the check cycle not started due to the running
+// one, refer to metric `health_check_cycles_skipped_total`.
+func (c *Collector) skipCycle(reason string) {
+	atomic.AddUint64(&c.skipped, 1)
+	if logger := c.log(); logger != nil {
+		logger.Debugf("health: check cycle skipped, %s", reason)
+	}
+}
+
+// checkAll method checks all the reporters except the ones with open circuit.
+func (c *Collector) checkAll() {
+	now := c.clock.Now()
+	c.mu.RLock()
+	reporters := make([]*Config, 0, len(c.reporters))
+	for name, cfg := range c.reporters {
+		if cb, found := c.circuits[name]; found && now.Before(cb.nextCheck) {
+			continue // circuit is open, check is backed off
+		}
+		reporters = append(reporters, cfg)
+	}
+	c.mu.RUnlock()
+
+	c.checkReporters(reporters)
+}
+
+// checkReporters method performs a check on given reporters concurrently,
+// bounded by `Collector.SetMaxConcurrentChecks`, and publishes the results.
+// Reporters are checked in the order of their dependencies, refer to
+// `Config.DependsOn`.
+func (c *Collector) checkReporters(reporters []*Config) {
+	ctx := c.ctx
+	if tracer := c.tracer(); tracer != nil {
+		var end func()
+		ctx, end = tracer.StartCycle(ctx, len(reporters))
+		defer end()
+	}
+	index := make(map[string]int, len(reporters))
+	for i, rc := range reporters {
+		index[rc.Name] = i
+	}
+	var deadline time.Time
+	if timeout := c.checkCycleTimeout(); timeout > 0 {
+		deadline = c.clock.Now().Add(timeout)
+	}
+	results := make([]*CheckResult, len(reporters))
+	for _, level := range dependencyLevels(reporters) {
+		pending := make([]int, 0, len(level))
+		for _, i := range level {
+			if parent := c.downDependency(reporters[i], index, results); len(parent) > 0 {
+				results[i] = c.skippedResult(reporters[i], parent)
 			} else {
-				c.mu.Lock()
-				c.reportersData[rc.Name] = "OK: Healthy"
-				c.mu.Unlock()
+				pending = append(pending, i)
 			}
-		}(cfg)
+		}
+		c.checkPending(ctx, reporters, pending, results, deadline)
 	}
 
-	// wait for all the deps to finish the checks
-	wg.Wait()
+	// update reporters and global health status
+	c.updateResults(reporters, results)
+	c.emitMetrics(ctx, reporters, results)
+}
 
-	// update global health status
-	if globalHealthy {
+// checkPending method checks the reporters of given indexes concurrently
+// and stores the results at the same indexes. If the deadline is non-zero,
+// it returns once the deadline is passed, the reporters yet to finish are
+// left running in the background and their results are timed out.
+func (c *Collector) checkPending(ctx context.Context, reporters []*Config, pending []int, results []*CheckResult, deadline time.Time) {
+	if len(pending) == 0 {
+		return
+	}
+	workers := c.maxConcurrentChecks()
+	if workers <= 0 || workers > len(pending) {
+		workers = len(pending)
+	}
+
+	// bounded pool of workers checks all the dependencies, buffered channels
+	// let the stragglers finish after the deadline without blocking
+	type checked struct {
+		i      int
+		result *CheckResult
+	}
+	done := make(chan checked, len(pending))
+	jobs := make(chan int, len(pending))
+	for _, i := range pending {
+		jobs <- i
+	}
+	close(jobs)
+	expired := make(chan struct{})
+	started := make([]int32, len(reporters))
+	for w := 0; w < workers; w++ {
+		go func() {
+			for i := range jobs {
+				select {
+				case <-expired:
+					continue // deadline passed, not started
+				default:
+				}
+				atomic.StoreInt32(&started[i], 1)
+				done <- checked{i: i, result: c.checkInflight(ctx, reporters[i], !deadline.IsZero())}
+			}
+		}()
+	}
+
+	var timeout <-chan time.Time
+	if !deadline.IsZero() {
+		timer := c.clock.NewTimer(deadline.Sub(c.clock.Now()))
+		defer timer.Stop()
+		timeout = timer.C()
+	}
+
+	// wait for all the deps to finish the checks or the deadline
+	finished := make([]bool, len(reporters))
+	for n := 0; n < len(pending); n++ {
+		select {
+		case r := <-done:
+			results[r.i] = r.result
+			finished[r.i] = true
+		case <-timeout:
+			close(expired)
+			for _, i := range pending {
+				if !finished[i] {
+					results[i] = c.timedOutResult(reporters[i], deadline, atomic.LoadInt32(&started[i]) == 1)
+				}
+			}
+			return
+		}
+	}
+}
+
+// checkInflight method checks the reporter same as `checkReporter`. If
+// track is true, the reporter still running from an earlier check cycle is
+// not checked again and it returns its timed out result.
+func (c *Collector) checkInflight(ctx context.Context, rc *Config, track bool) *CheckResult {
+	if !track {
+		return c.checkReporter(ctx, rc)
+	}
+	c.mu.Lock()
+	running := c.inflight[rc.Name]
+	c.inflight[rc.Name] = true
+	c.mu.Unlock()
+	if running {
+		return c.timedOutResult(rc, c.clock.Now(), true)
+	}
+	defer func() {
 		c.mu.Lock()
-		c.globalHealth = true
+		delete(c.inflight, rc.Name)
 		c.mu.Unlock()
+	}()
+	return c.checkReporter(ctx, rc)
+}
+
+// timedOutResult method returns the result of the reporter which did not
+// finish within the check cycle deadline.
+func (c *Collector) timedOutResult(rc *Config, at time.Time, running bool) *CheckResult {
+	msg := "timed out (not started)"
+	if running {
+		msg = "timed out (still running)"
+	}
+	return &CheckResult{
+		Status:      StatusKO,
+		Error:       msg,
+		ErrorType:   ErrorTypeTimeout,
+		LastChecked: at,
+		SoftFail:    rc.severity() != SeverityCritical,
+		Severity:    rc.severity(),
+	}
+}
+
+// checkCycleTimeout method returns the check cycle deadline of the collector.
+func (c *Collector) checkCycleTimeout() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cycleTimeout
+}
+
+// checkReporter method performs a check on given reporter within the check
+// run context and returns its result, it returns nil if the collector is
+// stopped meanwhile.
+func (c *Collector) checkReporter(ctx context.Context, rc *Config) *CheckResult {
+	var end func(CheckResult)
+	if tracer := c.tracer(); tracer != nil {
+		ctx, end = tracer.StartCheck(ctx, rc.Name)
+	}
+	start := c.clock.Now()
+	details, err := c.checkWithRetries(ctx, rc)
+	if c.ctx.Err() != nil {
+		// collector stopped, result is not meaningful
+		if end != nil {
+			end(CheckResult{Status: StatusKO, Error: c.ctx.Err().Error(), LastChecked: start})
+		}
+		return nil
+	}
+	severity := rc.severity()
+	var se *SeverityError
+	if errors.As(err, &se) && severityRank[se.Severity] < severityRank[severity] {
+		severity = se.Severity
+	}
+	result := &CheckResult{
+		Status:      StatusOK,
+		Duration:    c.clock.Now().Sub(start),
+		LastChecked: start,
+		SoftFail:    severity != SeverityCritical,
+		Severity:    severity,
+		Details:     details,
+	}
+	if threshold := c.slowThreshold(rc); threshold > 0 && result.Duration > threshold {
+		result.Slow = true
+		if logger := c.log(); logger != nil {
+			logger.Warnf("health: reporter '%s' check took %s, exceeds slow threshold %s",
+				rc.Name, result.Duration, threshold)
+		}
+	}
+	if err != nil {
+		result.Status = StatusKO
+		result.Error = err.Error()
+		result.ErrorType = classifyError(err)
+		if pe, ok := err.(*panicError); ok {
+			result.Details = map[string]interface{}{"stack": pe.stack}
+		}
+	}
+	if end != nil {
+		end(*result)
+	}
+	return result
+}
+
+type statusChange struct {
+	name     string
+	old, new Status
+}
+
+// updateResults method publishes a new snapshot with given check results
+// after applying the reporter's failure and success thresholds. Nil results
+// are skipped.
+func (c *Collector) updateResults(reporters []*Config, results []*CheckResult) {
+	var changes []statusChange
+	var flapping []StatusChangeEvent
+	var checked []checkLog
+	var streamed []streamChange
+	c.mu.Lock()
+	prev := c.load()
+	next := prev.without()
+	for i, rc := range reporters {
+		result := results[i]
+		// reporter might have been removed or updated meanwhile
+		if result == nil || c.reporters[rc.Name] != rc {
+			continue
+		}
+		last, found := prev.results[rc.Name]
+		if !found {
+			// collector assumes reporter is healthy until first check
+			last.Status = StatusOK
+		}
+		applyThresholds(rc, last, found, result)
+		result.Maintenance = inMaintenance(rc.Maintenance, result.LastChecked)
+		result.Muted = c.muted[rc.Name]
+		result.Metadata = rc.Metadata
+		c.recordHistory(rc.Name, result)
+		c.recordAvailability(rc.Name, result)
+		if c.updateFlapping(rc, result) {
+			flapping = append(flapping, StatusChangeEvent{
+				Reporter:  rc.Name,
+				OldStatus: string(last.Status),
+				NewStatus: EventFlapping,
+				Error:     result.Error,
+				SoftFail:  rc.severity() != SeverityCritical,
+				Severity:  rc.severity(),
+				Time:      result.LastChecked,
+			})
+		}
+		c.updateCircuit(rc, result)
+		next[rc.Name] = *result
+		if !result.Skipped {
+			c.observeDuration(rc.Name, result.Duration)
+			if !result.IsOK() {
+				c.failures[rc.Name]++
+			}
+		}
+		if last.Status != result.Status {
+			changes = append(changes, statusChange{name: rc.Name, old: last.Status, new: result.Status})
+			result := *result
+			streamed = append(streamed, streamChange{rc: rc, event: StreamEvent{
+				Type:           StreamEventStatus,
+				Reporter:       rc.Name,
+				PreviousStatus: last.Status,
+				Result:         &result,
+				Time:           result.LastChecked,
+			}})
+		}
+		checked = append(checked, checkLog{name: rc.Name, last: last.Status, result: *result})
+	}
+	wasHealthy, healthy := c.publish(next)
+	status := c.load().status
+	c.updateStartup(next)
+	c.mu.Unlock()
+
+	c.logResults(checked, prev.status, status)
+	for i := range streamed {
+		streamed[i].event.Status = status
+	}
+	c.broadcast(streamed)
+
+	for _, sc := range changes {
+		c.fireStatusChange(sc.name, sc.old, sc.new)
+	}
+	for _, e := range flapping {
+		c.enqueueEvent(e)
+	}
+	if wasHealthy != healthy {
+		c.fireHealthChange(healthy)
+	}
+	c.remindFailures()
+	c.saveToStore()
+}
+
+// publish method computes the global health of given results and stores it
+// as the current snapshot. It returns the previous and current global health.
+// Caller must hold the lock.
+func (c *Collector) publish(results map[string]CheckResult) (bool, bool) {
+	prev := c.load()
+	status := computeStatus(results)
+	healthy := status != Unhealthy
+	c.state.Store(&snapshot{
+		healthy: healthy,
+		status:  status,
+		score:   c.computeScore(results),
+		results: results,
+		version: prev.version + 1,
+		updated: c.clock.Now(),
+	})
+	return prev.healthy, healthy
+}
+
+// load method returns the current snapshot of check results, it must not
+// be modified.
+func (c *Collector) load() *snapshot {
+	return c.state.Load().(*snapshot)
+}
+
+// without method returns a copy of the snapshot results excluding the
+// given reporter names.
+func (s *snapshot) without(names ...string) map[string]CheckResult {
+	results := make(map[string]CheckResult, len(s.results))
+	for name, result := range s.results {
+		results[name] = result
+	}
+	for _, name := range names {
+		delete(results, name)
+	}
+	return results
+}
+
+// applyThresholds method updates the consecutive counters of the result and
+// retains previous status until reporter's threshold is reached. The very
+// first result of the reporter is taken as-is.
+func applyThresholds(rc *Config, prev CheckResult, hasPrev bool, result *CheckResult) {
+	if result.IsOK() {
+		result.ConsecutiveSuccesses = prev.ConsecutiveSuccesses + 1
 	} else {
-		c.mu.Lock()
-		c.globalHealth = false
-		c.mu.Unlock()
+		result.ConsecutiveFailures = prev.ConsecutiveFailures + 1
+	}
+	if !hasPrev || prev.Status == result.Status {
+		return
+	}
+
+	if result.IsOK() && result.ConsecutiveSuccesses < rc.SuccessThreshold {
+		result.Status = prev.Status
+		result.Error = prev.Error
+		result.ErrorType = prev.ErrorType
+	} else if !result.IsOK() && result.ConsecutiveFailures < rc.FailureThreshold {
+		result.Status = prev.Status
+	}
+}
+
+// updateCircuit method opens the reporter's circuit once it has been
+// failing continuously for `Config.CircuitOpenAfter` and doubles the check
+// backoff on every subsequent failure. Caller must hold the lock.
+func (c *Collector) updateCircuit(rc *Config, result *CheckResult) {
+	if rc.CircuitOpenAfter <= 0 || result.Skipped {
+		return
+	}
+	if result.ConsecutiveFailures == 0 {
+		delete(c.circuits, rc.Name)
+		return
+	}
+	cb, found := c.circuits[rc.Name]
+	if !found {
+		cb = &circuit{failingSince: result.LastChecked}
+		c.circuits[rc.Name] = cb
+	}
+	if result.LastChecked.Sub(cb.failingSince) < rc.CircuitOpenAfter {
+		return
+	}
+
+	maxBackoff := rc.CircuitMaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = 5 * time.Minute
+	}
+	if cb.backoff == 0 {
+		cb.backoff = time.Duration(atomic.LoadInt64(&c.period))
+		if cb.backoff < time.Second {
+			cb.backoff = time.Second
+		}
+	}
+	cb.backoff *= 2
+	if cb.backoff > maxBackoff {
+		cb.backoff = maxBackoff
+	}
+	cb.nextCheck = result.LastChecked.Add(cb.backoff)
+	result.CircuitOpen = true
+}
+
+// observeDuration method records the check duration into reporter's
+// histogram. Caller must hold the lock.
+func (c *Collector) observeDuration(name string, d time.Duration) {
+	h, found := c.durations[name]
+	if !found {
+		h = newHistogram()
+		c.durations[name] = h
+	}
+	h.observe(d.Seconds())
+}
+
+// computeStatus method returns `Unhealthy` if any of the critical reporters
+// is unhealthy, `Degraded` if any of the warning reporters is unhealthy
+// otherwise `Healthy`. Reporters under maintenance or muted are not considered.
+func computeStatus(results map[string]CheckResult) AggregateStatus {
+	status := Healthy
+	for _, result := range results {
+		if result.IsOK() || result.Maintenance || result.Muted {
+			continue
+		}
+		switch result.severity() {
+		case SeverityCritical:
+			return Unhealthy
+		case SeverityWarning:
+			status = Degraded
+		}
+	}
+	return status
+}
+
+// severity method returns the reporter's severity, it falls back to the
+// deprecated `SoftFail`.
+func (rc *Config) severity() Severity {
+	if rc.Severity != "" {
+		return rc.Severity
+	}
+	if rc.SoftFail {
+		return SeverityWarning
+	}
+	return SeverityCritical
+}
+
+// IsHealthy method returns true if none of the hard fail reporters is
+// unhealthy as per latest check results.
+func (c *Collector) IsHealthy() bool {
+	return c.load().healthy
+}
+
+// Status method returns the aggregate health status as per latest check
+// results, it does not consider the draining and shutdown state.
+func (c *Collector) Status() AggregateStatus {
+	return c.load().status
+}
+
+// Results method returns a copy of the latest check results keyed by
+// reporter name.
+func (c *Collector) Results() map[string]CheckResult {
+	return c.load().without()
+}
+
+// SetDraining method enables or disables the drain mode. While draining,
+// `/healthcheck` and `/healthcheck/ready` respond with
+// `503 Service Unavailable` regardless of reporters health so that load
+// balancers stop routing traffic, `/ping` stays `200 OK`.
+func (c *Collector) SetDraining(draining bool) {
+	atomic.StoreInt32(&c.draining, boolToInt32(draining))
+}
+
+// IsDraining method returns true if the collector is in drain mode.
+func (c *Collector) IsDraining() bool {
+	return atomic.LoadInt32(&c.draining) == 1
+}
+
+// PrepareShutdown method marks the collector as shutting down and enables
+// the drain mode, then waits for given lame-duck period or until the
+// collector is stopped. Meanwhile `/healthcheck` and `/healthcheck/ready`
+// report unhealthy while `/ping` stays `200 OK`, so that load balancers
+// drain the connections. Call it before closing the listener of
+// `net/http` server, aah application calls it on `OnPreShutdown`.
+func (c *Collector) PrepareShutdown(lameDuck time.Duration) {
+	atomic.StoreInt32(&c.shuttingDown, 1)
+	c.SetDraining(true)
+	if lameDuck <= 0 {
+		return
+	}
+	if logger := c.log(); logger != nil {
+		logger.Infof("health: draining for %s before shutdown", lameDuck)
+	}
+	t := c.clock.NewTimer(lameDuck)
+	defer t.Stop()
+	select {
+	case <-t.C():
+	case <-c.ctx.Done():
+	}
+}
+
+func (c *Collector) isShuttingDown() bool {
+	return atomic.LoadInt32(&c.shuttingDown) == 1
+}
+
+// reporterTimeout method returns the check timeout of the reporter, timeout
+// set in code takes precedence over aah application config.
+func (c *Collector) reporterTimeout(rc *Config) time.Duration {
+	if rc.Timeout > 0 {
+		return rc.Timeout
 	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if timeout, found := c.timeouts[rc.Name]; found {
+		return timeout
+	}
+	return c.timeout
+}
+
+// slowThreshold method returns the slow check threshold of given reporter.
+func (c *Collector) slowThreshold(rc *Config) time.Duration {
+	if rc.SlowThreshold > 0 {
+		return rc.SlowThreshold
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.slow
+}
+
+// SetSlowThreshold method sets the default slow check threshold of the
+// reporters, refer to `Config.SlowThreshold`. It is disabled by default.
+func (c *Collector) SetSlowThreshold(threshold time.Duration) {
+	c.mu.Lock()
+	c.slow = threshold
+	c.mu.Unlock()
+}
+
+// SetMaxConcurrentChecks method limits the number of reporters checked
+// concurrently in a check run, 0 means no limit which is the default.
+func (c *Collector) SetMaxConcurrentChecks(n int) {
+	c.mu.Lock()
+	c.maxChecks = n
+	c.mu.Unlock()
+}
+
+func (c *Collector) maxConcurrentChecks() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.maxChecks
+}
+
+// SetLogger method sets the logger of the collector, it defaults to aah
+// application logger once registered.
+func (c *Collector) SetLogger(logger log.Loggerer) {
+	c.mu.Lock()
+	c.logger = logger
+	c.mu.Unlock()
+}
+
+func (c *Collector) log() log.Loggerer {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.logger
+}
+
+// checkWithRetries method performs health check on given reporter and
+// retries it on failure as per `Config.Retries` and `Config.RetryBackoff`.
+func (c *Collector) checkWithRetries(ctx context.Context, rc *Config) (map[string]interface{}, error) {
+	details, err := c.check(ctx, rc)
+	backoff := rc.RetryBackoff
+	for i := 0; err != nil && i < rc.Retries; i++ {
+		if backoff > 0 {
+			t := c.clock.NewTimer(backoff)
+			select {
+			case <-t.C():
+			case <-c.ctx.Done():
+				t.Stop()
+				return details, err
+			}
+			backoff *= 2
+		}
+		details, err = c.check(ctx, rc)
+	}
+	return details, err
+}
+
+// check method performs health check on given reporter, it prefers
+// `ReporterDetails` and `ReporterContext` over `Reporter` if implemented.
+// Panic raised by the reporter is recovered and returned as an error.
+func (c *Collector) check(parent context.Context, rc *Config) (details map[string]interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			details, err = nil, &panicError{value: r, stack: string(debug.Stack())}
+		}
+	}()
+
+	switch r := rc.Reporter.(type) {
+	case ReporterDetails:
+		ctx, cancel := c.checkContext(parent, rc)
+		defer cancel()
+		return r.CheckDetails(ctx)
+	case ReporterContext:
+		ctx, cancel := c.checkContext(parent, rc)
+		defer cancel()
+		return nil, r.CheckContext(ctx)
+	default:
+		return nil, rc.Reporter.Check()
+	}
+}
+
+// checkContext method returns the context of reporter's check derived from
+// given parent and bounded by the reporter timeout, refer to `Config.Timeout`.
+func (c *Collector) checkContext(parent context.Context, rc *Config) (context.Context, context.CancelFunc) {
+	if timeout := c.reporterTimeout(rc); timeout > 0 {
+		return context.WithTimeout(parent, timeout)
+	}
+	return context.WithCancel(parent)
+}
+
+// RegisterOptions struct holds the options to register health collector
+// routes into aah application.
+type RegisterOptions struct {
+	// Domain hostname to register the routes, default is root domain.
+	Domain string
+
+	// BasePath is the route prefix for health routes.
+	BasePath string
+
+	// Name binds the collector to the routes `/healthcheck/<name>`,
+	// `/healthcheck/<name>/live`, etc. so that multiple collectors can be
+	// registered, route `/ping` is registered only for unnamed collector.
+	// The collector can be looked up by name using `Lookup`.
+	Name string
+
+	// RouteName is the name prefix of the health routes, default is
+	// `healthcheck`, for e.g. `healthcheck_live`. Path is the full path of
+	// the health check route, it takes precedence over BasePath and Name,
+	// other health routes are composed under it, for e.g. `/internal/health`
+	// and `/internal/health/live`.
+	RouteName string
+	Path      string
+
+	// PingRouteName and PingPath override the route name `ping` and the path
+	// `<BasePath>/ping` of the ping route, for e.g. `/internal/alive`.
+	PingRouteName string
+	PingPath      string
+
+	// Format of the health check response, default is `FormatJSON`. Client
+	// may request another format by query parameter `format`, e.g.
+	// `format=xml`, or the `Accept` header, e.g. `application/yaml`.
+	Format string
+
+	// Encoder customizes the wire format of the health check response, it
+	// takes precedence over Format and Envelope. Default is the built-in
+	// encoder of Format, refer to `ResponseEncoder`.
+	Encoder ResponseEncoder
+
+	// ReleaseID and ServiceID are reported in `FormatHealthJSON` response
+	// and the envelope of `FormatJSON` response as version and service,
+	// default is aah application build version and name respectively.
+	ReleaseID string
+	ServiceID string
+
+	// Envelope wraps the `FormatJSON` response with the metadata of the
+	// application such as service, version, build time, instance, process
+	// uptime and timestamp, checks are reported under `checks`. Aggregation
+	// dashboards use it to know which instance a response came from.
+	// Metadata holds the additional fields reported in the envelope.
+	Envelope  bool
+	BuildTime string
+	Metadata  map[string]string
+
+	// DegradedStatusCode is the HTTP status code of health check response
+	// when only soft fail reporters are unhealthy, default is `200 OK`.
+	// Use `207 Multi-Status` to distinguish it from healthy.
+	DegradedStatusCode int
+
+	// UnhealthyStatusCode is the HTTP status code of health check response
+	// when unhealthy, default is `503 Service Unavailable`. For e.g. some
+	// load balancers need `500 Internal Server Error` and blue/green tooling
+	// `429 Too Many Requests`. It must be 4xx or 5xx.
+	UnhealthyStatusCode int
+
+	// StartingStatusCode is the HTTP status code of health check response
+	// while the collector is `Starting`, default is `200 OK`. Refer to
+	// `WithStartupGracePeriod`.
+	StartingStatusCode int
+
+	// LameDuckPeriod holds the application shutdown after the collector
+	// flips to draining, so that load balancers observe the unhealthy status
+	// and drain the connections before the listener closes. `/ping` stays
+	// `200 OK` meanwhile. Default is 0, no wait. Refer to
+	// `Collector.PrepareShutdown`.
+	LameDuckPeriod time.Duration
+
+	// GzipMinSize is the minimum size in bytes of health check response to
+	// be gzip compressed when the client accepts it, default is 1024. Use
+	// negative value to disable it. Query parameter `pretty=true` indents the
+	// JSON response for humans.
+	GzipMinSize int
+
+	// RetryAfter adds `Retry-After` header to the health check responses of
+	// 4xx or 5xx status code, its value is the check interval in seconds,
+	// that is when the next check result is available.
+	RetryAfter bool
+
+	// ScoreThreshold decides the status code of health check response by
+	// the weighted health score instead of the aggregate status, if it is
+	// greater than 0. Response is `200 OK` when the score is at or above the
+	// threshold, otherwise UnhealthyStatusCode. Useful for the load balancers
+	// which support gradient weighting. Refer to `Collector.Score`.
+	ScoreThreshold float64
+
+	// ForceCheck allows `GET /healthcheck?force=true` to check all the
+	// reporters before responding, it waits up to `ForceCheckTimeout`
+	// (default is 10 seconds) and responds with last results on timeout.
+	ForceCheck        bool
+	ForceCheckTimeout time.Duration
+
+	// ForceCheckToken protects the force check, if not empty request must
+	// carry the header `Authorization: Bearer <token>`.
+	ForceCheckToken string
+
+	// Exposure level of the health check response, default is `ExposureVerbose`.
+	Exposure string
+
+	// AllowVerboseQuery allows `GET /healthcheck?verbose=true` to upgrade
+	// `ExposureSummary` to `ExposureVerbose` response, combine it with auth
+	// options to expose the details only for authorized requests.
+	AllowVerboseQuery bool
+
+	// DisableLive, DisableReady, DisableMetrics, DisableHistory,
+	// DisableBadge and DisablePing skip the registration of respective
+	// routes.
+	DisableLive    bool
+	DisableReady   bool
+	DisableMetrics bool
+	DisableHistory bool
+	DisableBadge   bool
+	DisablePing    bool
+
+	// EnableStream registers the route `/healthcheck/stream`, a live feed of
+	// the reporters' status as Server-Sent Events for dashboards. Current
+	// status of every reporter is sent first, then an event whenever a
+	// reporter's status changes and a heartbeat every StreamHeartbeat
+	// (default is 15 seconds). Query parameters `reporter` and `tag` narrow
+	// the reporters. Only the aggregate status is sent for `ExposureSummary`,
+	// once subscribed and then whenever it changes. It is protected same as
+	// the health check route.
+	EnableStream    bool
+	StreamHeartbeat time.Duration
+
+	// EnableWebSocket registers the route `/healthcheck/ws`, the live feed of
+	// `EnableStream` over WebSocket for the environments where proxies
+	// block Server-Sent Events. Every event is sent as a JSON text message,
+	// query parameters and heartbeat are same as the stream. Cross-origin
+	// handshake is allowed only for the origins of CORSAllowedOrigins.
+	EnableWebSocket bool
+
+	// EnableUI registers the route `/healthcheck/ui`, a self-contained HTML
+	// status page of the reporters for humans, it refreshes itself every
+	// check interval. It is protected same as the health check route.
+	EnableUI bool
+
+	// AuthToken, BasicAuthUsername with BasicAuthPassword and AuthValidator
+	// protect the routes `/healthcheck`, `/healthcheck/ready` and
+	// `/healthcheck/metrics`, request must carry the header
+	// `Authorization: Bearer <token>`, basic auth credentials or pass the
+	// validator, either one is sufficient. Routes `/healthcheck/live` and
+	// `/ping` stay public.
+	AuthToken         string
+	BasicAuthUsername string
+	BasicAuthPassword string
+	AuthValidator     func(r *http.Request) bool
+
+	// DrainAuth is the auth scheme name of the route `POST /healthcheck/drain`.
+	// The route is registered only if it is not empty, use `anonymous` to
+	// register it without authentication.
+	DrainAuth string
+
+	// MuteAuth is the auth scheme name of the route `POST /healthcheck/mute`,
+	// it mutes the reporter given by query parameter `reporter`, along with
+	// `enable=false` it unmutes. The route is registered only if it is not
+	// empty. Refer to `Collector.Mute`.
+	MuteAuth string
+
+	// ReportAuth is the auth scheme name of the route
+	// `POST /healthcheck/report`, it records the status of the passive
+	// reporter given by query parameter `reporter`, query parameter `error`
+	// reports the failure with its message. The route is registered only if
+	// it is not empty. Refer to `Collector.ReportStatus`.
+	ReportAuth string
+
+	// ReportersAuth is the auth scheme name of the route
+	// `GET /healthcheck/reporters`, it lists the registered reporters along
+	// with their current status. The route is registered only if it is not
+	// empty. Refer to `Collector.Reporters`.
+	ReportersAuth string
+
+	// TagRoutes registers the route `/healthcheck/tags/<tag>` for each given
+	// tag, it responds with the health check of reporters tagged with it.
+	// Refer to `Config.Tags`.
+	TagRoutes []string
+
+	// AllowedCIDRs restricts the health routes to the clients from given
+	// CIDR ranges or IP addresses, e.g. `10.0.0.0/8`. Route `/ping` is
+	// restricted only if `RestrictPing` is true. Denied requests are responded
+	// with `DeniedStatusCode`, default is `403 Forbidden`, use `404 Not Found`
+	// to hide the routes. Client address is taken from the connection, not
+	// from the `X-Forwarded-For` header.
+	AllowedCIDRs     []string
+	RestrictPing     bool
+	DeniedStatusCode int
+
+	// CORSAllowedOrigins enables Cross-Origin Resource Sharing on the health
+	// routes for given origins, so that browser-based status dashboards
+	// hosted on another origin can fetch them, `*` allows any origin.
+	// CORSAllowedMethods of the preflight response default to `GET, HEAD`,
+	// CORSAllowedHeaders lists the request headers allowed, for e.g.
+	// `Authorization` along with auth options. CORSMaxAge is the duration
+	// preflight response is cached by the browser.
+	CORSAllowedOrigins []string
+	CORSAllowedMethods []string
+	CORSAllowedHeaders []string
+	CORSMaxAge         time.Duration
+
+	allowedNets []*net.IPNet
+	pretty      bool
+}
+
+// normalize method validates the options and applies the defaults.
+func (opts *RegisterOptions) normalize() error {
+	if opts.RouteName == "" {
+		opts.RouteName = "healthcheck"
+	}
+	if opts.PingRouteName == "" {
+		opts.PingRouteName = "ping"
+	}
+	if opts.Path == "" {
+		opts.Path = composeRoutePath(path.Join(opts.BasePath, "healthcheck", opts.Name), "")
+	}
+	if opts.PingPath == "" {
+		opts.PingPath = composeRoutePath(opts.BasePath, "ping")
+	}
+	for _, p := range []string{opts.Path, opts.PingPath} {
+		if !strings.HasPrefix(p, "/") {
+			return fmt.Errorf("health: route path '%s' must begin with '/'", p)
+		}
+	}
+	if opts.RouteName == opts.PingRouteName || path.Clean(opts.Path) == path.Clean(opts.PingPath) {
+		return errors.New("health: health check and ping routes must have different names and paths")
+	}
+	switch opts.Format {
+	case "":
+		opts.Format = FormatJSON
+	case FormatJSON, FormatHealthJSON, FormatXML, FormatYAML:
+	default:
+		return fmt.Errorf("health: unsupported response format '%s'", opts.Format)
+	}
+	if opts.Encoder == nil {
+		opts.Encoder = encoderOf(*opts)
+	}
+	switch opts.Exposure {
+	case "":
+		opts.Exposure = ExposureVerbose
+	case ExposureVerbose, ExposureSummary:
+	default:
+		return fmt.Errorf("health: unsupported exposure level '%s'", opts.Exposure)
+	}
+	if opts.ForceCheckTimeout <= 0 {
+		opts.ForceCheckTimeout = 10 * time.Second
+	}
+	if opts.DegradedStatusCode == 0 {
+		opts.DegradedStatusCode = http.StatusOK
+	}
+	if opts.UnhealthyStatusCode == 0 {
+		opts.UnhealthyStatusCode = http.StatusServiceUnavailable
+	}
+	if opts.StartingStatusCode == 0 {
+		opts.StartingStatusCode = http.StatusOK
+	}
+	if opts.StreamHeartbeat <= 0 {
+		opts.StreamHeartbeat = 15 * time.Second
+	}
+	if opts.GzipMinSize == 0 {
+		opts.GzipMinSize = 1024
+	}
+	if opts.ScoreThreshold < 0 || opts.ScoreThreshold > 100 {
+		return fmt.Errorf("health: score threshold must be between 0 and 100, got %v", opts.ScoreThreshold)
+	}
+	if opts.UnhealthyStatusCode < 400 || opts.UnhealthyStatusCode > 599 {
+		return fmt.Errorf("health: unhealthy status code must be 4xx or 5xx, got %d", opts.UnhealthyStatusCode)
+	}
+	if opts.StartingStatusCode < 200 || opts.StartingStatusCode > 599 {
+		return fmt.Errorf("health: starting status code must be 2xx to 5xx, got %d", opts.StartingStatusCode)
+	}
+	if opts.DeniedStatusCode == 0 {
+		opts.DeniedStatusCode = http.StatusForbidden
+	}
+	if len(opts.CORSAllowedMethods) == 0 {
+		opts.CORSAllowedMethods = []string{http.MethodGet, http.MethodHead}
+	}
+	for _, tag := range opts.TagRoutes {
+		if _, reserved := reservedNames[tag]; reserved || len(tag) == 0 || strings.Contains(tag, "/") {
+			return fmt.Errorf("health: invalid tag route '%s'", tag)
+		}
+	}
+	opts.allowedNets = nil
+	for _, cidr := range opts.AllowedCIDRs {
+		ipNet, err := parseCIDR(cidr)
+		if err != nil {
+			return err
+		}
+		opts.allowedNets = append(opts.allowedNets, ipNet)
+	}
+	return nil
+}
+
+// panicError struct holds the recovered panic value of a reporter check
+// along with its stack trace.
+type panicError struct {
+	value interface{}
+	stack string
+}
+
+func (e *panicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.value)
 }
 
 // Register method registers the health collector into aah application with
-// two routes `/healthcheck` and `/ping`.
+// routes `/healthcheck`, `/healthcheck/live`, `/healthcheck/ready`,
+// `/healthcheck/metrics`, `/healthcheck/history` and `/ping`.
 //
 // Provides optional base path or route prefix for the above routes.
 func (c *Collector) Register(app *aah.Application, basePath ...string) error {
@@ -140,8 +1485,15 @@ func (c *Collector) Register(app *aah.Application, basePath ...string) error {
 }
 
 // RegisterForDomain method registers the health collector into
-// aah application with two routes `/healthcheck` and `/ping` for
-// given domain hostname.
+// aah application with routes `/healthcheck`, `/healthcheck/live`,
+// `/healthcheck/ready`, `/healthcheck/metrics` and `/ping` for given
+// domain hostname.
+//
+// Routes are bound to the collector within the domain, so that each domain
+// of multi-domain application can expose its own reporters, for e.g.:
+//
+//	_ = tenantA.RegisterForDomain(app, "tenant-a.example.com")
+//	_ = tenantB.RegisterForDomain(app, "tenant-b.example.com")
 //
 // Provides optional base path or route prefix for the above routes.
 func (c *Collector) RegisterForDomain(app *aah.Application, domainName string, basePath ...string) error {
@@ -149,43 +1501,223 @@ func (c *Collector) RegisterForDomain(app *aah.Application, domainName string, b
 	if len(basePath) > 0 {
 		routePrefix = basePath[0]
 	}
-	return registerInApp(app, domainName, routePrefix)
+	return c.RegisterWithOptions(app, RegisterOptions{Domain: domainName, BasePath: routePrefix})
+}
+
+// RegisterWithOptions method registers the health collector into aah
+// application as per given options. Refer to `RegisterOptions`.
+//
+// Options not set in the code are read from the `health { ... }` section
+// of aah application config, refer to `Collector.applyAppConfig`.
+func (c *Collector) RegisterWithOptions(app *aah.Application, opts RegisterOptions) error {
+	if opts.Domain == "" {
+		opts.Domain = app.Router().RootDomain().Key
+	}
+	if c.log() == nil {
+		c.SetLogger(app.Log())
+	}
+	if err := c.applyAppConfig(app.Config(), &opts); err != nil {
+		return err
+	}
+	if err := opts.normalize(); err != nil {
+		return err
+	}
+	if bi := app.BuildInfo(); bi != nil {
+		if opts.ReleaseID == "" {
+			opts.ReleaseID = bi.Version
+		}
+		if opts.BuildTime == "" {
+			opts.BuildTime = bi.Timestamp
+		}
+	}
+	if opts.ServiceID == "" {
+		opts.ServiceID = app.Name()
+	}
+	if err := c.registerInApp(app, opts); err != nil {
+		return err
+	}
+
+	// stop periodic checks along with the application
+	app.OnPostShutdown(func(_ *aah.Event) {
+		c.Stop()
+	})
+
+	// health check flips to draining once the application begins to
+	// shutdown, liveness probe reports failure too
+	app.OnPreShutdown(func(_ *aah.Event) {
+		c.PrepareShutdown(opts.LameDuckPeriod)
+	})
+	return nil
 }
 
-func registerInApp(app *aah.Application, domainName, basePath string) error {
+func (c *Collector) registerInApp(app *aah.Application, opts RegisterOptions) error {
+	if _, reserved := reservedNames[opts.Name]; reserved {
+		return fmt.Errorf("health: collector name '%s' is reserved", opts.Name)
+	}
+	if !registry.isAvailable(opts.Domain, opts.Name, c) {
+		return fmt.Errorf("health: collector name '%s' already registered for domain '%s'", opts.Name, opts.Domain)
+	}
+
 	app.AddController((*healthController)(nil), []*ainsp.Method{
 		{Name: "Healthcheck"},
+		{Name: "Live"},
+		{Name: "Ready"},
+		{Name: "Metrics"},
+		{Name: "History"},
+		{Name: "UI"},
+		{Name: "Stream"},
+		{Name: "WebSocket"},
+		{Name: "Badge"},
+		{Name: "Drain"},
+		{Name: "Mute"},
+		{Name: "Report"},
+		{Name: "Reporters"},
+		{Name: "Tag"},
 		{Name: "Ping"},
 	})
-	hcRoute := &router.Route{
-		Name:   "healthcheck",
-		Path:   composeRoutePath(basePath, "healthcheck"),
-		Method: http.MethodGet,
-		Target: "aahframe.work/ec/health/healthController",
-		Action: "Healthcheck",
-		Auth:   "anonymous",
+	basePath := opts.Path
+	routeName := opts.RouteName
+	if len(opts.Name) > 0 {
+		routeName += "_" + opts.Name
+	}
+	routes := []*router.Route{
+		createRoute(routeName, composeRoutePath(basePath, ""), "Healthcheck"),
+	}
+	if !opts.DisableLive {
+		routes = append(routes, createRoute(routeName+"_live", composeRoutePath(basePath, "live"), "Live"))
+	}
+	if !opts.DisableReady {
+		routes = append(routes, createRoute(routeName+"_ready", composeRoutePath(basePath, "ready"), "Ready"))
+	}
+	if !opts.DisableMetrics {
+		routes = append(routes, createRoute(routeName+"_metrics", composeRoutePath(basePath, "metrics"), "Metrics"))
+	}
+	if !opts.DisableHistory {
+		routes = append(routes, createRoute(routeName+"_history", composeRoutePath(basePath, "history"), "History"))
+	}
+	if !opts.DisableBadge {
+		routes = append(routes, createRoute(routeName+"_badge", composeRoutePath(basePath, "badge.svg"), "Badge"))
+	}
+	if opts.EnableUI {
+		routes = append(routes, createRoute(routeName+"_ui", composeRoutePath(basePath, "ui"), "UI"))
+	}
+	if opts.EnableStream {
+		routes = append(routes, createRoute(routeName+"_stream", composeRoutePath(basePath, "stream"), "Stream"))
+	}
+	if len(opts.DrainAuth) > 0 {
+		drainRoute := createRoute(routeName+"_drain", composeRoutePath(basePath, "drain"), "Drain")
+		drainRoute.Method = http.MethodPost
+		drainRoute.Auth = opts.DrainAuth
+		routes = append(routes, drainRoute)
 	}
-	if err := app.Router().Lookup(domainName).AddRoute(hcRoute); err != nil {
-		return fmt.Errorf("health: cannot add route '%v': %v", hcRoute.Name, err.Error())
+	if len(opts.MuteAuth) > 0 {
+		muteRoute := createRoute(routeName+"_mute", composeRoutePath(basePath, "mute"), "Mute")
+		muteRoute.Method = http.MethodPost
+		muteRoute.Auth = opts.MuteAuth
+		routes = append(routes, muteRoute)
 	}
-	pingRoute := &router.Route{
-		Name:   "ping",
-		Path:   composeRoutePath(basePath, "ping"),
+	if len(opts.ReportAuth) > 0 {
+		reportRoute := createRoute(routeName+"_report", composeRoutePath(basePath, "report"), "Report")
+		reportRoute.Method = http.MethodPost
+		reportRoute.Auth = opts.ReportAuth
+		routes = append(routes, reportRoute)
+	}
+	if len(opts.ReportersAuth) > 0 {
+		reportersRoute := createRoute(routeName+"_reporters", composeRoutePath(basePath, "reporters"), "Reporters")
+		reportersRoute.Auth = opts.ReportersAuth
+		routes = append(routes, reportersRoute)
+	}
+	for _, tag := range opts.TagRoutes {
+		routes = append(routes, createRoute(routeName+"_tags_"+tag,
+			composeRoutePath(basePath, path.Join(tagRoutePrefix, tag)), "Tag"))
+	}
+	if len(opts.Name) == 0 && !opts.DisablePing {
+		routes = append(routes, createRoute(opts.PingRouteName, composeRoutePath(opts.PingPath, ""), "Ping"))
+	}
+
+	routes = append(routes, headRoutes(routes)...)
+	if isCORSEnabled(opts) {
+		routes = append(routes, optionsRoutes(routes)...)
+	}
+	if opts.EnableWebSocket {
+		routes = append(routes, createRoute(routeName+"_ws", composeRoutePath(basePath, "ws"), "WebSocket"))
+	}
+
+	domain := app.Router().Lookup(opts.Domain)
+	if domain == nil {
+		return fmt.Errorf("health: domain '%s' does not exist", opts.Domain)
+	}
+	paths := make([]string, 0, len(routes))
+	for _, r := range routes {
+		if err := domain.AddRoute(r); err != nil {
+			return fmt.Errorf("health: cannot add route '%v': %v", r.Name, err.Error())
+		}
+		paths = append(paths, r.Path)
+	}
+	registry.add(c, opts, paths)
+	return nil
+}
+
+func createRoute(name, routePath, action string) *router.Route {
+	return &router.Route{
+		Name:   name,
+		Path:   routePath,
 		Method: http.MethodGet,
 		Target: "aahframe.work/ec/health/healthController",
-		Action: "Ping",
+		Action: action,
 		Auth:   "anonymous",
 	}
-	if err := app.Router().Lookup(domainName).AddRoute(pingRoute); err != nil {
-		return fmt.Errorf("health: cannot add route '%v': %v", hcRoute.Name, err.Error())
+}
+
+// headRoutes returns the `HEAD` method routes of given `GET` routes, since
+// load balancers and uptime checkers probe with `HEAD` too. They respond
+// with the status and headers only.
+func headRoutes(routes []*router.Route) []*router.Route {
+	var heads []*router.Route
+	for _, r := range routes {
+		if r.Method == http.MethodGet {
+			head := *r
+			head.Name += "_head"
+			head.Method = http.MethodHead
+			heads = append(heads, &head)
+		}
 	}
-	return nil
+	return heads
+}
+
+// requestDomain returns the key of the domain resolved for the request,
+// same as `RegisterOptions.Domain`.
+func requestDomain(ctx *aah.Context) string {
+	if domain := ctx.Domain(); domain != nil {
+		return domain.Key
+	}
+	return ""
 }
 
 func composeRoutePath(basePath, routePath string) string {
 	return path.Join("/", basePath, routePath)
 }
 
+func boolToInt32(v bool) int32 {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+// copyMetadata returns the copy of given reporter metadata, so that the
+// caller's map is not shared with the check results.
+func copyMetadata(metadata map[string]string) map[string]string {
+	if metadata == nil {
+		return nil
+	}
+	cp := make(map[string]string, len(metadata))
+	for k, v := range metadata {
+		cp[k] = v
+	}
+	return cp
+}
+
 //‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
 // HealthController struct and its methods
 //______________________________________________________________________________
@@ -194,18 +1726,289 @@ func composeRoutePath(basePath, routePath string) string {
 // for the aah application.
 type healthController struct {
 	*aah.Context
+	collector *Collector
+	opts      RegisterOptions
 }
 
-// TODO: this action should take input parameter *Collector, to support multiple collectors
-// Healthcheck action responds with reporter's health status.
+// Before interceptor resolves the collector bound to the requested route
+// of the request's domain.
+func (c *healthController) Before() {
+	b := registry.lookupPath(requestDomain(c.Context), c.Req.Path)
+	if b == nil {
+		c.Reply().NotFound().Text("404 Not Found\n")
+		c.Abort()
+		return
+	}
+	c.collector, c.opts = b.collector, b.opts
+
+	isPing := c.Req.Path == composeRoutePath(c.opts.PingPath, "")
+	if (!isPing || c.opts.RestrictPing) && !isClientAllowed(c.opts, c.Req.Unwrap()) {
+		c.Reply().Status(c.opts.DeniedStatusCode).Text("%d %s\n",
+			c.opts.DeniedStatusCode, http.StatusText(c.opts.DeniedStatusCode))
+		c.Abort()
+		return
+	}
+
+	// headers are set on the response, since stream actions write to it
+	// directly
+	writeCORSHeaders(c.opts, c.Req.Unwrap(), c.Res.Header())
+	if c.Req.Method == http.MethodOptions {
+		c.Reply().NoContent()
+		c.Abort()
+	}
+}
+
+// Healthcheck action responds with reporter's health status. Refer to
+// `RegisterOptions.ForceCheck` for query parameter `force=true` and
+// `Config.Tags` for query parameter `tag`.
 func (c *healthController) Healthcheck() {
-	defaultCollector.mu.RLock()
-	defer defaultCollector.mu.RUnlock()
-	if defaultCollector.globalHealth {
-		c.Reply().Ok().JSON(defaultCollector.reportersData)
+	if !c.authorize() {
+		return
+	}
+	if c.Req.QueryValue("force") == "true" {
+		if !c.opts.ForceCheck || !isForceCheckAuthorized(c.opts, c.Req.Header) {
+			c.Reply().Forbidden().Text("force check is not allowed\n")
+			return
+		}
+		if !c.collector.CheckNow(c.opts.ForceCheckTimeout) {
+			c.Log().Warnf("health: force check did not complete within %s", c.opts.ForceCheckTimeout)
+		}
+	}
+	c.replyHealth(c.collector.currentStatus(false, parseTags(c.Req.QueryValue("tag"))))
+}
+
+// Live action responds with status `200 OK` while the application is running,
+// and `503 Service Unavailable` once the application begins to shutdown.
+// Reporters health is not considered, use it as a liveness probe.
+func (c *healthController) Live() {
+	if c.collector.isShuttingDown() {
+		c.Reply().ServiceUnavailable().Text("shutting down\n")
+	} else {
+		c.Reply().Ok().Text("alive\n")
+	}
+}
+
+// Ready action responds with reporter's health status, it reports
+// `503 Service Unavailable` when any of the hard fail reporters is unhealthy
+// or the application is shutting down or draining. Use it as a readiness probe.
+func (c *healthController) Ready() {
+	if !c.authorize() {
+		return
+	}
+	c.replyHealth(c.collector.currentStatus(true, parseTags(c.Req.QueryValue("tag"))))
+}
+
+// Tag action responds with the health status of reporters tagged with the
+// route's tag. Refer to `RegisterOptions.TagRoutes`.
+func (c *healthController) Tag() {
+	if !c.authorize() {
+		return
+	}
+	c.replyHealth(c.collector.currentStatus(false, []string{path.Base(c.Req.Path)}))
+}
+
+// Metrics action responds with health status in Prometheus text exposition format.
+func (c *healthController) Metrics() {
+	if !c.authorize() {
+		return
+	}
+	buf := new(bytes.Buffer)
+	if err := c.collector.WriteMetrics(buf); err != nil {
+		c.Log().Errorf("health: unable to write metrics: %v", err)
+		c.Reply().InternalServerError().Text("unable to write metrics\n")
+		return
+	}
+	c.Reply().Ok().ContentType(MetricsContentType).Binary(buf.Bytes())
+}
+
+// History action responds with the last check results of the reporters,
+// errors are omitted for `ExposureSummary`.
+func (c *healthController) History() {
+	if !c.authorize() {
+		return
+	}
+	opts := exposureOptions(c.opts, c.Req.QueryValue("verbose"))
+	c.Reply().Ok().JSON(historyResponse(c.collector, opts))
+}
+
+// UI action responds with the HTML status page of the reporters, refer to
+// `RegisterOptions.EnableUI`.
+func (c *healthController) UI() {
+	if !c.authorize() {
+		return
+	}
+	state, status := c.collector.currentStatus(false, parseTags(c.Req.QueryValue("tag")))
+	body, err := c.collector.statusPage(state, status, exposureOptions(c.opts, c.Req.QueryValue("verbose")))
+	if err != nil {
+		c.Log().Errorf("health: unable to render status page: %v", err)
+		c.Reply().InternalServerError().Text("unable to render status page\n")
+		return
+	}
+	c.Reply().Ok().
+		Header(HeaderHealthStatus, string(status)).
+		ContentType(htmlContentType).
+		Binary(body)
+}
+
+// Stream action streams the reporters' status as Server-Sent Events, refer
+// to `RegisterOptions.EnableStream`.
+func (c *healthController) Stream() {
+	if !c.authorize() {
+		return
+	}
+	c.Reply().Done()
+	c.collector.serveStream(c.Res, c.Req.Unwrap(), c.opts)
+}
+
+// WebSocket action streams the reporters' status over WebSocket, refer to
+// `RegisterOptions.EnableWebSocket`.
+func (c *healthController) WebSocket() {
+	if !c.authorize() {
+		return
+	}
+	c.Reply().Done()
+	c.collector.serveWebSocket(c.Res, c.Req.Unwrap(), c.opts)
+}
+
+// Badge action responds with the SVG status badge of the aggregate health,
+// embeddable in wikis and dashboards. It is not protected by auth options
+// since images cannot carry credentials, it reveals only the aggregate
+// status. Query parameter `label` sets the badge label and `tag` narrows the
+// reporters.
+func (c *healthController) Badge() {
+	_, status := c.collector.currentStatus(false, parseTags(c.Req.QueryValue("tag")))
+	c.Reply().Ok().
+		Header(HeaderHealthStatus, string(status)).
+		Header("Cache-Control", "no-cache, no-store, must-revalidate").
+		ContentType(svgContentType).
+		Binary(badgeSVG(c.Req.QueryValue("label"), status))
+}
+
+// Drain action enables the drain mode, query parameter `enable=false`
+// disables it. Refer to `Collector.SetDraining`.
+func (c *healthController) Drain() {
+	draining := c.Req.QueryValue("enable") != "false"
+	c.collector.SetDraining(draining)
+	if draining {
+		c.Reply().Ok().Text("draining\n")
 	} else {
-		c.Reply().ServiceUnavailable().JSON(defaultCollector.reportersData)
+		c.Reply().Ok().Text("not draining\n")
+	}
+}
+
+// Mute action mutes the reporter given by query parameter `reporter`,
+// query parameter `enable=false` unmutes it. Refer to `Collector.Mute`.
+func (c *healthController) Mute() {
+	name := c.Req.QueryValue("reporter")
+	muted := c.Req.QueryValue("enable") != "false"
+	var err error
+	if muted {
+		err = c.collector.Mute(name)
+	} else {
+		err = c.collector.Unmute(name)
+	}
+	if err != nil {
+		c.Reply().NotFound().Text("%s\n", err)
+		return
+	}
+	if muted {
+		c.Reply().Ok().Text("muted\n")
+	} else {
+		c.Reply().Ok().Text("unmuted\n")
+	}
+}
+
+// Report action records the status of the passive reporter given by query
+// parameter `reporter`, query parameter `error` reports the failure with its
+// message. Refer to `Collector.ReportStatus`.
+func (c *healthController) Report() {
+	var status error
+	if msg := c.Req.QueryValue("error"); len(msg) > 0 {
+		status = errors.New(msg)
+	}
+	if err := c.collector.ReportStatus(c.Req.QueryValue("reporter"), status); err != nil {
+		c.Reply().NotFound().Text("%s\n", err)
+		return
+	}
+	c.Reply().Ok().Text("reported\n")
+}
+
+// Reporters action responds with the registered reporters along with their
+// current status. Refer to `RegisterOptions.ReportersAuth`.
+func (c *healthController) Reporters() {
+	c.Reply().Ok().JSON(c.collector.Reporters())
+}
+
+// authorize method replies `401 Unauthorized` and returns false if the
+// request is not authorized as per `RegisterOptions` auth settings.
+func (c *healthController) authorize() bool {
+	if isRequestAuthorized(c.opts, c.Req.Unwrap()) {
+		return true
+	}
+	c.Reply().Unauthorized().
+		Header("WWW-Authenticate", authChallenge(c.opts)).
+		Text("401 Unauthorized\n")
+	return false
+}
+
+// replyHealth method writes the health check response in the format
+// configured for the route.
+func (c *healthController) replyHealth(state *snapshot, status AggregateStatus) {
+	opts, err := responseOptions(c.opts, c.Req.Unwrap())
+	if err != nil {
+		c.Reply().BadRequest().Text("%s\n", err)
+		return
+	}
+	c.Reply().HeaderAppend("Vary", "Accept")
+	if etag, lastModified := c.collector.healthValidators(state, status, opts); len(etag) > 0 {
+		c.Reply().Header("ETag", etag).
+			Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+		if isNotModified(c.Req.Header, etag, lastModified) {
+			c.Reply().Status(http.StatusNotModified).
+				Header(HeaderHealthStatus, string(status))
+			return
+		}
+	}
+	if c.Req.Method == http.MethodHead {
+		// status only, response is not marshaled
+		code := healthStatusCode(state, status, opts)
+		reply := c.Reply().Status(code).
+			Header(HeaderHealthStatus, string(status)).
+			Header(HeaderHealthScore, formatFloat(state.score)).
+			ContentType(healthContentType(opts))
+		if c.collector.IsPaused() {
+			reply.Header(HeaderHealthPaused, "true")
+		}
+		if retryAfter := c.collector.retryAfter(opts, code); len(retryAfter) > 0 {
+			reply.Header("Retry-After", retryAfter)
+		}
+		return
+	}
+	code, contentType, body, err := c.collector.healthResponse(state, status, opts)
+	if err != nil {
+		c.Log().Errorf("health: unable to marshal response: %v", err)
+		c.Reply().InternalServerError().Text("unable to marshal response\n")
+		return
+	}
+	reply := c.Reply().Status(code).
+		Header(HeaderHealthStatus, string(status)).
+		Header(HeaderHealthScore, formatFloat(state.score)).
+		ContentType(contentType)
+	if c.collector.IsPaused() {
+		reply.Header(HeaderHealthPaused, "true")
+	}
+	if retryAfter := c.collector.retryAfter(opts, code); len(retryAfter) > 0 {
+		reply.Header("Retry-After", retryAfter)
+	}
+	if opts.GzipMinSize > 0 {
+		reply.HeaderAppend("Vary", "Accept-Encoding")
+	}
+	if gz, ok := gzipBody(body, c.Req.Header, opts); ok {
+		// compressed already, so aah must not gzip it again
+		reply.DisableGzip().Header("Content-Encoding", "gzip")
+		body = gz
 	}
+	reply.Binary(body)
 }
 
 // Ping action responds with static text response as `pong!` with status `200 OK`.
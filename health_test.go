@@ -6,121 +6,611 @@
 package health
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"aahframe.work/log"
+	"aahframe.work/router"
 	"github.com/stretchr/testify/assert"
 )
 
-type tcp struct {
-	address string
+type ctxReporter struct {
+	delay time.Duration
 }
 
-func (s *tcp) Check() error {
-	conn, err := net.DialTimeout("tcp", s.address, 3*time.Second)
-	if err == nil {
-		conn.Close()
-	}
-	return err
+func (r *ctxReporter) Check() error {
+	return errors.New("check should not be called")
 }
 
-func TestHealthSimple(t *testing.T) {
-	collector := NewCollector(-1)
-	googleDNS := &tcp{
-		address: "google.com:443",
+func (r *ctxReporter) CheckContext(ctx context.Context) error {
+	select {
+	case <-time.After(r.delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
-	rep1 := &Config{
-		Name:     "GoogleDNS",
-		Reporter: googleDNS,
-		SoftFail: true,
+}
+
+type flakyReporter struct {
+	failures int32
+	calls    int32
+}
+
+func (r *flakyReporter) Check() error {
+	if atomic.AddInt32(&r.calls, 1) <= r.failures {
+		return errors.New("connection reset")
 	}
+	return nil
+}
 
-	// Assert that rep1 is not already added with same name
-	err := collector.AddReporter(rep1)
-	assert.Nil(t, err)
+type toggleReporter struct {
+	mu  sync.Mutex
+	err error
+}
 
-	time.Sleep(20 * time.Second) // let's wait for time ticker to run
+func (r *toggleReporter) Check() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+func (r *toggleReporter) set(err error) {
+	r.mu.Lock()
+	r.err = err
+	r.mu.Unlock()
+}
 
-	collector.mu.RLock()
-	assert.True(t, collector.globalHealth)
+type panicReporter struct{}
 
-	// assert global JSON msg status
-	healthMsg, _ := json.Marshal(collector.reportersData)
-	collector.mu.RUnlock()
-	assert.JSONEq(t, `{"GoogleDNS":"OK: Healthy"}`, string(healthMsg))
+func (panicReporter) Check() error {
+	panic("boom")
 }
 
 func TestHealthForceCheck(t *testing.T) {
 	// Do not use NewCollector here, since datarace would occur
 	// between maunal vs ticker run
-	collector := &Collector{
-		reporters:     make(map[string]*Config),
-		reportersData: make(map[string]string),
-		globalHealth:  true,
-	}
+	collector := newCollector()
 
-	googleDNS := &tcp{
-		address: "google.com:443",
-	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer ln.Close()
+	local := NewTCPReporter(ln.Addr().String(), 3*time.Second)
 	rep1 := &Config{
-		Name:     "GoogleDNS",
-		Reporter: googleDNS,
+		Name:     "Local",
+		Reporter: local,
 		SoftFail: true,
 	}
 
 	// Assert that rep1 is not already added with same name
-	err := collector.AddReporter(rep1)
+	err = collector.AddReporter(rep1)
 	assert.Nil(t, err)
 	// immediately run checks
 	collector.runChecks()
 
 	// assert globalHealth
-	assert.True(t, collector.globalHealth)
+	assert.True(t, collector.load().healthy)
 
-	// assert global JSON msg status
-	healthMsg, _ := json.Marshal(collector.reportersData)
-	assert.JSONEq(t, `{"GoogleDNS":"OK: Healthy"}`, string(healthMsg))
+	// assert reporter status
+	result := collector.load().results["Local"]
+	assert.Equal(t, StatusOK, result.Status)
+	assert.True(t, result.SoftFail)
+	assert.False(t, result.LastChecked.IsZero())
 
 	// Assert that adding rep2 with same name as rep1 will throw err
 	rep2 := &Config{
-		Name:     "GoogleDNS",
-		Reporter: googleDNS,
+		Name:     "Local",
+		Reporter: local,
 		SoftFail: true,
 	}
 	err = collector.AddReporter(rep2)
 	assert.NotNil(t, err)
 
-	// Assert rep3 check fails
-	googleFakePort := &tcp{
-		address: "google.com:12345",
-	}
+	// Assert rep3 check fails, the port is not listening anymore
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	_ = closed.Close()
 	rep3 := &Config{
-		Name:     "GoogleFakePort",
-		Reporter: googleFakePort,
+		Name:     "Closed",
+		Reporter: NewTCPReporter(closed.Addr().String(), 3*time.Second),
 		SoftFail: false,
 	}
 	err = collector.AddReporter(rep3)
 	assert.Nil(t, err)
 	collector.runChecks()
-	assert.False(t, collector.globalHealth)
-	healthMsg, _ = json.Marshal(collector.reportersData)
-	assert.Contains(t, string(healthMsg), `"GoogleFakePort":"KO: dial tcp`)
-
-	// TODO: some more testcases
-	// testcases := []struct {
-	// 	caseName string
-	// 	expected interface{}
-	// }{
-	// 	{
-	// 		caseName: "testX",
-	// 		expected: nil,
-	// 	},
-	// }
-	// for _, tc := range testcases {
-	// assert stuff
-	// }
+	assert.False(t, collector.load().healthy)
+	result = collector.load().results["Closed"]
+	assert.Equal(t, StatusKO, result.Status)
+	assert.Contains(t, result.Error, "dial tcp")
+
+	// assert JSON representation
+	healthMsg, _ := json.Marshal(result)
+	assert.Contains(t, string(healthMsg), `"status":"KO","error":"dial tcp`)
+}
+
+func TestHealthReporterContext(t *testing.T) {
+	collector := newCollector()
+
+	err := collector.AddReporter(&Config{
+		Name:     "Fast",
+		Reporter: &ctxReporter{delay: time.Millisecond},
+		Timeout:  time.Second,
+	})
+	assert.Nil(t, err)
+	err = collector.AddReporter(&Config{
+		Name:     "Slow",
+		Reporter: &ctxReporter{delay: time.Minute},
+		Timeout:  50 * time.Millisecond,
+	})
+	assert.Nil(t, err)
+
+	collector.runChecks()
+	assert.False(t, collector.load().healthy)
+	assert.Equal(t, StatusOK, collector.load().results["Fast"].Status)
+	assert.Equal(t, StatusKO, collector.load().results["Slow"].Status)
+	assert.Equal(t, "context deadline exceeded", collector.load().results["Slow"].Error)
+}
+
+func TestHealthRemoveUpdateReporter(t *testing.T) {
+	collector := newCollector()
+	err := collector.AddReporter(&Config{
+		Name:     "Slow",
+		Reporter: &ctxReporter{delay: time.Minute},
+		Timeout:  10 * time.Millisecond,
+	})
+	assert.Nil(t, err)
+	collector.runChecks()
+	assert.False(t, collector.load().healthy)
+
+	// update to soft fail
+	err = collector.UpdateReporter(&Config{
+		Name:     "Slow",
+		Reporter: &ctxReporter{delay: time.Minute},
+		Timeout:  10 * time.Millisecond,
+		SoftFail: true,
+	})
+	assert.Nil(t, err)
+	assert.True(t, collector.load().healthy)
+	assert.Empty(t, collector.load().results)
+	collector.runChecks()
+	assert.True(t, collector.load().healthy)
+	assert.Equal(t, StatusKO, collector.load().results["Slow"].Status)
+
+	// update non-existing reporter
+	err = collector.UpdateReporter(&Config{Name: "Unknown"})
+	assert.NotNil(t, err)
+
+	// remove reporter
+	err = collector.AddReporter(&Config{
+		Name:     "Failing",
+		Reporter: &ctxReporter{delay: time.Minute},
+		Timeout:  10 * time.Millisecond,
+	})
+	assert.Nil(t, err)
+	collector.runChecks()
+	assert.False(t, collector.load().healthy)
+
+	err = collector.RemoveReporter("Failing")
+	assert.Nil(t, err)
+	assert.True(t, collector.load().healthy)
+	_, found := collector.load().results["Failing"]
+	assert.False(t, found)
+
+	err = collector.RemoveReporter("Failing")
+	assert.NotNil(t, err)
+}
+
+func TestHealthDraining(t *testing.T) {
+	collector := newCollector()
+	assert.False(t, collector.IsDraining())
+	collector.SetDraining(true)
+	assert.True(t, collector.IsDraining())
+	collector.SetDraining(false)
+	assert.False(t, collector.IsDraining())
+}
+
+func TestHealthPrepareShutdown(t *testing.T) {
+	collector := newCollector()
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: &toggleReporter{}})
+	collector.runChecks()
+	h, _ := collector.HandlerWithOptions(RegisterOptions{})
+	get := func(h http.Handler, target string) int {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+		return w.Code
+	}
+
+	done := make(chan struct{})
+	start := time.Now()
+	go func() {
+		defer close(done)
+		collector.PrepareShutdown(100 * time.Millisecond)
+	}()
+	assert.Eventually(t, collector.IsDraining, time.Second, 5*time.Millisecond)
+	assert.Equal(t, http.StatusServiceUnavailable, get(h, "/healthcheck"))
+	assert.Equal(t, http.StatusServiceUnavailable, get(h, "/healthcheck/ready"))
+	assert.Equal(t, http.StatusOK, get(collector.PingHandler(), "/ping"))
+	<-done
+	assert.True(t, time.Since(start) >= 100*time.Millisecond)
+
+	// stopped collector does not hold the shutdown
+	start = time.Now()
+	collector.Stop()
+	collector.PrepareShutdown(time.Minute)
+	assert.True(t, time.Since(start) < time.Second)
+}
+
+func TestHealthThresholds(t *testing.T) {
+	collector := newCollector()
+	reporter := &toggleReporter{}
+	err := collector.AddReporter(&Config{
+		Name:             "Flaky",
+		Reporter:         reporter,
+		FailureThreshold: 3,
+		SuccessThreshold: 2,
+	})
+	assert.Nil(t, err)
+
+	steps := []struct {
+		err      error
+		status   Status
+		failures int
+	}{
+		{err: nil, status: StatusOK},
+		{err: errors.New("blip"), status: StatusOK, failures: 1},
+		{err: errors.New("blip"), status: StatusOK, failures: 2},
+		{err: nil, status: StatusOK},
+		{err: errors.New("down"), status: StatusOK, failures: 1},
+		{err: errors.New("down"), status: StatusOK, failures: 2},
+		{err: errors.New("down"), status: StatusKO, failures: 3},
+		{err: nil, status: StatusKO},
+		{err: nil, status: StatusOK},
+	}
+	for i, step := range steps {
+		reporter.set(step.err)
+		collector.runChecks()
+		result := collector.load().results["Flaky"]
+		assert.Equal(t, step.status, result.Status, "step %d", i)
+		assert.Equal(t, step.failures, result.ConsecutiveFailures, "step %d", i)
+		assert.Equal(t, step.status == StatusOK, collector.load().healthy, "step %d", i)
+	}
+}
+
+func TestHealthSnapshotIsImmutable(t *testing.T) {
+	collector := newCollector()
+	reporter := &toggleReporter{}
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: reporter})
+	collector.runChecks()
+	before := collector.load()
+
+	reporter.set(errors.New("down"))
+	collector.runChecks()
+	after := collector.load()
+
+	assert.True(t, before.healthy)
+	assert.Equal(t, StatusOK, before.results["db"].Status)
+	assert.False(t, after.healthy)
+	assert.Equal(t, StatusKO, after.results["db"].Status)
+}
+
+func TestHealthReporterTimeout(t *testing.T) {
+	collector := newCollector()
+	collector.timeout = 5 * time.Second
+	collector.timeouts["db"] = 3 * time.Second
+
+	assert.Equal(t, time.Second, collector.reporterTimeout(&Config{Name: "db", Timeout: time.Second}))
+	assert.Equal(t, 3*time.Second, collector.reporterTimeout(&Config{Name: "db"}))
+	assert.Equal(t, 5*time.Second, collector.reporterTimeout(&Config{Name: "cache"}))
+}
+
+func TestHealthSetInterval(t *testing.T) {
+	collector := newCollector()
+	collector.SetInterval(time.Second)
+	collector.SetInterval(2 * time.Second) // must not block
+	assert.Equal(t, 2*time.Second, <-collector.interval)
+}
+
+func TestHealthJitter(t *testing.T) {
+	collector := newCollector()
+	assert.Equal(t, time.Second, collector.withJitter(time.Second))
+
+	collector.SetJitter(100 * time.Millisecond)
+	for i := 0; i < 50; i++ {
+		d := collector.withJitter(time.Second)
+		assert.True(t, d >= time.Second && d <= 1100*time.Millisecond, d)
+	}
+
+	collector.SetJitter(-time.Second)
+	assert.Equal(t, time.Second, collector.withJitter(time.Second))
+}
+
+func TestHealthAggregateStatus(t *testing.T) {
+	collector := newCollector()
+	hard, soft := &toggleReporter{}, &toggleReporter{}
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: hard})
+	_ = collector.AddReporter(&Config{Name: "cache", Reporter: soft, SoftFail: true})
+
+	collector.runChecks()
+	assert.Equal(t, Healthy, collector.load().status)
+
+	soft.set(errors.New("down"))
+	collector.runChecks()
+	assert.Equal(t, Degraded, collector.load().status)
+	assert.True(t, collector.load().healthy)
+
+	hard.set(errors.New("down"))
+	collector.runChecks()
+	assert.Equal(t, Unhealthy, collector.load().status)
+	assert.False(t, collector.load().healthy)
+}
+
+func TestHealthSeverity(t *testing.T) {
+	collector := newCollector()
+	crit, warn, info, soft := &toggleReporter{}, &toggleReporter{}, &toggleReporter{}, &toggleReporter{}
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: crit})
+	_ = collector.AddReporter(&Config{Name: "cache", Reporter: warn, Severity: SeverityWarning})
+	_ = collector.AddReporter(&Config{Name: "stats", Reporter: info, Severity: SeverityInfo})
+	_ = collector.AddReporter(&Config{Name: "queue", Reporter: soft, SoftFail: true})
+
+	collector.runChecks()
+	assert.Equal(t, Healthy, collector.load().status)
+	results := collector.Results()
+	assert.Equal(t, SeverityCritical, results["db"].Severity)
+	assert.Equal(t, SeverityWarning, results["queue"].Severity)
+	assert.True(t, results["queue"].SoftFail)
+
+	info.set(errors.New("down"))
+	collector.runChecks()
+	assert.Equal(t, Healthy, collector.load().status)
+	assert.Equal(t, StatusKO, collector.Results()["stats"].Status)
+
+	soft.set(errors.New("down"))
+	collector.runChecks()
+	assert.Equal(t, Degraded, collector.load().status)
+
+	soft.set(nil)
+	warn.set(errors.New("down"))
+	collector.runChecks()
+	assert.Equal(t, Degraded, collector.load().status)
+
+	crit.set(errors.New("down"))
+	collector.runChecks()
+	assert.Equal(t, Unhealthy, collector.load().status)
+}
+
+func TestHealthInitialCheck(t *testing.T) {
+	collector := newCollector()
+	collector.checkOnAdd = true
+	defer collector.Stop()
+
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: &toggleReporter{err: errors.New("down")}})
+	_ = collector.AddReporter(&Config{Name: "cache", Reporter: &toggleReporter{}, DeferInitialCheck: true})
+
+	assert.Eventually(t, func() bool {
+		_, found := collector.load().results["db"]
+		return found
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, StatusKO, collector.load().results["db"].Status)
+	assert.Equal(t, Unhealthy, collector.load().status)
+
+	_, found := collector.load().results["cache"]
+	assert.False(t, found)
+}
+
+func TestHealthAccessors(t *testing.T) {
+	collector := newCollector()
+	reporter := &toggleReporter{}
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: reporter})
+	collector.runChecks()
+	assert.True(t, collector.IsHealthy())
+	assert.Equal(t, Healthy, collector.Status())
+
+	results := collector.Results()
+	assert.Equal(t, StatusOK, results["db"].Status)
+	delete(results, "db")
+	assert.Len(t, collector.Results(), 1)
+
+	reporter.set(errors.New("down"))
+	collector.runChecks()
+	assert.False(t, collector.IsHealthy())
+	assert.Equal(t, Unhealthy, collector.Status())
+	assert.Equal(t, StatusKO, collector.Results()["db"].Status)
+}
+
+type warnLogger struct {
+	log.Loggerer
+	mu       sync.Mutex
+	warnings []string
+}
+
+func (l *warnLogger) Warnf(format string, v ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.warnings = append(l.warnings, fmt.Sprintf(format, v...))
+}
+
+func TestHealthSlowThreshold(t *testing.T) {
+	collector := newCollector()
+	logger := &warnLogger{}
+	collector.SetLogger(logger)
+	collector.SetSlowThreshold(10 * time.Millisecond)
+	_ = collector.AddReporter(&Config{Name: "slow", Reporter: &ctxReporter{delay: 20 * time.Millisecond}})
+	_ = collector.AddReporter(&Config{Name: "fast", Reporter: &ctxReporter{}})
+	_ = collector.AddReporter(&Config{
+		Name:          "tolerant",
+		Reporter:      &ctxReporter{delay: 20 * time.Millisecond},
+		SlowThreshold: time.Second,
+	})
+	collector.runChecks()
+
+	results := collector.Results()
+	assert.True(t, results["slow"].Slow)
+	assert.False(t, results["fast"].Slow)
+	assert.False(t, results["tolerant"].Slow)
+	assert.True(t, results["slow"].Duration >= 20*time.Millisecond)
+	assert.Len(t, logger.warnings, 1)
+	assert.Contains(t, logger.warnings[0], "reporter 'slow' check took")
+}
+
+func TestHealthReporterMetadata(t *testing.T) {
+	collector := newCollector()
+	metadata := map[string]string{"owner": "payments", "runbook": "https://wiki.example.com/runbooks/db"}
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: &toggleReporter{err: errors.New("down")}, Metadata: metadata})
+	_ = collector.AddReporter(&Config{Name: "cache", Reporter: &toggleReporter{}})
+	collector.runChecks()
+
+	results := collector.Results()
+	assert.Equal(t, metadata, results["db"].Metadata)
+	assert.Nil(t, results["cache"].Metadata)
+
+	w := httptest.NewRecorder()
+	collector.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthcheck", nil))
+	assert.Contains(t, w.Body.String(), `"metadata":{"owner":"payments","runbook":"https://wiki.example.com/runbooks/db"}`)
+
+	// caller's map is copied on registration
+	metadata["owner"] = "platform"
+	collector.runChecks()
+	assert.Equal(t, "payments", collector.Results()["db"].Metadata["owner"])
+}
+
+func TestHealthRetries(t *testing.T) {
+	collector := newCollector()
+	recovering := &flakyReporter{failures: 2}
+	failing := &flakyReporter{failures: 10}
+	_ = collector.AddReporter(&Config{Name: "recovering", Reporter: recovering, Retries: 2, RetryBackoff: time.Millisecond})
+	_ = collector.AddReporter(&Config{Name: "failing", Reporter: failing, Retries: 2})
+	collector.runChecks()
+
+	results := collector.Results()
+	assert.Equal(t, StatusOK, results["recovering"].Status)
+	assert.True(t, results["recovering"].Duration >= 3*time.Millisecond)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&recovering.calls))
+	assert.Equal(t, StatusKO, results["failing"].Status)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&failing.calls))
+}
+
+func TestHealthCircuitBreaker(t *testing.T) {
+	collector := newCollector()
+	reporter := &flakyReporter{failures: 3}
+	_ = collector.AddReporter(&Config{
+		Name:              "db",
+		Reporter:          reporter,
+		CircuitOpenAfter:  time.Nanosecond,
+		CircuitMaxBackoff: 3 * time.Second,
+	})
+	collector.runChecks()
+	assert.False(t, collector.Results()["db"].CircuitOpen)
+
+	collector.runChecks()
+	result := collector.Results()["db"]
+	assert.True(t, result.CircuitOpen)
+	assert.Equal(t, StatusKO, result.Status)
+	assert.Equal(t, 2*time.Second, collector.circuits["db"].backoff)
+
+	// backed off checks are skipped and not considered stale
+	collector.runChecks()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&reporter.calls))
+	atomic.StoreInt64(&collector.period, int64(time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+	assert.False(t, collector.resultsWithStaleness(collector.Results())["db"].Stale)
+
+	collector.circuits["db"].nextCheck = time.Now()
+	collector.runChecks()
+	assert.Equal(t, 3*time.Second, collector.circuits["db"].backoff)
+
+	collector.circuits["db"].nextCheck = time.Now()
+	collector.runChecks()
+	result = collector.Results()["db"]
+	assert.False(t, result.CircuitOpen)
+	assert.Equal(t, StatusOK, result.Status)
+	assert.Empty(t, collector.circuits)
+}
+
+type concurrencyReporter struct {
+	running, peak *int32
+}
+
+func (r *concurrencyReporter) Check() error {
+	n := atomic.AddInt32(r.running, 1)
+	defer atomic.AddInt32(r.running, -1)
+	for {
+		peak := atomic.LoadInt32(r.peak)
+		if n <= peak || atomic.CompareAndSwapInt32(r.peak, peak, n) {
+			break
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+	return nil
+}
+
+func TestHealthMaxConcurrentChecks(t *testing.T) {
+	collector := newCollector()
+	collector.SetMaxConcurrentChecks(3)
+	var running, peak int32
+	for i := 0; i < 20; i++ {
+		_ = collector.AddReporter(&Config{
+			Name:     fmt.Sprintf("dep%d", i),
+			Reporter: &concurrencyReporter{running: &running, peak: &peak},
+		})
+	}
+	collector.runChecks()
+
+	assert.Len(t, collector.Results(), 20)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&peak))
+}
+
+func TestHealthCheckNow(t *testing.T) {
+	collector := newCollector()
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: &toggleReporter{}})
+	assert.True(t, collector.CheckNow(time.Second))
+	assert.Equal(t, StatusOK, collector.load().results["db"].Status)
+
+	_ = collector.AddReporter(&Config{Name: "hung", Reporter: &ctxReporter{delay: time.Minute}})
+	assert.False(t, collector.CheckNow(20*time.Millisecond))
+
+	// concurrent call joins the running check
+	collector.forceMu.Lock()
+	running := collector.forceDone != nil
+	collector.forceMu.Unlock()
+	assert.True(t, running)
+	collector.Stop()
+}
+
+func TestHealthResultsStaleness(t *testing.T) {
+	collector := newCollector()
+	results := map[string]CheckResult{
+		"fresh": {Status: StatusOK, LastChecked: time.Now()},
+		"stale": {Status: StatusOK, LastChecked: time.Now().Add(-time.Minute)},
+	}
+
+	// interval unknown
+	assert.False(t, collector.resultsWithStaleness(results)["stale"].Stale)
+
+	collector.SetInterval(10 * time.Second)
+	marked := collector.resultsWithStaleness(results)
+	assert.False(t, marked["fresh"].Stale)
+	assert.True(t, marked["stale"].Stale)
+	assert.False(t, results["stale"].Stale, "source must not be modified")
+}
+
+func TestHealthPanicRecovery(t *testing.T) {
+	collector := newCollector()
+	_ = collector.AddReporter(&Config{Name: "panicky", Reporter: panicReporter{}})
+	collector.runChecks()
+
+	result := collector.load().results["panicky"]
+	assert.Equal(t, StatusKO, result.Status)
+	assert.Equal(t, "panic: boom", result.Error)
+	assert.Contains(t, result.Details["stack"], "panicReporter")
+	assert.Equal(t, Unhealthy, collector.load().status)
 }
 
 func TestHealthComposeRoutePath(t *testing.T) {
@@ -167,3 +657,51 @@ func TestHealthComposeRoutePath(t *testing.T) {
 		})
 	}
 }
+
+func TestHealthRouteOptions(t *testing.T) {
+	opts := RegisterOptions{BasePath: "/admin", Name: "db"}
+	assert.Nil(t, opts.normalize())
+	assert.Equal(t, "healthcheck", opts.RouteName)
+	assert.Equal(t, "/admin/healthcheck/db", opts.Path)
+	assert.Equal(t, "ping", opts.PingRouteName)
+	assert.Equal(t, "/admin/ping", opts.PingPath)
+
+	opts = RegisterOptions{
+		BasePath:      "/admin",
+		RouteName:     "health",
+		Path:          "/internal/health",
+		PingRouteName: "alive",
+		PingPath:      "/internal/alive",
+	}
+	assert.Nil(t, opts.normalize())
+	assert.Equal(t, "/internal/health", opts.Path)
+	assert.Equal(t, "/internal/alive", opts.PingPath)
+
+	for _, opts := range []RegisterOptions{
+		{Path: "internal/health"},
+		{PingPath: "alive"},
+		{Path: "/internal/health", PingPath: "/internal/health/"},
+		{RouteName: "alive", PingRouteName: "alive"},
+	} {
+		assert.NotNil(t, opts.normalize())
+	}
+}
+
+func TestHealthHeadRoutes(t *testing.T) {
+	routes := []*router.Route{
+		createRoute("healthcheck", "/healthcheck", "Healthcheck"),
+		createRoute("ping", "/ping", "Ping"),
+		{Name: "healthcheck_drain", Path: "/healthcheck/drain", Method: http.MethodPost, Action: "Drain"},
+	}
+	heads := headRoutes(routes)
+	if assert.Len(t, heads, 2) {
+		assert.Equal(t, "healthcheck_head", heads[0].Name)
+		assert.Equal(t, http.MethodHead, heads[0].Method)
+		assert.Equal(t, "/healthcheck", heads[0].Path)
+		assert.Equal(t, "Healthcheck", heads[0].Action)
+		assert.Equal(t, "ping_head", heads[1].Name)
+	}
+	// GET routes are not modified
+	assert.Equal(t, http.MethodGet, routes[0].Method)
+	assert.Equal(t, "healthcheck", routes[0].Name)
+}
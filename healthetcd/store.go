@@ -0,0 +1,203 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+// Package healthetcd provides etcd store for aah health check collector, it
+// publishes the instance's health to etcd with a lease so that service
+// discovery reading etcd sees only the live instances. It is a separate
+// module, so that core health package stays free of etcd client dependency.
+package healthetcd // import "aahframe.work/ec/health/healthetcd"
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"aahframe.work/ec/health"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+const defaultTimeout = 5 * time.Second
+
+var _ health.Store = (*Store)(nil)
+
+// StoreOptions struct holds the configuration of etcd `Store`.
+type StoreOptions struct {
+	// Endpoints of the etcd cluster, default is `localhost:2379`.
+	Endpoints []string
+
+	// Username and Password of the etcd user, if auth is enabled.
+	Username string
+	Password string
+
+	// TLS config of the client connection, if set.
+	TLS *tls.Config
+
+	// KeyPrefix of the instance keys, default is `/aah/health/`.
+	KeyPrefix string
+
+	// TTL of the lease attached to the instance key, so that health of the
+	// terminated instance expires. Default is 30 seconds, keep it larger
+	// than the check interval since lease is refreshed on every save.
+	TTL time.Duration
+
+	// Timeout of the etcd requests, default is 5 seconds.
+	Timeout time.Duration
+}
+
+// Store struct holds the instances' health in etcd as JSON values keyed by
+// `<KeyPrefix><instance>`. The key of the instance is attached to a lease
+// which is refreshed on every save, that is every check cycle.
+//
+//	store, err := healthetcd.NewStore(healthetcd.StoreOptions{
+//	    Endpoints: []string{"etcd-1:2379", "etcd-2:2379"},
+//	})
+//	collector := health.NewCollector(health.WithStore(store))
+type Store struct {
+	opts   StoreOptions
+	client etcdClient
+	mu     sync.Mutex
+	lease  clientv3.LeaseID
+}
+
+// etcdClient interface is the subset of `clientv3.Client` used by the store.
+type etcdClient interface {
+	Grant(ctx context.Context, ttl int64) (*clientv3.LeaseGrantResponse, error)
+	KeepAliveOnce(ctx context.Context, id clientv3.LeaseID) (*clientv3.LeaseKeepAliveResponse, error)
+	Revoke(ctx context.Context, id clientv3.LeaseID) (*clientv3.LeaseRevokeResponse, error)
+	Put(ctx context.Context, key, val string, opts ...clientv3.OpOption) (*clientv3.PutResponse, error)
+	Get(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.GetResponse, error)
+	Close() error
+}
+
+// NewStore method returns an etcd `Store` instance for given options.
+func NewStore(opts StoreOptions) (*Store, error) {
+	if len(opts.Endpoints) == 0 {
+		opts.Endpoints = []string{"localhost:2379"}
+	}
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   opts.Endpoints,
+		Username:    opts.Username,
+		Password:    opts.Password,
+		TLS:         opts.TLS,
+		DialTimeout: opts.Timeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("etcd: %v", err)
+	}
+	return newStore(client, opts), nil
+}
+
+func newStore(client etcdClient, opts StoreOptions) *Store {
+	if opts.KeyPrefix == "" {
+		opts.KeyPrefix = "/aah/health/"
+	}
+	if opts.TTL <= 0 {
+		opts.TTL = 30 * time.Second
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultTimeout
+	}
+	return &Store{opts: opts, client: client}
+}
+
+// Save method stores the health of the instance with the lease, lease is
+// granted again if it has expired meanwhile.
+func (s *Store) Save(h *health.InstanceHealth) error {
+	b, err := json.Marshal(h)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s.opts.Timeout)
+	defer cancel()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.lease != clientv3.NoLease {
+		if _, err = s.client.KeepAliveOnce(ctx, s.lease); err != nil {
+			s.lease = clientv3.NoLease // expired or revoked
+		}
+	}
+	if s.lease == clientv3.NoLease {
+		resp, err := s.client.Grant(ctx, int64(math.Ceil(s.opts.TTL.Seconds())))
+		if err != nil {
+			return fmt.Errorf("etcd: unable to grant lease: %v", err)
+		}
+		s.lease = resp.ID
+	}
+	if _, err = s.client.Put(ctx, s.opts.KeyPrefix+h.Instance, string(b), clientv3.WithLease(s.lease)); err != nil {
+		return fmt.Errorf("etcd: %v", err)
+	}
+	return nil
+}
+
+// Load method returns the health of given instance.
+func (s *Store) Load(instance string) (*health.InstanceHealth, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.opts.Timeout)
+	defer cancel()
+	resp, err := s.client.Get(ctx, s.opts.KeyPrefix+instance)
+	if err != nil {
+		return nil, fmt.Errorf("etcd: %v", err)
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+	return decode(resp.Kvs[0].Value)
+}
+
+// LoadAll method returns the health of all the instances under the key
+// prefix.
+func (s *Store) LoadAll() (map[string]*health.InstanceHealth, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.opts.Timeout)
+	defer cancel()
+	resp, err := s.client.Get(ctx, s.opts.KeyPrefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("etcd: %v", err)
+	}
+	all := make(map[string]*health.InstanceHealth, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		h, err := decode(kv.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%v, key '%s'", err, kv.Key)
+		}
+		all[h.Instance] = h
+	}
+	return all, nil
+}
+
+// Close method revokes the lease, so that the instance key is removed right
+// away, and closes the etcd client. Call it on application shutdown.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var errs []string
+	if s.lease != clientv3.NoLease {
+		ctx, cancel := context.WithTimeout(context.Background(), s.opts.Timeout)
+		defer cancel()
+		if _, err := s.client.Revoke(ctx, s.lease); err != nil {
+			errs = append(errs, err.Error())
+		}
+		s.lease = clientv3.NoLease
+	}
+	if err := s.client.Close(); err != nil {
+		errs = append(errs, err.Error())
+	}
+	if len(errs) > 0 {
+		return errors.New("etcd: " + strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+func decode(b []byte) (*health.InstanceHealth, error) {
+	h := new(health.InstanceHealth)
+	if err := json.Unmarshal(b, h); err != nil {
+		return nil, fmt.Errorf("etcd: invalid store value: %v", err)
+	}
+	return h, nil
+}
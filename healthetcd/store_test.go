@@ -0,0 +1,111 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package healthetcd
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"aahframe.work/ec/health"
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// fakeClient holds the keys in memory, keys are removed along with their
+// lease on revoke. Get with any option is a prefix query.
+type fakeClient struct {
+	leases map[clientv3.LeaseID][]string
+	keys   map[string]string
+	grants int
+}
+
+func (c *fakeClient) Grant(_ context.Context, ttl int64) (*clientv3.LeaseGrantResponse, error) {
+	c.grants++
+	id := clientv3.LeaseID(c.grants)
+	c.leases[id] = nil
+	return &clientv3.LeaseGrantResponse{ID: id, TTL: ttl}, nil
+}
+
+func (c *fakeClient) KeepAliveOnce(_ context.Context, id clientv3.LeaseID) (*clientv3.LeaseKeepAliveResponse, error) {
+	if _, found := c.leases[id]; !found {
+		return nil, errors.New("requested lease not found")
+	}
+	return &clientv3.LeaseKeepAliveResponse{ID: id}, nil
+}
+
+func (c *fakeClient) Revoke(_ context.Context, id clientv3.LeaseID) (*clientv3.LeaseRevokeResponse, error) {
+	for _, key := range c.leases[id] {
+		delete(c.keys, key)
+	}
+	delete(c.leases, id)
+	return &clientv3.LeaseRevokeResponse{}, nil
+}
+
+func (c *fakeClient) Put(_ context.Context, key, val string, _ ...clientv3.OpOption) (*clientv3.PutResponse, error) {
+	c.keys[key] = val
+	lease := clientv3.LeaseID(c.grants)
+	c.leases[lease] = append(c.leases[lease], key)
+	return &clientv3.PutResponse{}, nil
+}
+
+func (c *fakeClient) Get(_ context.Context, key string, opts ...clientv3.OpOption) (*clientv3.GetResponse, error) {
+	var names []string
+	for k := range c.keys {
+		if k == key || (len(opts) > 0 && strings.HasPrefix(k, key)) {
+			names = append(names, k)
+		}
+	}
+	sort.Strings(names)
+	resp := &clientv3.GetResponse{}
+	for _, k := range names {
+		resp.Kvs = append(resp.Kvs, &mvccpb.KeyValue{Key: []byte(k), Value: []byte(c.keys[k])})
+	}
+	return resp, nil
+}
+
+func (c *fakeClient) Close() error { return nil }
+
+func TestStore(t *testing.T) {
+	client := &fakeClient{leases: make(map[clientv3.LeaseID][]string), keys: make(map[string]string)}
+	s := newStore(client, StoreOptions{})
+	assert.Equal(t, 30*time.Second, s.opts.TTL)
+
+	h, err := s.Load("app-1")
+	assert.Nil(t, err)
+	assert.Nil(t, h)
+
+	assert.Nil(t, s.Save(&health.InstanceHealth{Instance: "app-1", Status: health.Healthy}))
+	assert.Nil(t, s.Save(&health.InstanceHealth{Instance: "app-1", Status: health.Degraded}))
+	assert.Equal(t, 1, client.grants)
+	h, err = s.Load("app-1")
+	assert.Nil(t, err)
+	assert.Equal(t, health.Degraded, h.Status)
+
+	// expired lease is granted again
+	_, _ = client.Revoke(context.Background(), s.lease)
+	assert.Nil(t, s.Save(&health.InstanceHealth{Instance: "app-1", Status: health.Healthy}))
+	assert.Equal(t, 2, client.grants)
+
+	client.keys["/aah/health/app-2"] = `{"instance":"app-2","status":"unhealthy"}`
+	all, err := s.LoadAll()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(all))
+	assert.Equal(t, health.Unhealthy, all["app-2"].Status)
+
+	// close removes the instance key
+	assert.Nil(t, s.Close())
+	h, err = s.Load("app-1")
+	assert.Nil(t, err)
+	assert.Nil(t, h)
+
+	client.keys["/aah/health/app-3"] = `invalid`
+	_, err = s.LoadAll()
+	assert.Contains(t, err.Error(), "etcd: invalid store value")
+}
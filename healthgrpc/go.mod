@@ -0,0 +1,23 @@
+module aahframe.work/ec/health/healthgrpc
+
+go 1.22.0
+
+require (
+	aahframe.work/ec/health v0.1.0
+	github.com/stretchr/testify v1.8.1
+	google.golang.org/grpc v1.71.0
+)
+
+require (
+	aahframe.work v0.12.5 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/net v0.34.0 // indirect
+	golang.org/x/sys v0.29.0 // indirect
+	golang.org/x/text v0.21.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250115164207-1a7da9e5054f // indirect
+	google.golang.org/protobuf v1.36.4 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace aahframe.work/ec/health => ../
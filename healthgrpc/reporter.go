@@ -0,0 +1,113 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+// Package healthgrpc provides gRPC Health Checking Protocol integration
+// for aah health check collector. It is a separate module, so that core
+// health package stays free of gRPC dependency.
+package healthgrpc // import "aahframe.work/ec/health/healthgrpc"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const defaultTimeout = 5 * time.Second
+
+// ReporterOptions struct holds the configuration of gRPC health `Reporter`.
+type ReporterOptions struct {
+	// Target is the gRPC server address, for e.g. `localhost:50051`.
+	Target string
+
+	// Service name to be checked, empty checks the overall server health.
+	Service string
+
+	// Timeout of the health check call, default is 5 seconds.
+	Timeout time.Duration
+
+	// DialOptions used to create the client connection, default is
+	// insecure transport credentials.
+	DialOptions []grpc.DialOption
+}
+
+// Reporter struct checks the health of a gRPC server using the standard
+// `grpc.health.v1.Health/Check` method. It implements the interfaces
+// `health.Reporter` and `health.ReporterContext`.
+type Reporter struct {
+	opts ReporterOptions
+	mu   sync.Mutex
+	conn *grpc.ClientConn
+}
+
+// NewReporter method returns a gRPC health `Reporter` instance for given
+// options. Connection is established lazily on first check.
+func NewReporter(opts ReporterOptions) *Reporter {
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultTimeout
+	}
+	if len(opts.DialOptions) == 0 {
+		opts.DialOptions = []grpc.DialOption{
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		}
+	}
+	return &Reporter{opts: opts}
+}
+
+// Check method calls the gRPC health check service.
+func (r *Reporter) Check() error {
+	return r.CheckContext(context.Background())
+}
+
+// CheckContext method calls the gRPC health check service with given context,
+// it returns an error if the service is not `SERVING`.
+func (r *Reporter) CheckContext(ctx context.Context) error {
+	conn, err := r.connection()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.opts.Timeout)
+	defer cancel()
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{
+		Service: r.opts.Service,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("service '%s' status is %s", r.opts.Service, resp.GetStatus())
+	}
+	return nil
+}
+
+// Close method closes the underlying client connection.
+func (r *Reporter) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.conn == nil {
+		return nil
+	}
+	err := r.conn.Close()
+	r.conn = nil
+	return err
+}
+
+func (r *Reporter) connection() (*grpc.ClientConn, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.conn != nil {
+		return r.conn, nil
+	}
+	conn, err := grpc.NewClient(r.opts.Target, r.opts.DialOptions...)
+	if err != nil {
+		return nil, err
+	}
+	r.conn = conn
+	return conn, nil
+}
@@ -0,0 +1,53 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package healthgrpc
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestReporter(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+
+	hs := health.NewServer()
+	hs.SetServingStatus("orders", healthpb.HealthCheckResponse_SERVING)
+	hs.SetServingStatus("payments", healthpb.HealthCheckResponse_NOT_SERVING)
+	srv := grpc.NewServer()
+	healthpb.RegisterHealthServer(srv, hs)
+	go func() { _ = srv.Serve(ln) }()
+	defer srv.Stop()
+
+	overall := NewReporter(ReporterOptions{Target: ln.Addr().String()})
+	defer overall.Close()
+	assert.Nil(t, overall.Check())
+
+	orders := NewReporter(ReporterOptions{Target: ln.Addr().String(), Service: "orders"})
+	defer orders.Close()
+	assert.Nil(t, orders.Check())
+
+	payments := NewReporter(ReporterOptions{Target: ln.Addr().String(), Service: "payments"})
+	defer payments.Close()
+	err = payments.Check()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "NOT_SERVING")
+
+	unknown := NewReporter(ReporterOptions{
+		Target:  ln.Addr().String(),
+		Service: "unknown",
+		Timeout: time.Second,
+	})
+	defer unknown.Close()
+	err = unknown.Check()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "NotFound")
+}
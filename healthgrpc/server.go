@@ -0,0 +1,82 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package healthgrpc
+
+import (
+	"context"
+
+	"aahframe.work/ec/health"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// Server struct serves the standard gRPC Health Checking Protocol
+// (`grpc.health.v1.Health`) backed by the health collector. Each reporter
+// is exposed as a service by its name and the overall server health, empty
+// service name, reflects collector's global health.
+type Server struct {
+	*grpchealth.Server
+	collector *health.Collector
+}
+
+var _ healthpb.HealthServer = (*Server)(nil)
+
+// NewServer method returns a gRPC health `Server` instance for given
+// collector, serving statuses are kept in sync with the collector.
+func NewServer(c *health.Collector) *Server {
+	s := &Server{
+		Server:    grpchealth.NewServer(),
+		collector: c,
+	}
+	c.OnStatusChange(func(name string, _, new health.Status) {
+		s.SetServingStatus(name, servingStatus(new == health.StatusOK))
+	})
+	c.OnHealthChange(func(healthy bool) {
+		s.SetServingStatus("", servingStatus(healthy))
+	})
+	s.SetServingStatus("", servingStatus(c.IsHealthy()))
+	for name, result := range c.Results() {
+		s.SetServingStatus(name, servingStatus(result.IsOK()))
+	}
+	return s
+}
+
+// Register method registers the health service into given gRPC server.
+func (s *Server) Register(gs *grpc.Server) {
+	healthpb.RegisterHealthServer(gs, s)
+}
+
+// NewGRPCServer method returns a dedicated gRPC server with the health
+// service registered, caller is responsible to serve and stop it.
+func (s *Server) NewGRPCServer(opts ...grpc.ServerOption) *grpc.Server {
+	gs := grpc.NewServer(opts...)
+	s.Register(gs)
+	return gs
+}
+
+// Check method responds with the serving status of the requested service
+// as per collector's latest check results.
+func (s *Server) Check(_ context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if req.GetService() == "" {
+		return &healthpb.HealthCheckResponse{
+			Status: servingStatus(s.collector.IsHealthy() && !s.collector.IsDraining()),
+		}, nil
+	}
+	result, found := s.collector.Results()[req.GetService()]
+	if !found {
+		return nil, status.Error(codes.NotFound, "unknown service")
+	}
+	return &healthpb.HealthCheckResponse{Status: servingStatus(result.IsOK())}, nil
+}
+
+func servingStatus(ok bool) healthpb.HealthCheckResponse_ServingStatus {
+	if ok {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
@@ -0,0 +1,71 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package healthgrpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"aahframe.work/ec/health"
+	"github.com/stretchr/testify/assert"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type staticReporter struct {
+	err error
+}
+
+func (r *staticReporter) Check() error {
+	return r.err
+}
+
+func TestServer(t *testing.T) {
+	collector := health.NewCollector()
+	defer collector.Stop()
+	_ = collector.AddReporter(&health.Config{Name: "db", Reporter: &staticReporter{}})
+	_ = collector.AddReporter(&health.Config{
+		Name:     "cache",
+		Reporter: &staticReporter{err: errors.New("down")},
+		SoftFail: true,
+	})
+	assert.True(t, collector.CheckNow(time.Second))
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	gs := NewServer(collector).NewGRPCServer()
+	go func() { _ = gs.Serve(ln) }()
+	defer gs.Stop()
+
+	r := NewReporter(ReporterOptions{Target: ln.Addr().String()})
+	defer r.Close()
+	conn, err := r.connection()
+	assert.Nil(t, err)
+	client := healthpb.NewHealthClient(conn)
+
+	testcases := []struct {
+		service string
+		status  healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{service: "", status: healthpb.HealthCheckResponse_SERVING},
+		{service: "db", status: healthpb.HealthCheckResponse_SERVING},
+		{service: "cache", status: healthpb.HealthCheckResponse_NOT_SERVING},
+	}
+	for _, tc := range testcases {
+		resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: tc.service})
+		assert.Nil(t, err)
+		assert.Equal(t, tc.status, resp.GetStatus(), tc.service)
+	}
+
+	_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "unknown"})
+	assert.NotNil(t, err)
+
+	collector.SetDraining(true)
+	resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.GetStatus())
+}
@@ -0,0 +1,20 @@
+module aahframe.work/ec/health/healthkafka
+
+go 1.22.0
+
+require (
+	aahframe.work/ec/health v0.1.0
+	github.com/segmentio/kafka-go v0.4.47
+	github.com/stretchr/testify v1.8.1
+)
+
+require (
+	aahframe.work v0.12.5 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/klauspost/compress v1.15.9 // indirect
+	github.com/pierrec/lz4/v4 v4.1.15 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace aahframe.work/ec/health => ../
@@ -0,0 +1,151 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+// Package healthkafka provides Kafka reporter for aah health check
+// collector. It is a separate module, so that core health package stays
+// free of Kafka client dependency.
+package healthkafka // import "aahframe.work/ec/health/healthkafka"
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"aahframe.work/ec/health"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+)
+
+const defaultTimeout = 5 * time.Second
+
+var _ health.ReporterDetails = (*Reporter)(nil)
+
+// ReporterOptions struct holds the configuration of Kafka `Reporter`.
+type ReporterOptions struct {
+	// Brokers are the bootstrap broker addresses, for e.g. `kafka-1:9092`.
+	// Reporter connects to the first reachable broker.
+	Brokers []string
+
+	// Topics to be verified, if not empty reporter fetches their metadata
+	// and fails if any of the partitions has no leader.
+	Topics []string
+
+	// Timeout of the check, default is 5 seconds.
+	Timeout time.Duration
+
+	// TLS config to connect the brokers over TLS, if not nil.
+	TLS *tls.Config
+
+	// SASL mechanism to authenticate with the brokers, if not nil. For e.g.
+	// `plain.Mechanism` or `scram.Mechanism` of kafka-go.
+	SASL sasl.Mechanism
+
+	// ClientID reported to the brokers, default is `aah-health`.
+	ClientID string
+}
+
+// Reporter struct checks the health of a Kafka cluster by connecting to the
+// brokers and optionally verifying topic metadata and partition leadership.
+// Number of brokers and partitions are reported in `CheckResult.Details`.
+// It implements the interfaces `health.Reporter` and `health.ReporterDetails`.
+type Reporter struct {
+	opts   ReporterOptions
+	dialer *kafka.Dialer
+}
+
+// NewReporter method returns a Kafka `Reporter` instance for given options.
+func NewReporter(opts ReporterOptions) *Reporter {
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultTimeout
+	}
+	if opts.ClientID == "" {
+		opts.ClientID = "aah-health"
+	}
+	return &Reporter{
+		opts: opts,
+		dialer: &kafka.Dialer{
+			ClientID:      opts.ClientID,
+			Timeout:       opts.Timeout,
+			TLS:           opts.TLS,
+			SASLMechanism: opts.SASL,
+		},
+	}
+}
+
+// Check method checks the Kafka cluster.
+func (r *Reporter) Check() error {
+	_, err := r.CheckDetails(context.Background())
+	return err
+}
+
+// CheckDetails method checks the Kafka cluster with given context and
+// returns the cluster details.
+func (r *Reporter) CheckDetails(ctx context.Context) (map[string]interface{}, error) {
+	if len(r.opts.Brokers) == 0 {
+		return nil, errors.New("kafka: no brokers configured")
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.opts.Timeout)
+	defer cancel()
+
+	conn, err := r.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	brokers, err := conn.Brokers()
+	if err != nil {
+		return nil, fmt.Errorf("kafka: unable to fetch brokers: %v", err)
+	}
+	details := map[string]interface{}{"brokers": len(brokers)}
+	if len(r.opts.Topics) == 0 {
+		return details, nil
+	}
+
+	partitions, err := conn.ReadPartitions(r.opts.Topics...)
+	if err != nil {
+		return details, fmt.Errorf("kafka: unable to fetch topic metadata: %v", err)
+	}
+	counts := make(map[string]int, len(r.opts.Topics))
+	var leaderless []string
+	for _, p := range partitions {
+		counts[p.Topic]++
+		if p.Leader.Host == "" {
+			leaderless = append(leaderless, fmt.Sprintf("%s/%d", p.Topic, p.ID))
+		}
+	}
+	details["partitions"] = counts
+	for _, topic := range r.opts.Topics {
+		if counts[topic] == 0 {
+			return details, fmt.Errorf("kafka: topic '%s' does not exist", topic)
+		}
+	}
+	if len(leaderless) > 0 {
+		details["leaderless"] = leaderless
+		return details, fmt.Errorf("kafka: partitions without leader: %s", strings.Join(leaderless, ", "))
+	}
+	return details, nil
+}
+
+// dial method connects to the first reachable broker.
+func (r *Reporter) dial(ctx context.Context) (*kafka.Conn, error) {
+	var errs []string
+	for _, broker := range r.opts.Brokers {
+		conn, err := r.dialer.DialContext(ctx, "tcp", broker)
+		if err == nil {
+			return conn, nil
+		}
+		errs = append(errs, fmt.Sprintf("%s: %v", broker, err))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, fmt.Errorf("kafka: unable to connect brokers: %s", strings.Join(errs, "; "))
+}
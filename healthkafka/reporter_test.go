@@ -0,0 +1,28 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package healthkafka
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReporter(t *testing.T) {
+	err := NewReporter(ReporterOptions{}).Check()
+	assert.Equal(t, "kafka: no brokers configured", err.Error())
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	address := ln.Addr().String()
+	_ = ln.Close()
+
+	r := NewReporter(ReporterOptions{Brokers: []string{address}, Timeout: time.Second})
+	assert.Equal(t, "aah-health", r.opts.ClientID)
+	err = r.Check()
+	assert.Contains(t, err.Error(), "kafka: unable to connect brokers: "+address)
+}
@@ -0,0 +1,152 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+// Package healthmongo provides MongoDB reporter for aah health check
+// collector. It is a separate module, so that core health package stays
+// free of MongoDB driver dependency.
+package healthmongo // import "aahframe.work/ec/health/healthmongo"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"aahframe.work/ec/health"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+const defaultTimeout = 5 * time.Second
+
+var _ health.ReporterDetails = (*Reporter)(nil)
+
+// ReporterOptions struct holds the configuration of MongoDB `Reporter`.
+type ReporterOptions struct {
+	// URI is the MongoDB connection string, for e.g.
+	// `mongodb://mongo-1:27017,mongo-2:27017/?replicaSet=rs0`.
+	URI string
+
+	// Timeout of the check, default is 5 seconds.
+	Timeout time.Duration
+}
+
+// Reporter struct checks the health of a MongoDB server using `ping` and
+// `isMaster` commands, replica set role of the server is reported in
+// `CheckResult.Details`. It implements the interfaces `health.Reporter`
+// and `health.ReporterDetails`.
+type Reporter struct {
+	opts   ReporterOptions
+	mu     sync.Mutex
+	client *mongo.Client
+}
+
+// NewReporter method returns a MongoDB `Reporter` instance for given
+// options. Connection is established lazily on first check.
+func NewReporter(opts ReporterOptions) *Reporter {
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultTimeout
+	}
+	return &Reporter{opts: opts}
+}
+
+// Check method runs the `ping` command.
+func (r *Reporter) Check() error {
+	_, err := r.CheckDetails(context.Background())
+	return err
+}
+
+// isMasterResult struct holds the fields of `isMaster` command response.
+type isMasterResult struct {
+	IsMaster    bool     `bson:"ismaster"`
+	Secondary   bool     `bson:"secondary"`
+	ArbiterOnly bool     `bson:"arbiterOnly"`
+	SetName     string   `bson:"setName"`
+	Me          string   `bson:"me"`
+	Hosts       []string `bson:"hosts"`
+	Msg         string   `bson:"msg"`
+}
+
+// role returns the replica set role of the server.
+func (im isMasterResult) role() string {
+	switch {
+	case im.Msg == "isdbgrid":
+		return "mongos"
+	case im.SetName == "":
+		return "standalone"
+	case im.IsMaster:
+		return "primary"
+	case im.Secondary:
+		return "secondary"
+	case im.ArbiterOnly:
+		return "arbiter"
+	default:
+		return "other"
+	}
+}
+
+// CheckDetails method runs the `ping` and `isMaster` commands with given
+// context and returns the replica set role of the server as details.
+func (r *Reporter) CheckDetails(ctx context.Context) (map[string]interface{}, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.opts.Timeout)
+	defer cancel()
+
+	client, err := r.connection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = client.Ping(ctx, readpref.Nearest()); err != nil {
+		return nil, fmt.Errorf("mongo: ping failed: %v", err)
+	}
+
+	var im isMasterResult
+	if err = client.Database("admin").RunCommand(ctx, bson.D{{Key: "isMaster", Value: 1}}).Decode(&im); err != nil {
+		return nil, fmt.Errorf("mongo: isMaster failed: %v", err)
+	}
+	details := map[string]interface{}{"role": im.role()}
+	if im.SetName != "" {
+		details["setName"] = im.SetName
+		details["me"] = im.Me
+		details["hosts"] = len(im.Hosts)
+	}
+	return details, nil
+}
+
+// Close method disconnects the client.
+func (r *Reporter) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.client == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), r.opts.Timeout)
+	defer cancel()
+	err := r.client.Disconnect(ctx)
+	r.client = nil
+	return err
+}
+
+func (r *Reporter) connection(ctx context.Context) (*mongo.Client, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.client != nil {
+		return r.client, nil
+	}
+	if r.opts.URI == "" {
+		return nil, errors.New("mongo: URI is not configured")
+	}
+	opts := options.Client().
+		ApplyURI(r.opts.URI).
+		SetConnectTimeout(r.opts.Timeout).
+		SetServerSelectionTimeout(r.opts.Timeout)
+	client, err := mongo.Connect(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("mongo: %v", err)
+	}
+	r.client = client
+	return client, nil
+}
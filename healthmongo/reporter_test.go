@@ -0,0 +1,35 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package healthmongo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReporter(t *testing.T) {
+	r := NewReporter(ReporterOptions{})
+	assert.Equal(t, defaultTimeout, r.opts.Timeout)
+	assert.Equal(t, "mongo: URI is not configured", r.Check().Error())
+	assert.Nil(t, r.Close())
+}
+
+func TestIsMasterRole(t *testing.T) {
+	testcases := []struct {
+		result isMasterResult
+		role   string
+	}{
+		{result: isMasterResult{IsMaster: true}, role: "standalone"},
+		{result: isMasterResult{IsMaster: true, Msg: "isdbgrid"}, role: "mongos"},
+		{result: isMasterResult{IsMaster: true, SetName: "rs0"}, role: "primary"},
+		{result: isMasterResult{Secondary: true, SetName: "rs0"}, role: "secondary"},
+		{result: isMasterResult{ArbiterOnly: true, SetName: "rs0"}, role: "arbiter"},
+		{result: isMasterResult{SetName: "rs0"}, role: "other"},
+	}
+	for _, tc := range testcases {
+		assert.Equal(t, tc.role, tc.result.role())
+	}
+}
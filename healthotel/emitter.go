@@ -0,0 +1,95 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package healthotel
+
+import (
+	"context"
+
+	"aahframe.work/ec/health"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Metric names of the emitter.
+const (
+	MetricUp            = "health.up"
+	MetricCheckUp       = "health.check.up"
+	MetricCheckDuration = "health.check.duration"
+	MetricChecks        = "health.checks"
+)
+
+var _ health.MetricsEmitter = (*MeterEmitter)(nil)
+
+// MeterEmitter struct implements `health.MetricsEmitter` using
+// OpenTelemetry metrics, so that health is pushed through the configured
+// metric exporter, for e.g. OTLP.
+//
+// Recorded metrics:
+//
+//	health.up             - global health status, 1 is healthy (gauge)
+//	health.check.up       - reporter health status, 1 is healthy (gauge)
+//	health.check.duration - reporter check duration in seconds (histogram)
+//	health.checks         - reporter checks by status `OK` or `KO` (counter)
+//
+// Reporter metrics carry the attribute `health.reporter`.
+//
+//	emitter, err := healthotel.NewMeterEmitter(nil)
+//	collector := health.NewCollector(health.WithMetricsEmitter(emitter))
+type MeterEmitter struct {
+	up       metric.Int64Gauge
+	checkUp  metric.Int64Gauge
+	duration metric.Float64Histogram
+	checks   metric.Int64Counter
+}
+
+// NewMeterEmitter method returns a `MeterEmitter` instance for given meter
+// provider, if nil global meter provider is used.
+func NewMeterEmitter(mp metric.MeterProvider) (*MeterEmitter, error) {
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+	meter := mp.Meter(instrumentationName)
+	var (
+		e   MeterEmitter
+		err error
+	)
+	if e.up, err = meter.Int64Gauge(MetricUp,
+		metric.WithDescription("Global health status, 1 is healthy and 0 is unhealthy.")); err != nil {
+		return nil, err
+	}
+	if e.checkUp, err = meter.Int64Gauge(MetricCheckUp,
+		metric.WithDescription("Reporter health status, 1 is healthy and 0 is unhealthy.")); err != nil {
+		return nil, err
+	}
+	if e.duration, err = meter.Float64Histogram(MetricCheckDuration, metric.WithUnit("s"),
+		metric.WithDescription("Reporter health check duration in seconds.")); err != nil {
+		return nil, err
+	}
+	if e.checks, err = meter.Int64Counter(MetricChecks,
+		metric.WithDescription("Reporter health checks by status.")); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// Emit method records the metrics of the check run.
+func (e *MeterEmitter) Emit(ctx context.Context, m *health.CycleMetrics) error {
+	e.up.Record(ctx, boolToInt(m.Healthy()))
+	for _, cm := range m.Checks {
+		reporter := attribute.String(AttrReporter, cm.Name)
+		e.checkUp.Record(ctx, boolToInt(cm.Status == health.StatusOK), metric.WithAttributes(reporter))
+		e.duration.Record(ctx, cm.Duration.Seconds(), metric.WithAttributes(reporter))
+		e.checks.Add(ctx, 1, metric.WithAttributes(reporter, attribute.String(AttrStatus, string(cm.Status))))
+	}
+	return nil
+}
+
+func boolToInt(v bool) int64 {
+	if v {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,76 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package healthotel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"aahframe.work/ec/health"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestMeterEmitter(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	emitter, err := NewMeterEmitter(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	ctx := context.Background()
+	m := &health.CycleMetrics{
+		Status: health.Unhealthy,
+		Checks: []health.CheckMetrics{
+			{Name: "db", Status: health.StatusOK, Duration: 20 * time.Millisecond},
+			{Name: "cache", Status: health.StatusKO, Duration: 100 * time.Millisecond},
+		},
+	}
+	assert.NoError(t, emitter.Emit(ctx, m))
+	m.Status, m.Checks = health.Healthy, m.Checks[:1]
+	assert.NoError(t, emitter.Emit(ctx, m))
+
+	var rm metricdata.ResourceMetrics
+	assert.NoError(t, reader.Collect(ctx, &rm))
+	metrics := make(map[string]metricdata.Aggregation)
+	for _, sm := range rm.ScopeMetrics {
+		for _, mt := range sm.Metrics {
+			metrics[mt.Name] = mt.Data
+		}
+	}
+
+	up := metrics[MetricUp].(metricdata.Gauge[int64])
+	if assert.Len(t, up.DataPoints, 1) {
+		assert.Equal(t, int64(1), up.DataPoints[0].Value)
+	}
+
+	checkUp := make(map[string]int64)
+	for _, dp := range metrics[MetricCheckUp].(metricdata.Gauge[int64]).DataPoints {
+		checkUp[reporterOf(dp.Attributes)] = dp.Value
+	}
+	assert.Equal(t, map[string]int64{"db": 1, "cache": 0}, checkUp)
+
+	checks := make(map[string]int64)
+	for _, dp := range metrics[MetricChecks].(metricdata.Sum[int64]).DataPoints {
+		status, _ := dp.Attributes.Value(AttrStatus)
+		checks[reporterOf(dp.Attributes)+"/"+status.AsString()] = dp.Value
+	}
+	assert.Equal(t, map[string]int64{"db/OK": 2, "cache/KO": 1}, checks)
+
+	for _, dp := range metrics[MetricCheckDuration].(metricdata.Histogram[float64]).DataPoints {
+		if reporterOf(dp.Attributes) == "db" {
+			assert.Equal(t, uint64(2), dp.Count)
+			assert.InDelta(t, 0.04, dp.Sum, 1e-9)
+		}
+	}
+}
+
+func reporterOf(set attribute.Set) string {
+	v, _ := set.Value(AttrReporter)
+	return v.AsString()
+}
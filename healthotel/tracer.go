@@ -0,0 +1,88 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+// Package healthotel provides OpenTelemetry tracer and metrics emitter for
+// aah health check collector, each check run is traced as a span with a
+// child span per reporter and its metrics are recorded into the meter. It is
+// a separate module, so that core health package stays free of OpenTelemetry
+// dependency.
+package healthotel // import "aahframe.work/ec/health/healthotel"
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"aahframe.work/ec/health"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "aahframe.work/ec/health/healthotel"
+
+// Span names and attribute keys of the tracer.
+const (
+	CycleSpanName = "health.check_cycle"
+	CheckSpanName = "health.check"
+
+	AttrReporters  = "health.reporters"
+	AttrReporter   = "health.reporter"
+	AttrStatus     = "health.status"
+	AttrSeverity   = "health.severity"
+	AttrDurationMs = "health.duration_ms"
+	AttrSlow       = "health.slow"
+)
+
+var _ health.Tracer = (*Tracer)(nil)
+
+// Tracer struct implements `health.Tracer` using OpenTelemetry. Check run
+// is traced as span `health.check_cycle` and each reporter's check as its
+// child span `health.check`, failed check marks its span as error.
+//
+//	collector := health.NewCollector(
+//	    health.WithTracer(healthotel.NewTracer(nil)),
+//	)
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+// NewTracer method returns a `Tracer` instance for given tracer provider, if
+// nil global tracer provider is used.
+func NewTracer(tp trace.TracerProvider) *Tracer {
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	return &Tracer{tracer: tp.Tracer(instrumentationName)}
+}
+
+// StartCycle method starts the span of a check run.
+func (t *Tracer) StartCycle(ctx context.Context, reporters int) (context.Context, func()) {
+	ctx, span := t.tracer.Start(ctx, CycleSpanName,
+		trace.WithAttributes(attribute.Int(AttrReporters, reporters)))
+	return ctx, func() { span.End() }
+}
+
+// StartCheck method starts the span of given reporter's check, span is
+// ended with the status, severity and duration of the check result.
+func (t *Tracer) StartCheck(ctx context.Context, name string) (context.Context, func(result health.CheckResult)) {
+	ctx, span := t.tracer.Start(ctx, CheckSpanName,
+		trace.WithAttributes(attribute.String(AttrReporter, name)))
+	return ctx, func(result health.CheckResult) {
+		span.SetAttributes(
+			attribute.String(AttrStatus, string(result.Status)),
+			attribute.Float64(AttrDurationMs, float64(result.Duration)/float64(time.Millisecond)),
+			attribute.Bool(AttrSlow, result.Slow),
+		)
+		if len(result.Severity) > 0 {
+			span.SetAttributes(attribute.String(AttrSeverity, string(result.Severity)))
+		}
+		if !result.IsOK() {
+			span.RecordError(errors.New(result.Error))
+			span.SetStatus(codes.Error, result.Error)
+		}
+		span.End()
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package healthotel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"aahframe.work/ec/health"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func attrs(s sdktrace.ReadOnlySpan) map[string]string {
+	m := make(map[string]string)
+	for _, kv := range s.Attributes() {
+		m[string(kv.Key)] = kv.Value.Emit()
+	}
+	return m
+}
+
+func TestTracer(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := NewTracer(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	ctx, endCycle := tracer.StartCycle(context.Background(), 2)
+	_, endDB := tracer.StartCheck(ctx, "db")
+	endDB(health.CheckResult{Status: health.StatusOK, Duration: 1500 * time.Microsecond})
+	_, endCache := tracer.StartCheck(ctx, "cache")
+	endCache(health.CheckResult{
+		Status:   health.StatusKO,
+		Error:    "connection refused",
+		Severity: health.SeverityWarning,
+		Slow:     true,
+	})
+	endCycle()
+
+	spans := recorder.Ended()
+	if !assert.Len(t, spans, 3) {
+		return
+	}
+	db, cache, cycle := spans[0], spans[1], spans[2]
+
+	assert.Equal(t, CycleSpanName, cycle.Name())
+	assert.Equal(t, "2", attrs(cycle)[AttrReporters])
+
+	assert.Equal(t, CheckSpanName, db.Name())
+	assert.Equal(t, cycle.SpanContext().SpanID(), db.Parent().SpanID())
+	assert.Equal(t, map[string]string{
+		AttrReporter:   "db",
+		AttrStatus:     string(health.StatusOK),
+		AttrDurationMs: "1.5",
+		AttrSlow:       "false",
+	}, attrs(db))
+	assert.Equal(t, codes.Unset, db.Status().Code)
+
+	assert.Equal(t, cycle.SpanContext().SpanID(), cache.Parent().SpanID())
+	assert.Equal(t, "cache", attrs(cache)[AttrReporter])
+	assert.Equal(t, string(health.SeverityWarning), attrs(cache)[AttrSeverity])
+	assert.Equal(t, "true", attrs(cache)[AttrSlow])
+	assert.Equal(t, codes.Error, cache.Status().Code)
+	assert.Equal(t, "connection refused", cache.Status().Description)
+}
@@ -0,0 +1,154 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+// Package healths3 provides S3 compatible object storage reporter for aah
+// health check collector. It is a separate module, so that core health
+// package stays free of AWS SDK dependency.
+package healths3 // import "aahframe.work/ec/health/healths3"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"aahframe.work/ec/health"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+const defaultTimeout = 5 * time.Second
+
+// Operations performed by the reporter.
+const (
+	// OperationHead checks the bucket using `HeadBucket`.
+	OperationHead = "head"
+
+	// OperationList checks the bucket by listing at most one object.
+	OperationList = "list"
+)
+
+var _ health.ReporterDetails = (*Reporter)(nil)
+
+// ReporterOptions struct holds the configuration of object storage `Reporter`.
+type ReporterOptions struct {
+	// Bucket to be checked, it is required.
+	Bucket string
+
+	// Region of the bucket, default is `us-east-1`.
+	Region string
+
+	// Endpoint of S3 compatible storage such as MinIO, for e.g.
+	// `https://minio.example.com:9000`. Default is AWS S3.
+	Endpoint string
+
+	// UsePathStyle addresses the bucket as path instead of sub-domain,
+	// usually required for S3 compatible storage.
+	UsePathStyle bool
+
+	// AccessKeyID, SecretAccessKey and SessionToken are the static
+	// credentials, if empty default AWS credential chain is used.
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+
+	// Operation is either `OperationHead` or `OperationList`, default is
+	// `OperationHead`.
+	Operation string
+
+	// Timeout of the check, default is 5 seconds.
+	Timeout time.Duration
+}
+
+// Reporter struct checks the health of S3 compatible object storage bucket,
+// latency of the operation is reported in `CheckResult.Details`. It
+// implements the interfaces `health.Reporter` and `health.ReporterDetails`.
+type Reporter struct {
+	opts   ReporterOptions
+	mu     sync.Mutex
+	client *s3.Client
+}
+
+// NewReporter method returns an object storage `Reporter` instance for given
+// options. Client is created lazily on first check.
+func NewReporter(opts ReporterOptions) *Reporter {
+	if opts.Region == "" {
+		opts.Region = "us-east-1"
+	}
+	if opts.Operation == "" {
+		opts.Operation = OperationHead
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultTimeout
+	}
+	return &Reporter{opts: opts}
+}
+
+// Check method performs the operation on the bucket.
+func (r *Reporter) Check() error {
+	_, err := r.CheckDetails(context.Background())
+	return err
+}
+
+// CheckDetails method performs the operation on the bucket with given
+// context and returns the latency as details.
+func (r *Reporter) CheckDetails(ctx context.Context) (map[string]interface{}, error) {
+	if r.opts.Bucket == "" {
+		return nil, errors.New("s3: bucket is not configured")
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.opts.Timeout)
+	defer cancel()
+
+	client, err := r.connection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	switch r.opts.Operation {
+	case OperationHead:
+		_, err = client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(r.opts.Bucket)})
+	case OperationList:
+		_, err = client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+			Bucket:  aws.String(r.opts.Bucket),
+			MaxKeys: aws.Int32(1),
+		})
+	default:
+		return nil, fmt.Errorf("s3: unsupported operation '%s'", r.opts.Operation)
+	}
+	details := map[string]interface{}{
+		"operation": r.opts.Operation,
+		"latencyMs": float64(time.Since(start)) / float64(time.Millisecond),
+	}
+	if err != nil {
+		return details, fmt.Errorf("s3: %s bucket '%s' failed: %v", r.opts.Operation, r.opts.Bucket, err)
+	}
+	return details, nil
+}
+
+func (r *Reporter) connection(ctx context.Context) (*s3.Client, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.client != nil {
+		return r.client, nil
+	}
+	loadOpts := []func(*config.LoadOptions) error{config.WithRegion(r.opts.Region)}
+	if len(r.opts.AccessKeyID) > 0 {
+		loadOpts = append(loadOpts, config.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(r.opts.AccessKeyID, r.opts.SecretAccessKey, r.opts.SessionToken)))
+	}
+	cfg, err := config.LoadDefaultConfig(ctx, loadOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("s3: %v", err)
+	}
+	r.client = s3.NewFromConfig(cfg, func(o *s3.Options) {
+		if len(r.opts.Endpoint) > 0 {
+			o.BaseEndpoint = aws.String(r.opts.Endpoint)
+		}
+		o.UsePathStyle = r.opts.UsePathStyle
+	})
+	return r.client, nil
+}
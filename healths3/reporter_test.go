@@ -0,0 +1,44 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package healths3
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReporter(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/backups") {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	opts := ReporterOptions{
+		Bucket:          "backups",
+		Endpoint:        ts.URL,
+		UsePathStyle:    true,
+		AccessKeyID:     "access",
+		SecretAccessKey: "secret",
+	}
+	details, err := NewReporter(opts).CheckDetails(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, OperationHead, details["operation"])
+	assert.Contains(t, details, "latencyMs")
+
+	opts.Bucket = "unknown"
+	err = NewReporter(opts).Check()
+	assert.Contains(t, err.Error(), "s3: head bucket 'unknown' failed")
+
+	assert.Equal(t, "s3: bucket is not configured", NewReporter(ReporterOptions{}).Check().Error())
+}
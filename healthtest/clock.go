@@ -0,0 +1,130 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+// Package healthtest provides utilities to test the health collector and
+// reporters deterministically.
+package healthtest
+
+import (
+	"sync"
+	"time"
+
+	"aahframe.work/ec/health"
+)
+
+var _ health.Clock = (*FakeClock)(nil)
+
+// FakeClock struct implements `health.Clock` whose time moves only when
+// `FakeClock.Advance` is called, timers fire once their deadline is reached.
+//
+//	clock := healthtest.NewFakeClock(time.Now())
+//	collector := health.NewCollector(health.WithClock(clock))
+//	clock.BlockUntil(1) // wait for collector to schedule the initial check
+//	clock.Advance(5 * time.Second)
+type FakeClock struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// NewFakeClock method returns a `FakeClock` instance set to given time.
+func NewFakeClock(now time.Time) *FakeClock {
+	f := &FakeClock{now: now}
+	f.cond = sync.NewCond(&f.mu)
+	return f
+}
+
+// Now method returns the current time of the fake clock.
+func (f *FakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// NewTimer method returns a timer which fires once the fake clock is
+// advanced by given duration.
+func (f *FakeClock) NewTimer(d time.Duration) health.Timer {
+	t := &fakeTimer{clock: f, c: make(chan time.Time, 1)}
+	t.Reset(d)
+	return t
+}
+
+// Advance method moves the fake clock forward by given duration and fires
+// the timers whose deadline is reached.
+func (f *FakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+	active := f.timers[:0]
+	for _, t := range f.timers {
+		if t.deadline.After(f.now) {
+			active = append(active, t)
+			continue
+		}
+		select {
+		case t.c <- f.now:
+		default:
+		}
+	}
+	f.timers = active
+	f.cond.Broadcast()
+}
+
+// BlockUntil method blocks until the fake clock has at least given number
+// of active timers, use it to synchronize with the collector goroutine.
+func (f *FakeClock) BlockUntil(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for len(f.timers) < n {
+		f.cond.Wait()
+	}
+}
+
+// remove method removes given timer from active timers. Caller must hold
+// the lock.
+func (f *FakeClock) remove(t *fakeTimer) bool {
+	for i, at := range f.timers {
+		if at == t {
+			f.timers = append(f.timers[:i], f.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// fakeTimer struct implements `health.Timer` for `FakeClock`.
+type fakeTimer struct {
+	clock    *FakeClock
+	c        chan time.Time
+	deadline time.Time
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	return t.clock.remove(t)
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	f := t.clock
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	active := f.remove(t)
+	t.deadline = f.now.Add(d)
+	if d <= 0 {
+		select {
+		case t.c <- f.now:
+		default:
+		}
+		return active
+	}
+	f.timers = append(f.timers, t)
+	f.cond.Broadcast()
+	return active
+}
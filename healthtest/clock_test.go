@@ -0,0 +1,74 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package healthtest
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"aahframe.work/ec/health"
+	"github.com/stretchr/testify/assert"
+)
+
+type countReporter struct {
+	calls int32
+}
+
+func (r *countReporter) Check() error {
+	atomic.AddInt32(&r.calls, 1)
+	return nil
+}
+
+func TestFakeClockTimer(t *testing.T) {
+	start := time.Date(2019, 3, 1, 10, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	timer := clock.NewTimer(time.Second)
+
+	clock.Advance(999 * time.Millisecond)
+	select {
+	case <-timer.C():
+		t.Fatal("timer fired before deadline")
+	default:
+	}
+
+	clock.Advance(time.Millisecond)
+	assert.Equal(t, start.Add(time.Second), <-timer.C())
+	assert.False(t, timer.Stop())
+
+	assert.False(t, timer.Reset(time.Second))
+	assert.True(t, timer.Stop())
+	clock.Advance(time.Hour)
+	select {
+	case <-timer.C():
+		t.Fatal("stopped timer fired")
+	default:
+	}
+}
+
+func TestFakeClockCollector(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	reporter := &countReporter{}
+	collector := health.NewCollector(
+		health.WithClock(clock),
+		health.WithInterval(10*time.Second),
+	)
+	defer collector.Stop()
+	_ = collector.AddReporter(&health.Config{Name: "db", Reporter: reporter, DeferInitialCheck: true})
+
+	// initial delay
+	clock.BlockUntil(1)
+	clock.Advance(5 * time.Second)
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&reporter.calls) == 1 }, time.Second, time.Millisecond)
+
+	for i := int32(2); i <= 4; i++ {
+		clock.BlockUntil(1)
+		clock.Advance(10 * time.Second)
+		assert.Eventually(t, func() bool { return atomic.LoadInt32(&reporter.calls) == i }, time.Second, time.Millisecond)
+	}
+	assert.Eventually(t, func() bool {
+		return collector.Results()["db"].LastChecked.Equal(clock.Now())
+	}, time.Second, time.Millisecond)
+}
@@ -0,0 +1,82 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import "time"
+
+// defaultHistorySize is the default number of check results retained per
+// reporter, refer to `WithHistorySize`.
+const defaultHistorySize = 20
+
+// HistoryEntry struct holds a past check result of the reporter.
+type HistoryEntry struct {
+	Status   Status        `json:"status"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+	Time     time.Time     `json:"time"`
+}
+
+// History method returns the last check results of given reporter, oldest
+// first. It returns nil if the reporter does not exist.
+func (c *Collector) History(name string) []HistoryEntry {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if r, found := c.history[name]; found {
+		return r.list()
+	}
+	return nil
+}
+
+// Histories method returns the last check results of all the reporters
+// keyed by reporter name.
+func (c *Collector) Histories() map[string][]HistoryEntry {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	histories := make(map[string][]HistoryEntry, len(c.history))
+	for name, r := range c.history {
+		histories[name] = r.list()
+	}
+	return histories
+}
+
+// recordHistory method appends the result into reporter's history. Caller
+// must hold the lock.
+func (c *Collector) recordHistory(name string, result *CheckResult) {
+	if c.historySize <= 0 {
+		return
+	}
+	r, found := c.history[name]
+	if !found {
+		r = &historyRing{entries: make([]HistoryEntry, 0, c.historySize)}
+		c.history[name] = r
+	}
+	r.add(HistoryEntry{
+		Status:   result.Status,
+		Error:    result.Error,
+		Duration: result.Duration,
+		Time:     result.LastChecked,
+	})
+}
+
+// historyRing struct is a fixed size ring buffer of history entries.
+type historyRing struct {
+	entries []HistoryEntry
+	next    int
+}
+
+func (r *historyRing) add(e HistoryEntry) {
+	if len(r.entries) < cap(r.entries) {
+		r.entries = append(r.entries, e)
+		return
+	}
+	r.entries[r.next] = e
+	r.next = (r.next + 1) % len(r.entries)
+}
+
+func (r *historyRing) list() []HistoryEntry {
+	list := make([]HistoryEntry, 0, len(r.entries))
+	list = append(list, r.entries[r.next:]...)
+	return append(list, r.entries[:r.next]...)
+}
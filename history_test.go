@@ -0,0 +1,59 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthHistory(t *testing.T) {
+	collector := newCollector()
+	collector.historySize = 3
+	reporter := &toggleReporter{}
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: reporter})
+	for i := 0; i < 5; i++ {
+		if i >= 3 {
+			reporter.set(fmt.Errorf("failure %d", i))
+		}
+		collector.runChecks()
+	}
+
+	history := collector.History("db")
+	assert.Len(t, history, 3)
+	assert.Equal(t, StatusOK, history[0].Status)
+	assert.Equal(t, "failure 3", history[1].Error)
+	assert.Equal(t, "failure 4", history[2].Error)
+	assert.True(t, history[1].Time.Before(history[2].Time))
+	assert.Nil(t, collector.History("unknown"))
+
+	_ = collector.RemoveReporter("db")
+	assert.Empty(t, collector.Histories())
+}
+
+func TestHealthHistoryHandler(t *testing.T) {
+	collector := newCollector()
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: &toggleReporter{err: errors.New("dial tcp db.internal")}})
+	collector.runChecks()
+
+	h, _ := collector.HandlerWithOptions(RegisterOptions{Exposure: ExposureSummary, AllowVerboseQuery: true})
+	for _, verbose := range []bool{false, true} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/healthcheck/history?verbose=%v", verbose), nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var histories map[string][]HistoryEntry
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &histories))
+		assert.Len(t, histories["db"], 1)
+		assert.Equal(t, StatusKO, histories["db"][0].Status)
+		assert.Equal(t, verbose, histories["db"][0].Error != "")
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"time"
+
+	"aahframe.work/log"
+)
+
+// checkLog struct holds the check result to be logged along with the
+// previous status of the reporter.
+type checkLog struct {
+	name   string
+	last   Status
+	result CheckResult
+}
+
+// logResults method logs the check results with fields, so that failures
+// are visible in the application logs:
+//
+//   - reporter turned unhealthy, at error level for critical reporters and
+//     warn level otherwise
+//   - reporter recovered, at info level
+//   - reporter failed again, at warn level
+//   - aggregate status changed, at warn level or info level once healthy
+//   - every other check at debug level, if enabled by `WithCheckLogging`
+//
+// Muted reporters and reporters within maintenance window are logged at
+// debug level only.
+func (c *Collector) logResults(checked []checkLog, oldStatus, newStatus AggregateStatus) {
+	logger := c.log()
+	if logger == nil {
+		return
+	}
+	c.mu.RLock()
+	logChecks := c.logChecks
+	c.mu.RUnlock()
+
+	for _, cl := range checked {
+		r := cl.result
+		fields := log.Fields{
+			"reporter":   cl.name,
+			"status":     r.Status,
+			"durationMs": float64(r.Duration) / float64(time.Millisecond),
+		}
+		if len(r.Severity) > 0 {
+			fields["severity"] = r.Severity
+		}
+		if !r.IsOK() {
+			fields["error"] = r.Error
+			fields["consecutiveFailures"] = r.ConsecutiveFailures
+		}
+		if cl.last != r.Status {
+			fields["previousStatus"] = cl.last
+		}
+
+		quiet := r.Skipped || r.Muted || r.Maintenance
+		switch {
+		case quiet || (r.IsOK() && cl.last == r.Status):
+			if logChecks {
+				logger.WithFields(fields).Debug("health: reporter checked")
+			}
+		case r.IsOK():
+			logger.WithFields(fields).Info("health: reporter is healthy again")
+		case cl.last != r.Status && r.severity() == SeverityCritical:
+			logger.WithFields(fields).Error("health: reporter is unhealthy")
+		case cl.last != r.Status:
+			logger.WithFields(fields).Warn("health: reporter is unhealthy")
+		default:
+			logger.WithFields(fields).Warn("health: reporter check failed")
+		}
+	}
+
+	if oldStatus != newStatus {
+		fields := log.Fields{"status": newStatus, "previousStatus": oldStatus}
+		if newStatus == Healthy {
+			logger.WithFields(fields).Info("health: aggregate status changed")
+		} else {
+			logger.WithFields(fields).Warn("health: aggregate status changed")
+		}
+	}
+}
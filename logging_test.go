@@ -0,0 +1,121 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"aahframe.work/log"
+	"github.com/stretchr/testify/assert"
+)
+
+type logEntry struct {
+	level  string
+	msg    string
+	fields log.Fields
+}
+
+// fieldLogger records the entries logged with fields.
+type fieldLogger struct {
+	log.Loggerer
+	mu      *sync.Mutex
+	entries *[]logEntry
+	fields  log.Fields
+}
+
+func newFieldLogger() *fieldLogger {
+	return &fieldLogger{mu: &sync.Mutex{}, entries: &[]logEntry{}}
+}
+
+func (l *fieldLogger) WithFields(fields log.Fields) log.Loggerer {
+	return &fieldLogger{mu: l.mu, entries: l.entries, fields: fields}
+}
+
+func (l *fieldLogger) record(level string, v []interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	*l.entries = append(*l.entries, logEntry{level: level, msg: v[0].(string), fields: l.fields})
+}
+
+func (l *fieldLogger) Error(v ...interface{}) { l.record("error", v) }
+func (l *fieldLogger) Warn(v ...interface{})  { l.record("warn", v) }
+func (l *fieldLogger) Info(v ...interface{})  { l.record("info", v) }
+func (l *fieldLogger) Debug(v ...interface{}) { l.record("debug", v) }
+
+func (l *fieldLogger) take() []logEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entries := *l.entries
+	*l.entries = nil
+	return entries
+}
+
+func TestHealthLogResults(t *testing.T) {
+	logger := newFieldLogger()
+	collector := newCollector()
+	collector.SetLogger(logger)
+	db, cache := &toggleReporter{}, &toggleReporter{}
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: db})
+	_ = collector.AddReporter(&Config{Name: "cache", Reporter: cache, SoftFail: true})
+
+	// healthy checks are not logged by default
+	collector.runChecks()
+	assert.Empty(t, logger.take())
+
+	db.set(errors.New("connection refused"))
+	collector.runChecks()
+	entries := logger.take()
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, "error", entries[0].level)
+		assert.Equal(t, "health: reporter is unhealthy", entries[0].msg)
+		assert.Equal(t, "db", entries[0].fields["reporter"])
+		assert.Equal(t, StatusKO, entries[0].fields["status"])
+		assert.Equal(t, StatusOK, entries[0].fields["previousStatus"])
+		assert.Equal(t, "connection refused", entries[0].fields["error"])
+		assert.Equal(t, SeverityCritical, entries[0].fields["severity"])
+		assert.Contains(t, entries[0].fields, "durationMs")
+
+		assert.Equal(t, "warn", entries[1].level)
+		assert.Equal(t, "health: aggregate status changed", entries[1].msg)
+		assert.Equal(t, log.Fields{"status": Unhealthy, "previousStatus": Healthy}, entries[1].fields)
+	}
+
+	cache.set(errors.New("timeout"))
+	collector.runChecks()
+	entries = logger.take()
+	if assert.Len(t, entries, 2) {
+		levels := map[string]string{}
+		for _, e := range entries {
+			levels[e.fields["reporter"].(string)] = e.level + " " + e.msg
+		}
+		assert.Equal(t, map[string]string{
+			"db":    "warn health: reporter check failed",
+			"cache": "warn health: reporter is unhealthy",
+		}, levels)
+	}
+
+	db.set(nil)
+	cache.set(nil)
+	WithCheckLogging(true)(collector)
+	collector.runChecks()
+	entries = logger.take()
+	if assert.Len(t, entries, 3) {
+		for _, e := range entries[:2] {
+			assert.Equal(t, "info", e.level)
+			assert.Equal(t, "health: reporter is healthy again", e.msg)
+		}
+		assert.Equal(t, "info", entries[2].level)
+		assert.Equal(t, Healthy, entries[2].fields["status"])
+	}
+
+	collector.runChecks()
+	entries = logger.take()
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, "debug", entries[0].level)
+		assert.Equal(t, "health: reporter checked", entries[0].msg)
+	}
+}
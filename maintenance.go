@@ -0,0 +1,192 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaintenanceWindow struct represents a planned maintenance period of the
+// reporter, refer to `Config.Maintenance`. Create it using
+// `MaintenanceBetween` or `MaintenanceCron`.
+type MaintenanceWindow struct {
+	start, end time.Time
+	schedule   *cronSchedule
+	duration   time.Duration
+}
+
+// MaintenanceBetween method returns a one-off maintenance window from start
+// until end time.
+func MaintenanceBetween(start, end time.Time) MaintenanceWindow {
+	return MaintenanceWindow{start: start, end: end}
+}
+
+// MaintenanceCron method returns a recurring maintenance window which starts
+// at the times matching the cron spec and lasts for given duration. Spec has
+// five fields `minute hour day-of-month month day-of-week` supporting `*`,
+// lists, ranges and steps, for e.g. `30 2 * * 0` is every Sunday at 02:30,
+// day-of-week 7 is Sunday too. Same as cron, when both day-of-month and
+// day-of-week are restricted, the day matching either of them is matched.
+// Spec is evaluated in the time zone of the check time.
+func MaintenanceCron(spec string, duration time.Duration) (MaintenanceWindow, error) {
+	if duration <= 0 {
+		return MaintenanceWindow{}, fmt.Errorf("health: maintenance duration must be positive")
+	}
+	schedule, err := parseCron(spec)
+	if err != nil {
+		return MaintenanceWindow{}, err
+	}
+	return MaintenanceWindow{schedule: schedule, duration: duration}, nil
+}
+
+// Active method returns true if given time is within the maintenance window.
+func (w MaintenanceWindow) Active(t time.Time) bool {
+	if w.schedule == nil {
+		return !t.Before(w.start) && t.Before(w.end)
+	}
+	start, found := w.schedule.prev(t, t.Add(-w.duration))
+	return found && t.Sub(start) < w.duration
+}
+
+func inMaintenance(windows []MaintenanceWindow, t time.Time) bool {
+	for _, w := range windows {
+		if w.Active(t) {
+			return true
+		}
+	}
+	return false
+}
+
+//‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Cron schedule
+//______________________________________________________________________________
+
+// cronSchedule struct holds the allowed values of each cron field, domAny
+// and dowAny are true if the day field is `*`.
+type cronSchedule struct {
+	minute, hour, dom, month, dow map[int]bool
+	domAny, dowAny                bool
+}
+
+// matchesDay method returns true if the day of given time matches, day is
+// matched by either of day-of-month and day-of-week if both are restricted.
+func (s *cronSchedule) matchesDay(t time.Time) bool {
+	if !s.month[int(t.Month())] {
+		return false
+	}
+	dom, dow := s.dom[t.Day()], s.dow[int(t.Weekday())]
+	if s.domAny || s.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// prev method returns the latest time at or before t matching the schedule,
+// it returns false if there is none after given limit. Days are looked back
+// first, then the hour and minute of the matching day.
+func (s *cronSchedule) prev(t, limit time.Time) (time.Time, bool) {
+	loc := t.Location()
+	limit = limit.In(loc)
+	first := time.Date(limit.Year(), limit.Month(), limit.Day(), 0, 0, 0, 0, loc)
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	for today := true; !day.Before(first); day, today = day.AddDate(0, 0, -1), false {
+		if !s.matchesDay(day) {
+			continue
+		}
+		hour := 23
+		if today {
+			hour = t.Hour()
+		}
+		for h := hour; h >= 0; h-- {
+			if !s.hour[h] {
+				continue
+			}
+			minute := 59
+			if today && h == t.Hour() {
+				minute = t.Minute()
+			}
+			for m := minute; m >= 0; m-- {
+				if !s.minute[m] {
+					continue
+				}
+				start := time.Date(day.Year(), day.Month(), day.Day(), h, m, 0, 0, loc)
+				if start.After(t) {
+					continue // shifted by daylight saving
+				}
+				return start, !start.Before(limit)
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+func parseCron(spec string) (*cronSchedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("health: invalid cron spec '%s', expected 5 fields", spec)
+	}
+	bounds := [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+	sets := make([]map[int]bool, 5)
+	for i, field := range fields {
+		set, err := parseCronField(field, bounds[i][0], bounds[i][1])
+		if err != nil {
+			return nil, fmt.Errorf("health: invalid cron spec '%s': %v", spec, err)
+		}
+		sets[i] = set
+	}
+	if sets[4][7] {
+		sets[4][0] = true // Sunday
+		delete(sets[4], 7)
+	}
+	return &cronSchedule{
+		minute: sets[0],
+		hour:   sets[1],
+		dom:    sets[2],
+		month:  sets[3],
+		dow:    sets[4],
+		domAny: strings.HasPrefix(fields[2], "*"),
+		dowAny: strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+// parseCronField parses comma separated list of `*`, `n`, `n-m` with
+// optional step `/s` within given bounds.
+func parseCronField(field string, min, max int) (map[int]bool, error) {
+	set := make(map[int]bool)
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			var err error
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step '%s'", part)
+			}
+			part = part[:i]
+		}
+		lo, hi := min, max
+		if part != "*" {
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			if lo, err = strconv.Atoi(bounds[0]); err != nil {
+				return nil, fmt.Errorf("invalid value '%s'", part)
+			}
+			hi = lo
+			if len(bounds) == 2 {
+				if hi, err = strconv.Atoi(bounds[1]); err != nil {
+					return nil, fmt.Errorf("invalid value '%s'", part)
+				}
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return nil, fmt.Errorf("value '%s' out of range %d-%d", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			set[v] = true
+		}
+	}
+	return set, nil
+}
@@ -0,0 +1,87 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthMaintenanceWindow(t *testing.T) {
+	start := time.Date(2019, 3, 3, 2, 30, 0, 0, time.UTC) // Sunday
+	once := MaintenanceBetween(start, start.Add(time.Hour))
+	assert.False(t, once.Active(start.Add(-time.Second)))
+	assert.True(t, once.Active(start))
+	assert.True(t, once.Active(start.Add(59*time.Minute)))
+	assert.False(t, once.Active(start.Add(time.Hour)))
+
+	weekly, err := MaintenanceCron("30 2 * * 0", time.Hour)
+	assert.Nil(t, err)
+	assert.False(t, weekly.Active(start.Add(-time.Minute)))
+	assert.True(t, weekly.Active(start))
+	assert.True(t, weekly.Active(start.Add(59*time.Minute+59*time.Second)))
+	assert.False(t, weekly.Active(start.Add(time.Hour)))
+	assert.False(t, weekly.Active(start.Add(24*time.Hour)))
+	assert.True(t, weekly.Active(start.Add(7*24*time.Hour)))
+
+	stepped, err := MaintenanceCron("*/15 1-3,22 * 1,6 *", time.Minute)
+	assert.Nil(t, err)
+	assert.True(t, stepped.Active(time.Date(2019, 6, 1, 22, 45, 30, 0, time.UTC)))
+	assert.False(t, stepped.Active(time.Date(2019, 6, 1, 22, 46, 0, 0, time.UTC)))
+	assert.False(t, stepped.Active(time.Date(2019, 7, 1, 1, 0, 0, 0, time.UTC)))
+
+	// 7 is Sunday, restricted day fields match either
+	sunday, err := MaintenanceCron("30 2 * * 7", time.Hour)
+	assert.Nil(t, err)
+	assert.True(t, sunday.Active(start.Add(time.Minute)))
+	either, err := MaintenanceCron("0 0 1 * 1", 2*time.Hour)
+	assert.Nil(t, err)
+	assert.True(t, either.Active(time.Date(2019, 3, 1, 1, 0, 0, 0, time.UTC)))  // Friday, 1st
+	assert.True(t, either.Active(time.Date(2019, 3, 4, 1, 59, 0, 0, time.UTC))) // Monday
+	assert.False(t, either.Active(time.Date(2019, 3, 5, 1, 0, 0, 0, time.UTC)))
+	monthly, err := MaintenanceCron("0 0 1 * *", 36*time.Hour)
+	assert.Nil(t, err)
+	assert.True(t, monthly.Active(time.Date(2019, 3, 2, 11, 59, 0, 0, time.UTC)))
+	assert.False(t, monthly.Active(time.Date(2019, 3, 2, 12, 0, 0, 0, time.UTC)))
+
+	// long window is looked back directly
+	yearly, err := MaintenanceCron("0 0 1 1 *", 360*24*time.Hour)
+	assert.Nil(t, err)
+	assert.True(t, yearly.Active(time.Date(2019, 12, 25, 23, 59, 0, 0, time.UTC)))
+	assert.False(t, yearly.Active(time.Date(2019, 12, 31, 0, 0, 0, 0, time.UTC)))
+
+	for _, spec := range []string{"* * * *", "60 * * * *", "*/0 * * * *", "a * * * *", "5-1 * * * *", "* * * * 8"} {
+		_, err := MaintenanceCron(spec, time.Hour)
+		assert.NotNil(t, err, spec)
+	}
+	_, err = MaintenanceCron("* * * * *", 0)
+	assert.NotNil(t, err)
+}
+
+func TestHealthMaintenance(t *testing.T) {
+	collector := newCollector()
+	defer collector.Stop()
+	notifier := &recordNotifier{}
+	collector.AddNotifier(notifier, OnlyReporters("db"))
+
+	now := time.Now()
+	_ = collector.AddReporter(&Config{
+		Name:        "db",
+		Reporter:    &toggleReporter{err: errors.New("failover")},
+		Maintenance: []MaintenanceWindow{MaintenanceBetween(now.Add(-time.Minute), now.Add(time.Hour))},
+	})
+	collector.runChecks()
+
+	result := collector.Results()["db"]
+	assert.Equal(t, StatusKO, result.Status)
+	assert.True(t, result.Maintenance)
+	assert.True(t, collector.IsHealthy())
+	assert.Equal(t, Healthy, collector.Status())
+	time.Sleep(10 * time.Millisecond)
+	assert.Empty(t, notifier.recorded())
+}
@@ -0,0 +1,144 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"bufio"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// MetricsContentType is the Prometheus text exposition format content type.
+const MetricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// durationBuckets are the upper bounds (in seconds) of check duration histogram.
+var durationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// WriteMetrics method writes the collector health state in Prometheus text
+// exposition format into given writer. It can be used to integrate with
+// any Prometheus scrape endpoint.
+//
+// Exposed metrics:
+//
+//	health_up                         - global health status (gauge)
+//	health_degraded                   - global degraded status (gauge)
+//	health_score                      - weighted health score (gauge)
+//	health_check_up                   - per reporter health status (gauge)
+//	health_check_duration_seconds     - per reporter check duration (histogram)
+//	health_check_availability         - per reporter rolling availability percent (gauge)
+//	health_check_cycles_skipped_total - check cycles skipped while the previous one was running (counter)
+//	health_notify_dropped_total       - notification events dropped due to the full queue (counter)
+func (c *Collector) WriteMetrics(w io.Writer) error {
+	state := c.load()
+	now := c.clock.Now()
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	names := make([]string, 0, len(state.results))
+	for name := range state.results {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString("# HELP health_up Global health status, 1 is healthy and 0 is unhealthy.\n")
+	bw.WriteString("# TYPE health_up gauge\n")
+	bw.WriteString("health_up " + boolToMetric(state.healthy) + "\n")
+
+	bw.WriteString("# HELP health_degraded Global degraded status, 1 if only soft fail reporters are unhealthy.\n")
+	bw.WriteString("# TYPE health_degraded gauge\n")
+	bw.WriteString("health_degraded " + boolToMetric(state.status == Degraded) + "\n")
+
+	bw.WriteString("# HELP health_score Weighted health score from 0 to 100.\n")
+	bw.WriteString("# TYPE health_score gauge\n")
+	bw.WriteString("health_score " + formatFloat(state.score) + "\n")
+
+	bw.WriteString("# HELP health_check_up Reporter health status, 1 is healthy and 0 is unhealthy.\n")
+	bw.WriteString("# TYPE health_check_up gauge\n")
+	for _, name := range names {
+		result := state.results[name]
+		bw.WriteString("health_check_up{name=\"" + labelValueReplacer.Replace(name) +
+			"\",soft_fail=\"" + strconv.FormatBool(result.SoftFail) + "\"} " +
+			boolToMetric(result.IsOK()) + "\n")
+	}
+
+	bw.WriteString("# HELP health_check_duration_seconds Reporter health check duration in seconds.\n")
+	bw.WriteString("# TYPE health_check_duration_seconds histogram\n")
+	for _, name := range names {
+		h, found := c.durations[name]
+		if !found {
+			continue
+		}
+		label := "name=\"" + labelValueReplacer.Replace(name) + "\""
+		for i, le := range durationBuckets {
+			bw.WriteString("health_check_duration_seconds_bucket{" + label + ",le=\"" +
+				formatFloat(le) + "\"} " + strconv.FormatUint(h.counts[i], 10) + "\n")
+		}
+		bw.WriteString("health_check_duration_seconds_bucket{" + label + ",le=\"+Inf\"} " +
+			strconv.FormatUint(h.count, 10) + "\n")
+		bw.WriteString("health_check_duration_seconds_sum{" + label + "} " + formatFloat(h.sum) + "\n")
+		bw.WriteString("health_check_duration_seconds_count{" + label + "} " +
+			strconv.FormatUint(h.count, 10) + "\n")
+	}
+
+	bw.WriteString("# HELP health_check_availability Reporter rolling availability in percent.\n")
+	bw.WriteString("# TYPE health_check_availability gauge\n")
+	for _, name := range names {
+		r, found := c.availability[name]
+		if !found {
+			continue
+		}
+		a := r.availability(now)
+		label := "name=\"" + labelValueReplacer.Replace(name) + "\""
+		bw.WriteString("health_check_availability{" + label + ",window=\"1h\"} " + formatFloat(a.LastHour) + "\n")
+		bw.WriteString("health_check_availability{" + label + ",window=\"24h\"} " + formatFloat(a.LastDay) + "\n")
+	}
+
+	bw.WriteString("# HELP health_check_cycles_skipped_total Check cycles skipped while the previous one was still running.\n")
+	bw.WriteString("# TYPE health_check_cycles_skipped_total counter\n")
+	bw.WriteString("health_check_cycles_skipped_total " + strconv.FormatUint(atomic.LoadUint64(&c.skipped), 10) + "\n")
+
+	bw.WriteString("# HELP health_notify_dropped_total Notification events dropped since the notifier's queue was full.\n")
+	bw.WriteString("# TYPE health_notify_dropped_total counter\n")
+	bw.WriteString("health_notify_dropped_total " + strconv.FormatUint(atomic.LoadUint64(&c.dropped), 10) + "\n")
+	return bw.Flush()
+}
+
+// histogram struct holds cumulative bucket counts of check durations.
+type histogram struct {
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+func newHistogram() *histogram {
+	return &histogram{counts: make([]uint64, len(durationBuckets))}
+}
+
+func (h *histogram) observe(v float64) {
+	for i, le := range durationBuckets {
+		if v <= le {
+			h.counts[i]++
+		}
+	}
+	h.sum += v
+	h.count++
+}
+
+func boolToMetric(v bool) string {
+	if v {
+		return "1"
+	}
+	return "0"
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
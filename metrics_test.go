@@ -0,0 +1,51 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthWriteMetrics(t *testing.T) {
+	collector := newCollector()
+	_ = collector.AddReporter(&Config{
+		Name:     "cache",
+		Reporter: &ctxReporter{delay: time.Millisecond},
+	})
+	_ = collector.AddReporter(&Config{
+		Name:     `db "primary"`,
+		Reporter: &ctxReporter{delay: time.Minute},
+		Timeout:  10 * time.Millisecond,
+	})
+	collector.runChecks()
+
+	buf := new(bytes.Buffer)
+	err := collector.WriteMetrics(buf)
+	assert.Nil(t, err)
+
+	metrics := buf.String()
+	assert.Contains(t, metrics, "# TYPE health_up gauge\nhealth_up 0\n")
+	assert.Contains(t, metrics, `health_check_up{name="cache",soft_fail="false"} 1`)
+	assert.Contains(t, metrics, `health_check_up{name="db \"primary\"",soft_fail="false"} 0`)
+	assert.Contains(t, metrics, "# TYPE health_check_duration_seconds histogram")
+	assert.Contains(t, metrics, `health_check_duration_seconds_bucket{name="cache",le="+Inf"} 1`)
+	assert.Contains(t, metrics, `health_check_duration_seconds_bucket{name="db \"primary\"",le="0.005"} 0`)
+	assert.Contains(t, metrics, `health_check_duration_seconds_count{name="cache"} 1`)
+}
+
+func TestHealthHistogram(t *testing.T) {
+	h := newHistogram()
+	h.observe(0.003)
+	h.observe(0.2)
+	h.observe(20)
+	assert.Equal(t, uint64(1), h.counts[0])
+	assert.Equal(t, uint64(2), h.counts[len(durationBuckets)-1])
+	assert.Equal(t, uint64(3), h.count)
+	assert.InDelta(t, 20.203, h.sum, 0.0001)
+}
@@ -0,0 +1,54 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import "fmt"
+
+// Mute method silences the reporter for given name, so that a known broken,
+// non-critical dependency does not flip the global health. Muted reporter
+// is still checked and reported with `CheckResult.Muted`, status change
+// notifications are skipped.
+func (c *Collector) Mute(name string) error {
+	return c.setMuted(name, true)
+}
+
+// Unmute method reverts the `Collector.Mute` of the reporter.
+func (c *Collector) Unmute(name string) error {
+	return c.setMuted(name, false)
+}
+
+// IsMuted method returns true if the reporter for given name is muted.
+func (c *Collector) IsMuted(name string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.muted[name]
+}
+
+func (c *Collector) setMuted(name string, muted bool) error {
+	c.mu.Lock()
+	if _, exists := c.reporters[name]; !exists {
+		c.mu.Unlock()
+		return fmt.Errorf("health: reporter name '%s' does not exist", name)
+	}
+	if muted {
+		c.muted[name] = true
+	} else {
+		delete(c.muted, name)
+	}
+
+	// global health reflects the mute right away
+	results := c.load().without()
+	if result, found := results[name]; found {
+		result.Muted = muted
+		results[name] = result
+	}
+	wasHealthy, healthy := c.publish(results)
+	c.mu.Unlock()
+
+	if wasHealthy != healthy {
+		c.fireHealthChange(healthy)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthMute(t *testing.T) {
+	collector := newCollector()
+	var healthChanges []bool
+	collector.OnHealthChange(func(healthy bool) { healthChanges = append(healthChanges, healthy) })
+	_ = collector.AddReporter(&Config{Name: "search", Reporter: &toggleReporter{err: errors.New("down")}})
+	collector.runChecks()
+	assert.False(t, collector.IsHealthy())
+
+	assert.NotNil(t, collector.Mute("unknown"))
+	assert.Nil(t, collector.Mute("search"))
+	assert.True(t, collector.IsMuted("search"))
+	assert.True(t, collector.IsHealthy())
+	assert.True(t, collector.Results()["search"].Muted)
+
+	// muted reporter is still checked
+	collector.runChecks()
+	result := collector.Results()["search"]
+	assert.Equal(t, StatusKO, result.Status)
+	assert.True(t, result.Muted)
+	assert.True(t, collector.IsHealthy())
+
+	assert.Nil(t, collector.Unmute("search"))
+	assert.False(t, collector.IsMuted("search"))
+	assert.False(t, collector.IsHealthy())
+	assert.Equal(t, []bool{false, true, false}, healthChanges)
+}
@@ -0,0 +1,136 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+// PagerDutyEventsURL is the PagerDuty Events API v2 endpoint.
+const PagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+var _ Notifier = (*PagerDutyNotifier)(nil)
+
+// PagerDutyOptions struct holds the configuration of `PagerDutyNotifier`.
+type PagerDutyOptions struct {
+	// RoutingKey is the integration key of PagerDuty service, it is required.
+	RoutingKey string
+
+	// Source of the incident, default is the hostname.
+	Source string
+
+	// DedupKeyPrefix is prepended to compose the incident dedup key,
+	// `reporter:<name>` of the reporter and `aggregate` of the global health,
+	// default is `health/<source>/`.
+	DedupKeyPrefix string
+
+	// URL of the Events API, default is `PagerDutyEventsURL`.
+	URL string
+
+	// Timeout of the HTTP request, default is 5 seconds.
+	Timeout time.Duration
+}
+
+// PagerDutyNotifier struct triggers the PagerDuty incident when a reporter
+// or the global health fails and resolves it on recovery. Incidents are
+// keyed by reporter name, so a flapping reporter does not open duplicate
+// incidents. Incident severity is the reporter's `Severity`, it is raised to
+// critical once the failure is escalated, refer to `NotifyPolicy`. Flapping
+// event is skipped, the reporter's incident is driven by its status
+// transitions.
+type PagerDutyNotifier struct {
+	opts   PagerDutyOptions
+	client *http.Client
+}
+
+// pagerDutyEvent struct represents the Events API v2 payload.
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string             `json:"summary"`
+	Source        string             `json:"source"`
+	Severity      string             `json:"severity"`
+	Timestamp     string             `json:"timestamp"`
+	Component     string             `json:"component,omitempty"`
+	CustomDetails *StatusChangeEvent `json:"custom_details"`
+}
+
+// NewPagerDutyNotifier method returns a `PagerDutyNotifier` instance for
+// given options.
+func NewPagerDutyNotifier(opts PagerDutyOptions) *PagerDutyNotifier {
+	if opts.Source == "" {
+		opts.Source, _ = os.Hostname()
+	}
+	if opts.DedupKeyPrefix == "" {
+		opts.DedupKeyPrefix = "health/" + opts.Source + "/"
+	}
+	if opts.URL == "" {
+		opts.URL = PagerDutyEventsURL
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+	return &PagerDutyNotifier{
+		opts:   opts,
+		client: &http.Client{Timeout: opts.Timeout},
+	}
+}
+
+// Notify method triggers or resolves the PagerDuty incident of the event.
+func (p *PagerDutyNotifier) Notify(e StatusChangeEvent) error {
+	if e.NewStatus == EventFlapping {
+		return nil
+	}
+	name, key := e.Reporter, "reporter:"+e.Reporter
+	if e.IsGlobal() {
+		name, key = "global", "aggregate"
+	}
+	pe := &pagerDutyEvent{
+		RoutingKey:  p.opts.RoutingKey,
+		EventAction: "resolve",
+		DedupKey:    p.opts.DedupKeyPrefix + key,
+	}
+	if !e.IsRecovery() {
+		severity := string(SeverityCritical)
+		switch {
+		case e.Severity != "":
+			severity = string(e.Severity)
+		case e.SoftFail:
+			severity = string(SeverityWarning)
+		}
+		summary := fmt.Sprintf("%s health check is %s", name, e.NewStatus)
+		if e.Escalated {
+			severity = string(SeverityCritical)
+			summary += " for " + e.Outage.String()
+		}
+		if e.Error != "" {
+			summary += ": " + e.Error
+		}
+		pe.EventAction = "trigger"
+		pe.Payload = &pagerDutyPayload{
+			Summary:       summary,
+			Source:        p.opts.Source,
+			Severity:      severity,
+			Timestamp:     e.Time.UTC().Format(time.RFC3339),
+			Component:     e.Reporter,
+			CustomDetails: &e,
+		}
+	}
+	body, err := json.Marshal(pe)
+	if err != nil {
+		return err
+	}
+	return postJSON(context.Background(), p.client, p.opts.URL, nil, body)
+}
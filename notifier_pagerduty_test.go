@@ -0,0 +1,48 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthPagerDutyNotifier(t *testing.T) {
+	var received []pagerDutyEvent
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var pe pagerDutyEvent
+		_ = json.NewDecoder(r.Body).Decode(&pe)
+		received = append(received, pe)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ts.Close()
+
+	n := NewPagerDutyNotifier(PagerDutyOptions{RoutingKey: "key", Source: "app-1", URL: ts.URL})
+	now := time.Now()
+	assert.Nil(t, n.Notify(StatusChangeEvent{Reporter: "cache", OldStatus: "OK", NewStatus: "KO", Error: "timeout", SoftFail: true, Time: now}))
+	assert.Nil(t, n.Notify(StatusChangeEvent{Reporter: "cache", OldStatus: "KO", NewStatus: "OK", Time: now}))
+	assert.Nil(t, n.Notify(StatusChangeEvent{OldStatus: GlobalHealthy, NewStatus: GlobalUnhealthy, Time: now}))
+	assert.Nil(t, n.Notify(StatusChangeEvent{Reporter: "global", OldStatus: "OK", NewStatus: "KO", Time: now}))
+	assert.Nil(t, n.Notify(StatusChangeEvent{Reporter: "cache", OldStatus: "OK", NewStatus: EventFlapping, Time: now}))
+
+	// flapping is skipped
+	assert.Len(t, received, 4)
+	assert.Equal(t, "trigger", received[0].EventAction)
+	assert.Equal(t, "health/app-1/reporter:cache", received[0].DedupKey)
+	assert.Equal(t, "key", received[0].RoutingKey)
+	assert.Equal(t, "warning", received[0].Payload.Severity)
+	assert.Equal(t, "cache health check is KO: timeout", received[0].Payload.Summary)
+	assert.Equal(t, "resolve", received[1].EventAction)
+	assert.Equal(t, received[0].DedupKey, received[1].DedupKey)
+	assert.Nil(t, received[1].Payload)
+	assert.Equal(t, "health/app-1/aggregate", received[2].DedupKey)
+	assert.Equal(t, "critical", received[2].Payload.Severity)
+	assert.Equal(t, "health/app-1/reporter:global", received[3].DedupKey)
+}
@@ -0,0 +1,140 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// DefaultSlackTemplate is the default message template of `SlackNotifier`,
+// template is executed with `StatusChangeEvent`.
+const DefaultSlackTemplate = `{{if .IsRecovery}}:white_check_mark:{{else if .Escalated}}:fire:{{else}}:rotating_light:{{end}} ` +
+	`{{if .IsGlobal}}Application health{{else}}Reporter *{{.Reporter}}*{{end}} ` +
+	`{{if .IsReminder}}is still {{.NewStatus}} for {{.Outage}}{{else}}changed from {{.OldStatus}} to {{.NewStatus}}{{end}}` +
+	`{{with .Error}}: {{.}}{{end}}`
+
+var _ Notifier = (*SlackNotifier)(nil)
+
+// SlackOptions struct holds the configuration of `SlackNotifier`.
+type SlackOptions struct {
+	// WebhookURL is the Slack incoming webhook URL, it is required.
+	WebhookURL string
+
+	// Channel and Username override the defaults of the incoming webhook.
+	Channel  string
+	Username string
+
+	// Template of the message, default is `DefaultSlackTemplate`.
+	Template string
+
+	// Throttle suppresses the repeated failure notifications of a reporter
+	// within the given period, so that a flapping dependency does not flood
+	// the channel. Recovery is announced only for the announced failure.
+	// Default is 0, that is disabled; prefer `WithNotifyPolicy`, which
+	// throttles all the notifiers of the collector alike.
+	Throttle time.Duration
+
+	// Timeout of the HTTP request, default is 5 seconds.
+	Timeout time.Duration
+}
+
+// SlackNotifier struct announces the dependency failures and recoveries
+// into Slack channel via incoming webhook.
+type SlackNotifier struct {
+	opts      SlackOptions
+	tmpl      *template.Template
+	client    *http.Client
+	mu        sync.Mutex
+	announced map[string]time.Time // reporter name -> failure announced at
+	outage    map[string]bool
+}
+
+// slackMessage struct represents the Slack incoming webhook payload.
+type slackMessage struct {
+	Text     string `json:"text"`
+	Channel  string `json:"channel,omitempty"`
+	Username string `json:"username,omitempty"`
+}
+
+// NewSlackNotifier method returns a `SlackNotifier` instance for given
+// options, it returns an error if the template is invalid.
+func NewSlackNotifier(opts SlackOptions) (*SlackNotifier, error) {
+	if opts.Template == "" {
+		opts.Template = DefaultSlackTemplate
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+	tmpl, err := template.New("slack").Parse(opts.Template)
+	if err != nil {
+		return nil, err
+	}
+	return &SlackNotifier{
+		opts:      opts,
+		tmpl:      tmpl,
+		client:    &http.Client{Timeout: opts.Timeout},
+		announced: make(map[string]time.Time),
+		outage:    make(map[string]bool),
+	}, nil
+}
+
+// Notify method posts the event message into Slack unless it is throttled.
+func (s *SlackNotifier) Notify(e StatusChangeEvent) error {
+	if !s.allow(e) {
+		return nil
+	}
+	buf := new(bytes.Buffer)
+	if err := s.tmpl.Execute(buf, e); err != nil {
+		return err
+	}
+	body, err := json.Marshal(&slackMessage{
+		Text:     buf.String(),
+		Channel:  s.opts.Channel,
+		Username: s.opts.Username,
+	})
+	if err != nil {
+		return err
+	}
+	return postJSON(context.Background(), s.client, s.opts.WebhookURL, nil, body)
+}
+
+// allow method returns true if the event has to be announced as per the
+// throttle, it records the announcement.
+func (s *SlackNotifier) allow(e StatusChangeEvent) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e.NewStatus == EventFlapping {
+		// flapping is announced, but it is not an outage of the reporter
+		return true
+	}
+	if e.IsRecovery() {
+		if !s.outage[e.Reporter] {
+			return false
+		}
+		delete(s.outage, e.Reporter)
+		return true
+	}
+	if e.IsReminder() {
+		// repeat of the ongoing failure is throttled by the collector
+		s.announced[e.Reporter] = e.Time
+		s.outage[e.Reporter] = true
+		return true
+	}
+	if s.outage[e.Reporter] {
+		return false
+	}
+	if last, found := s.announced[e.Reporter]; found && s.opts.Throttle > 0 && e.Time.Sub(last) < s.opts.Throttle {
+		return false
+	}
+	s.announced[e.Reporter] = e.Time
+	s.outage[e.Reporter] = true
+	return true
+}
@@ -0,0 +1,74 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthSlackNotifier(t *testing.T) {
+	var mu sync.Mutex
+	var messages []slackMessage
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg slackMessage
+		_ = json.NewDecoder(r.Body).Decode(&msg)
+		mu.Lock()
+		messages = append(messages, msg)
+		mu.Unlock()
+	}))
+	defer ts.Close()
+
+	_, err := NewSlackNotifier(SlackOptions{WebhookURL: ts.URL, Template: "{{.Unknown"})
+	assert.NotNil(t, err)
+
+	n, err := NewSlackNotifier(SlackOptions{WebhookURL: ts.URL, Channel: "#ops", Throttle: time.Hour})
+	assert.Nil(t, err)
+
+	now := time.Now()
+	events := []StatusChangeEvent{
+		{Reporter: "db", OldStatus: "OK", NewStatus: "KO", Error: "connection refused", Time: now},
+		{Reporter: "db", OldStatus: "KO", NewStatus: "OK", Time: now.Add(time.Minute)},
+		{Reporter: "db", OldStatus: "OK", NewStatus: "KO", Time: now.Add(2 * time.Minute)}, // throttled
+		{Reporter: "db", OldStatus: "KO", NewStatus: "OK", Time: now.Add(3 * time.Minute)}, // not announced
+		{OldStatus: GlobalHealthy, NewStatus: GlobalUnhealthy, Time: now.Add(4 * time.Minute)},
+		{Reporter: "db", OldStatus: "OK", NewStatus: "KO", Time: now.Add(2 * time.Hour)},
+		{Reporter: "db", OldStatus: "KO", NewStatus: "KO", Error: "timeout", Time: now.Add(3 * time.Hour), Outage: time.Hour, Repeat: true, Escalated: true},
+	}
+	for _, e := range events {
+		assert.Nil(t, n.Notify(e))
+	}
+
+	assert.Len(t, messages, 5)
+	assert.Equal(t, "#ops", messages[0].Channel)
+	assert.Equal(t, ":rotating_light: Reporter *db* changed from OK to KO: connection refused", messages[0].Text)
+	assert.Equal(t, ":white_check_mark: Reporter *db* changed from KO to OK", messages[1].Text)
+	assert.Equal(t, ":rotating_light: Application health changed from healthy to unhealthy", messages[2].Text)
+	assert.Contains(t, messages[3].Text, "Reporter *db*")
+	assert.Equal(t, ":fire: Reporter *db* is still KO for 1h0m0s: timeout", messages[4].Text)
+
+	// flapping does not hold back the next failure
+	messages = nil
+	n, _ = NewSlackNotifier(SlackOptions{WebhookURL: ts.URL})
+	assert.Nil(t, n.Notify(StatusChangeEvent{Reporter: "cache", OldStatus: "OK", NewStatus: EventFlapping, Time: now}))
+	assert.Nil(t, n.Notify(StatusChangeEvent{Reporter: "cache", OldStatus: "OK", NewStatus: "KO", Error: "timeout", Time: now}))
+	assert.Len(t, messages, 2)
+	assert.Equal(t, ":rotating_light: Reporter *cache* changed from OK to FLAPPING", messages[0].Text)
+	assert.Equal(t, ":rotating_light: Reporter *cache* changed from OK to KO: timeout", messages[1].Text)
+
+	// not throttled by default
+	messages = nil
+	n, _ = NewSlackNotifier(SlackOptions{WebhookURL: ts.URL})
+	for _, e := range events[:4] {
+		assert.Nil(t, n.Notify(e))
+	}
+	assert.Len(t, messages, 4)
+}
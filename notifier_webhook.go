@@ -0,0 +1,171 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	_ Notifier        = (*WebhookNotifier)(nil)
+	_ NotifierContext = (*WebhookNotifier)(nil)
+)
+
+// WebhookOptions struct holds the configuration of `WebhookNotifier`.
+type WebhookOptions struct {
+	// URL to POST the `StatusChangeEvent` as JSON payload, it is required.
+	URL string
+
+	// Header values added to the HTTP request, for e.g. `Authorization`.
+	Header http.Header
+
+	// Timeout of the HTTP request, default is 5 seconds.
+	Timeout time.Duration
+
+	// Retries is the number of times the failed delivery is retried,
+	// default is 3, negative value disables it. RetryBackoff is the delay
+	// before the first retry, default is 1 second, it doubles on every
+	// subsequent retry.
+	Retries      int
+	RetryBackoff time.Duration
+
+	// Clock of the retry backoff, default is the system clock.
+	Clock Clock
+}
+
+// WebhookNotifier struct POSTs the status change events to the webhook URL.
+// Each delivery carries the header `X-Health-Event-ID` so that the receiver
+// can de-duplicate the retried deliveries. Event which repeats the last
+// delivered status of the reporter is skipped, unless it is a reminder of
+// the ongoing failure, refer to `NotifyPolicy`.
+type WebhookNotifier struct {
+	opts   WebhookOptions
+	client *http.Client
+	mu     sync.Mutex
+	last   map[string]string
+}
+
+// NewWebhookNotifier method returns a `WebhookNotifier` instance for given
+// options.
+func NewWebhookNotifier(opts WebhookOptions) *WebhookNotifier {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+	if opts.Retries == 0 {
+		opts.Retries = 3
+	}
+	if opts.RetryBackoff <= 0 {
+		opts.RetryBackoff = time.Second
+	}
+	if opts.Clock == nil {
+		opts.Clock = realClock{}
+	}
+	return &WebhookNotifier{
+		opts:   opts,
+		client: &http.Client{Timeout: opts.Timeout},
+		last:   make(map[string]string),
+	}
+}
+
+// Notify method delivers the event to the webhook URL with retries.
+func (w *WebhookNotifier) Notify(e StatusChangeEvent) error {
+	return w.NotifyContext(context.Background(), e)
+}
+
+// NotifyContext method delivers the event to the webhook URL with retries,
+// the delivery is abandoned once given context is done.
+func (w *WebhookNotifier) NotifyContext(ctx context.Context, e StatusChangeEvent) error {
+	w.mu.Lock()
+	if !e.IsReminder() && w.last[e.Reporter] == e.NewStatus {
+		w.mu.Unlock()
+		return nil
+	}
+	w.mu.Unlock()
+
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	id := eventID(e)
+	backoff := w.opts.RetryBackoff
+	for i := 0; ; i++ {
+		if err = w.post(ctx, id, body); err == nil {
+			break
+		}
+		if i >= w.opts.Retries {
+			return err
+		}
+		if err = w.wait(ctx, backoff); err != nil {
+			return err
+		}
+		backoff *= 2
+	}
+
+	w.mu.Lock()
+	w.last[e.Reporter] = e.NewStatus
+	w.mu.Unlock()
+	return nil
+}
+
+// wait method waits for given backoff, it returns the context error if the
+// context is done first.
+func (w *WebhookNotifier) wait(ctx context.Context, backoff time.Duration) error {
+	t := w.opts.Clock.NewTimer(backoff)
+	defer t.Stop()
+	select {
+	case <-t.C():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w *WebhookNotifier) post(ctx context.Context, id string, body []byte) error {
+	header := make(http.Header, len(w.opts.Header)+1)
+	for k, v := range w.opts.Header {
+		header[k] = v
+	}
+	header.Set("X-Health-Event-ID", id)
+	return postJSON(ctx, w.client, w.opts.URL, header, body)
+}
+
+// postJSON POSTs given JSON body to the URL, it returns an error if the
+// response status is not `2xx`.
+func postJSON(ctx context.Context, client *http.Client, url string, header http.Header, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxHTTPBodySize))
+	_ = resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("health: '%s' responded with status %d", url, resp.StatusCode)
+	}
+	return nil
+}
+
+// eventID returns the stable identifier of the event.
+func eventID(e StatusChangeEvent) string {
+	sum := sha1.Sum([]byte(e.Reporter + "|" + e.NewStatus + "|" + e.Time.UTC().Format(time.RFC3339Nano)))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,81 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthWebhookNotifier(t *testing.T) {
+	var calls int32
+	var received StatusChangeEvent
+	var gotID, token string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		gotID, token = r.Header.Get("X-Health-Event-ID"), r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&received)
+	}))
+	defer ts.Close()
+
+	n := NewWebhookNotifier(WebhookOptions{
+		URL:          ts.URL,
+		Header:       http.Header{"Authorization": {"Bearer secret"}},
+		RetryBackoff: time.Millisecond,
+	})
+	e := StatusChangeEvent{Reporter: "db", OldStatus: "OK", NewStatus: "KO", Error: "down", Time: time.Now()}
+	assert.Nil(t, n.Notify(e))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	assert.Equal(t, "db", received.Reporter)
+	assert.Equal(t, "down", received.Error)
+	assert.Equal(t, eventID(e), gotID)
+	assert.Equal(t, "Bearer secret", token)
+
+	// repeated status is de-duplicated
+	assert.Nil(t, n.Notify(e))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+	failing := NewWebhookNotifier(WebhookOptions{URL: closed.URL, Retries: -1})
+	assert.NotNil(t, failing.Notify(e))
+}
+
+func TestHealthWebhookNotifierCancel(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	n := NewWebhookNotifier(WebhookOptions{URL: ts.URL, RetryBackoff: time.Hour})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- n.NotifyContext(ctx, StatusChangeEvent{Reporter: "db", NewStatus: "KO", Time: time.Now()})
+	}()
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&calls) == 1 }, time.Second, time.Millisecond)
+
+	// retry backoff is abandoned once cancelled
+	cancel()
+	select {
+	case err := <-done:
+		assert.Equal(t, context.Canceled, err)
+	case <-time.After(time.Second):
+		t.Fatal("retry backoff is not cancelled")
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
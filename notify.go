@@ -0,0 +1,240 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// Status values of `StatusChangeEvent` for the collector's global health.
+const (
+	GlobalHealthy   = "healthy"
+	GlobalUnhealthy = "unhealthy"
+)
+
+// StatusChangeEvent struct describes a reporter's status transition or the
+// collector's global health transition.
+type StatusChangeEvent struct {
+	// Reporter is the name of the reporter, it is empty for the global
+	// health transition.
+	Reporter string `json:"reporter,omitempty"`
+
+	// OldStatus and NewStatus are `OK` or `KO` for the reporter and
+	// `healthy` or `unhealthy` for the global health.
+	OldStatus string `json:"oldStatus"`
+	NewStatus string `json:"newStatus"`
+
+	// Error is the reporter's latest check error, if any.
+	Error     string    `json:"error,omitempty"`
+	ErrorType ErrorType `json:"errorType,omitempty"`
+	SoftFail  bool      `json:"softFail,omitempty"`
+	Severity  Severity  `json:"severity,omitempty"`
+	Time      time.Time `json:"time"`
+
+	// Outage is the failure duration of the reporter on repeat and recovery
+	// events, refer to `NotifyPolicy`.
+	Outage time.Duration `json:"outage,omitempty"`
+
+	// Repeat is true if the event repeats the reporter's ongoing failure and
+	// Escalated is true once the failure lasts `NotifyPolicy.EscalateAfter`.
+	Repeat    bool `json:"repeat,omitempty"`
+	Escalated bool `json:"escalated,omitempty"`
+}
+
+// IsGlobal method returns true if the event is the collector's global
+// health transition.
+func (e StatusChangeEvent) IsGlobal() bool {
+	return e.Reporter == ""
+}
+
+// IsRecovery method returns true if the transition is a recovery from
+// failure.
+func (e StatusChangeEvent) IsRecovery() bool {
+	return e.NewStatus == string(StatusOK) || e.NewStatus == GlobalHealthy
+}
+
+// IsReminder method returns true if the event repeats the ongoing failure
+// rather than a transition.
+func (e StatusChangeEvent) IsReminder() bool {
+	return e.Repeat || e.Escalated
+}
+
+// Notifier interface for the destination of status change notifications,
+// for e.g. webhook, Slack, PagerDuty. Implement it to plug in a custom
+// destination and register it with `Collector.AddNotifier`.
+type Notifier interface {
+	// Notify delivers the event, returned error is logged by the collector.
+	Notify(event StatusChangeEvent) error
+}
+
+// NotifierContext interface for the notifier that delivers the event with
+// a context. Collector prefers it over `Notifier.Notify` when implemented,
+// the context gets cancelled once the collector is stopped.
+type NotifierContext interface {
+	// NotifyContext delivers the event, it should return promptly when
+	// given context is done.
+	NotifyContext(ctx context.Context, event StatusChangeEvent) error
+}
+
+// NotifyFilter func type reports whether the event has to be delivered to
+// the notifier, refer to `Collector.AddNotifier`.
+type NotifyFilter func(e StatusChangeEvent) bool
+
+// OnlyGlobal filter allows only the collector's global health transitions.
+func OnlyGlobal() NotifyFilter {
+	return func(e StatusChangeEvent) bool {
+		return e.IsGlobal()
+	}
+}
+
+// OnlyHardFailures filter allows only the transitions of critical
+// reporters and the global health.
+func OnlyHardFailures() NotifyFilter {
+	return func(e StatusChangeEvent) bool {
+		return !e.SoftFail
+	}
+}
+
+// OnlyReporters filter allows only the transitions of given reporters.
+func OnlyReporters(names ...string) NotifyFilter {
+	return func(e StatusChangeEvent) bool {
+		for _, name := range names {
+			if e.Reporter == name {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// notifierEntry struct holds the registered notifier along with its
+// filters and event queue.
+type notifierEntry struct {
+	notifier Notifier
+	filters  []NotifyFilter
+	events   chan StatusChangeEvent
+}
+
+// AddNotifier method registers a notifier which is notified on reporter's
+// status transitions and the global health transitions that pass all the
+// given filters, for e.g.:
+//
+//	collector.AddNotifier(slack, health.OnlyHardFailures())
+//
+// Each notifier is dispatched asynchronously in its own goroutine in the
+// order of occurrence, so a slow notifier does not delay the others.
+func (c *Collector) AddNotifier(n Notifier, filters ...NotifyFilter) {
+	ne := &notifierEntry{
+		notifier: n,
+		filters:  filters,
+		events:   make(chan StatusChangeEvent, 64),
+	}
+	c.mu.Lock()
+	first := len(c.notifiers) == 0
+	c.notifiers = append(c.notifiers, ne)
+	c.mu.Unlock()
+
+	if first {
+		c.OnStatusChange(c.notifyStatusChange)
+		c.OnHealthChange(c.notifyHealthChange)
+	}
+	go c.dispatchEvents(ne)
+}
+
+func (c *Collector) notifyStatusChange(name string, old, new Status) {
+	result := c.load().results[name]
+	// skipped reporter's upstream dependency is notified already
+	if result.Maintenance || result.Muted || result.Skipped {
+		if new == StatusOK {
+			c.clearAlert(name)
+		}
+		return
+	}
+	e := StatusChangeEvent{
+		Reporter:  name,
+		OldStatus: string(old),
+		NewStatus: string(new),
+		Error:     result.Error,
+		ErrorType: result.ErrorType,
+		SoftFail:  result.SoftFail,
+		Severity:  result.Severity,
+		Time:      c.clock.Now(),
+	}
+	if c.throttleEvent(&e) {
+		c.enqueueEvent(e)
+	}
+}
+
+func (c *Collector) notifyHealthChange(healthy bool) {
+	e := StatusChangeEvent{
+		OldStatus: GlobalHealthy,
+		NewStatus: GlobalUnhealthy,
+		Time:      c.clock.Now(),
+	}
+	if healthy {
+		e.OldStatus, e.NewStatus = e.NewStatus, e.OldStatus
+	}
+	c.enqueueEvent(e)
+}
+
+// enqueueEvent method queues the event for the notifiers whose filters
+// allow it. Event is dropped if the notifier's queue is full, so that
+// checks are never blocked by slow notifiers, dropped events are counted in
+// the metric `health_notify_dropped_total`.
+func (c *Collector) enqueueEvent(e StatusChangeEvent) {
+	c.mu.RLock()
+	notifiers := c.notifiers
+	c.mu.RUnlock()
+	for _, ne := range notifiers {
+		if !ne.allow(e) {
+			continue
+		}
+		select {
+		case ne.events <- e:
+		default:
+			atomic.AddUint64(&c.dropped, 1)
+			if logger := c.log(); logger != nil {
+				logger.Warnf("health: notification queue is full, dropping event of '%s'", e.Reporter)
+			}
+		}
+	}
+}
+
+// dispatchEvents method delivers the queued events to the notifier until
+// the collector is stopped.
+func (c *Collector) dispatchEvents(ne *notifierEntry) {
+	for {
+		select {
+		case e := <-ne.events:
+			if err := c.notify(ne.notifier, e); err != nil {
+				if logger := c.log(); logger != nil {
+					logger.Errorf("health: unable to notify: %v", err)
+				}
+			}
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+// notify method delivers the event to the notifier, `NotifierContext` is
+// preferred when implemented.
+func (c *Collector) notify(n Notifier, e StatusChangeEvent) error {
+	if nc, ok := n.(NotifierContext); ok {
+		return nc.NotifyContext(c.ctx, e)
+	}
+	return n.Notify(e)
+}
+
+func (ne *notifierEntry) allow(e StatusChangeEvent) bool {
+	for _, filter := range ne.filters {
+		if !filter(e) {
+			return false
+		}
+	}
+	return true
+}
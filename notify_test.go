@@ -0,0 +1,123 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordNotifier struct {
+	mu     sync.Mutex
+	events []StatusChangeEvent
+}
+
+func (n *recordNotifier) Notify(e StatusChangeEvent) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.events = append(n.events, e)
+	return nil
+}
+
+func (n *recordNotifier) recorded() []StatusChangeEvent {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]StatusChangeEvent(nil), n.events...)
+}
+
+func TestHealthNotifier(t *testing.T) {
+	collector := newCollector()
+	defer collector.Stop()
+	notifier := &recordNotifier{}
+	collector.AddNotifier(notifier)
+
+	reporter := &toggleReporter{err: errors.New("connection refused")}
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: reporter})
+	collector.runChecks()
+	reporter.set(nil)
+	collector.runChecks()
+
+	assert.Eventually(t, func() bool { return len(notifier.recorded()) == 4 }, time.Second, time.Millisecond)
+	events := notifier.recorded()
+	assert.Equal(t, "db", events[0].Reporter)
+	assert.Equal(t, "KO", events[0].NewStatus)
+	assert.Equal(t, "connection refused", events[0].Error)
+	assert.False(t, events[0].IsRecovery())
+	assert.True(t, events[1].IsGlobal())
+	assert.Equal(t, GlobalUnhealthy, events[1].NewStatus)
+	assert.Equal(t, "OK", events[2].NewStatus)
+	assert.True(t, events[2].IsRecovery())
+	assert.Equal(t, GlobalHealthy, events[3].NewStatus)
+}
+
+type blockingNotifier struct {
+	release chan struct{}
+}
+
+func (n *blockingNotifier) Notify(e StatusChangeEvent) error {
+	<-n.release
+	return nil
+}
+
+func TestHealthNotifierFilters(t *testing.T) {
+	collector := newCollector()
+	defer collector.Stop()
+	blocking := &blockingNotifier{release: make(chan struct{})}
+	defer close(blocking.release)
+	global, hard, db := &recordNotifier{}, &recordNotifier{}, &recordNotifier{}
+	collector.AddNotifier(blocking)
+	collector.AddNotifier(global, OnlyGlobal())
+	collector.AddNotifier(hard, OnlyHardFailures())
+	collector.AddNotifier(db, OnlyReporters("db"), OnlyHardFailures())
+
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: &toggleReporter{err: errors.New("down")}})
+	_ = collector.AddReporter(&Config{Name: "cache", Reporter: &toggleReporter{err: errors.New("down")}, SoftFail: true})
+	collector.runChecks()
+
+	// blocked notifier does not delay the others
+	assert.Eventually(t, func() bool {
+		return len(global.recorded()) == 1 && len(hard.recorded()) == 2 && len(db.recorded()) == 1
+	}, time.Second, time.Millisecond)
+	assert.True(t, global.recorded()[0].IsGlobal())
+	assert.Equal(t, "db", db.recorded()[0].Reporter)
+	for _, e := range hard.recorded() {
+		assert.NotEqual(t, "cache", e.Reporter)
+	}
+}
+
+func TestHealthNotifyQueueFull(t *testing.T) {
+	collector := newCollector()
+	defer collector.Stop()
+	blocking := &blockingNotifier{release: make(chan struct{})}
+	defer close(blocking.release)
+	collector.AddNotifier(blocking, OnlyReporters("db"))
+
+	reporter := &toggleReporter{}
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: reporter})
+	collector.runChecks()
+	// events beyond the queue size are dropped
+	for i := 0; i < 70; i++ {
+		if i%2 == 0 {
+			reporter.set(errors.New("down"))
+		} else {
+			reporter.set(nil)
+		}
+		collector.runChecks()
+	}
+
+	var buf bytes.Buffer
+	assert.Nil(t, collector.WriteMetrics(&buf))
+	assert.Contains(t, buf.String(), "# TYPE health_notify_dropped_total counter\n")
+	dropped := atomic.LoadUint64(&collector.dropped)
+	assert.NotZero(t, dropped)
+	assert.Contains(t, buf.String(), "health_notify_dropped_total "+strconv.FormatUint(dropped, 10)+"\n")
+}
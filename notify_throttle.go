@@ -0,0 +1,122 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import "time"
+
+// NotifyPolicy struct holds the per reporter throttling of the notifications,
+// refer to `WithNotifyPolicy`. Zero value notifies every status transition.
+type NotifyPolicy struct {
+	// RepeatInterval throttles the failure notifications of a reporter to
+	// at most once per interval and repeats the notification while the
+	// failure is ongoing. Recovery is notified only for the notified
+	// failure. 0 disables it.
+	RepeatInterval time.Duration
+
+	// EscalateAfter notifies the escalation once the reporter has been
+	// failing for the duration, subsequent repeats are marked escalated
+	// too. 0 disables it.
+	EscalateAfter time.Duration
+}
+
+// alertState struct holds the notification state of a failing reporter.
+type alertState struct {
+	since      time.Time // failing since
+	notifiedAt time.Time
+	failing    bool
+	notified   bool
+	escalated  bool
+}
+
+// throttleEvent method records the reporter's status change event as per
+// the notify policy and returns true if it has to be notified.
+func (c *Collector) throttleEvent(e *StatusChangeEvent) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	policy := c.notifyPolicy
+	if policy == (NotifyPolicy{}) {
+		return true
+	}
+	st := c.alerts[e.Reporter]
+	if e.IsRecovery() {
+		if st == nil || !st.failing {
+			return true
+		}
+		notified := st.notified
+		st.failing, st.notified, st.escalated = false, false, false
+		e.Outage = e.Time.Sub(st.since)
+		return notified
+	}
+
+	if st == nil {
+		st = &alertState{}
+		c.alerts[e.Reporter] = st
+	}
+	st.since, st.failing, st.escalated = e.Time, true, false
+	if policy.RepeatInterval > 0 && !st.notifiedAt.IsZero() && e.Time.Sub(st.notifiedAt) < policy.RepeatInterval {
+		st.notified = false // flapping, repeated once the interval passes
+		return false
+	}
+	st.notified, st.notifiedAt = true, e.Time
+	return true
+}
+
+// clearAlert method clears the failure of the reporter's alert state on
+// its recovery which is not notified, for e.g. in maintenance or muted, so
+// that the stale failure is neither reminded nor escalated. Notified time is
+// retained to throttle the next failure.
+func (c *Collector) clearAlert(name string) {
+	c.mu.Lock()
+	if st := c.alerts[name]; st != nil {
+		st.failing, st.notified, st.escalated = false, false, false
+	}
+	c.mu.Unlock()
+}
+
+// remindFailures method notifies the ongoing failures of the reporters
+// whose repeat interval or escalation duration has passed.
+func (c *Collector) remindFailures() {
+	results := c.load().results
+	now := c.clock.Now()
+
+	var events []StatusChangeEvent
+	c.mu.Lock()
+	policy := c.notifyPolicy
+	for name, st := range c.alerts {
+		result, found := results[name]
+		if !found {
+			delete(c.alerts, name)
+			continue
+		}
+		if !st.failing || result.IsOK() || result.Maintenance || result.Muted || result.Skipped {
+			continue
+		}
+		escalate := policy.EscalateAfter > 0 && !st.escalated && now.Sub(st.since) >= policy.EscalateAfter
+		repeat := policy.RepeatInterval > 0 && now.Sub(st.notifiedAt) >= policy.RepeatInterval
+		if !escalate && !repeat {
+			continue
+		}
+		st.escalated = st.escalated || escalate
+		st.notified, st.notifiedAt = true, now
+		events = append(events, StatusChangeEvent{
+			Reporter:  name,
+			OldStatus: string(StatusKO),
+			NewStatus: string(StatusKO),
+			Error:     result.Error,
+			ErrorType: result.ErrorType,
+			SoftFail:  result.SoftFail,
+			Severity:  result.Severity,
+			Time:      now,
+			Outage:    now.Sub(st.since),
+			Repeat:    true,
+			Escalated: st.escalated,
+		})
+	}
+	c.mu.Unlock()
+
+	for _, e := range events {
+		c.enqueueEvent(e)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthNotifyPolicy(t *testing.T) {
+	collector := newCollector()
+	defer collector.Stop()
+	WithNotifyPolicy(NotifyPolicy{RepeatInterval: 30 * time.Minute, EscalateAfter: time.Hour})(collector)
+	notifier := &recordNotifier{}
+	collector.AddNotifier(notifier, OnlyReporters("db"))
+
+	now := time.Date(2019, 3, 1, 10, 0, 0, 0, time.UTC)
+	reporter := &toggleReporter{}
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: reporter})
+	checkAt := func(d time.Duration, err error) {
+		reporter.set(err)
+		collector.clock = fixedClock(now.Add(d))
+		collector.runChecks()
+	}
+	down := errors.New("connection refused")
+
+	checkAt(0, down)               // notified
+	checkAt(10*time.Minute, down)  // ongoing
+	checkAt(20*time.Minute, nil)   // recovery notified
+	checkAt(25*time.Minute, down)  // flapping, throttled
+	checkAt(27*time.Minute, nil)   // recovery of throttled failure, not notified
+	checkAt(28*time.Minute, down)  // throttled
+	checkAt(35*time.Minute, down)  // repeated
+	checkAt(50*time.Minute, down)  // ongoing
+	checkAt(90*time.Minute, down)  // escalated
+	checkAt(100*time.Minute, down) // ongoing
+	checkAt(125*time.Minute, down) // repeated, escalated
+	checkAt(130*time.Minute, nil)  // recovery notified
+
+	assert.Eventually(t, func() bool { return len(notifier.recorded()) == 6 }, time.Second, time.Millisecond)
+	events := notifier.recorded()
+	assert.Equal(t, "KO", events[0].NewStatus)
+	assert.False(t, events[0].IsReminder())
+	assert.True(t, events[1].IsRecovery())
+	assert.Equal(t, 20*time.Minute, events[1].Outage)
+
+	assert.True(t, events[2].Repeat)
+	assert.False(t, events[2].Escalated)
+	assert.Equal(t, "KO", events[2].OldStatus)
+	assert.Equal(t, "connection refused", events[2].Error)
+	assert.Equal(t, 7*time.Minute, events[2].Outage)
+	assert.Equal(t, now.Add(35*time.Minute), events[2].Time)
+
+	assert.True(t, events[3].Escalated)
+	assert.Equal(t, 62*time.Minute, events[3].Outage)
+	assert.True(t, events[4].Escalated)
+	assert.Equal(t, now.Add(125*time.Minute), events[4].Time)
+
+	assert.True(t, events[5].IsRecovery())
+	assert.Equal(t, 102*time.Minute, events[5].Outage)
+}
+
+func TestHealthNotifyPolicyMutedRecovery(t *testing.T) {
+	collector := newCollector()
+	defer collector.Stop()
+	WithNotifyPolicy(NotifyPolicy{RepeatInterval: 30 * time.Minute, EscalateAfter: time.Hour})(collector)
+	notifier := &recordNotifier{}
+	collector.AddNotifier(notifier, OnlyReporters("db"))
+
+	now := time.Date(2019, 3, 1, 10, 0, 0, 0, time.UTC)
+	reporter := &toggleReporter{}
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: reporter})
+	checkAt := func(d time.Duration, err error) {
+		reporter.set(err)
+		collector.clock = fixedClock(now.Add(d))
+		collector.runChecks()
+	}
+	down := errors.New("connection refused")
+
+	checkAt(0, down) // notified
+	_ = collector.Mute("db")
+	checkAt(10*time.Minute, nil)  // recovery is muted, alert is cleared
+	checkAt(20*time.Minute, down) // muted
+	_ = collector.Unmute("db")
+	checkAt(70*time.Minute, down) // stale failure is neither repeated nor escalated
+
+	assert.Eventually(t, func() bool { return len(notifier.recorded()) == 1 }, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	assert.Len(t, notifier.recorded(), 1)
+	assert.False(t, collector.alerts["db"].failing)
+}
+
+func TestHealthNotifyPolicyDisabled(t *testing.T) {
+	collector := newCollector()
+	defer collector.Stop()
+	notifier := &recordNotifier{}
+	collector.AddNotifier(notifier, OnlyReporters("db"))
+
+	reporter := &toggleReporter{}
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: reporter})
+	for i := 0; i < 3; i++ {
+		reporter.set(errors.New("down"))
+		collector.runChecks()
+		collector.runChecks()
+		reporter.set(nil)
+		collector.runChecks()
+	}
+	assert.Eventually(t, func() bool { return len(notifier.recorded()) == 6 }, time.Second, time.Millisecond)
+	for _, e := range notifier.recorded() {
+		assert.False(t, e.IsReminder())
+		assert.Zero(t, e.Outage)
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"sync/atomic"
+	"time"
+
+	"aahframe.work/log"
+)
+
+// Option func type to customize the `Collector` created by `NewCollector`.
+type Option func(c *Collector)
+
+// WithInterval option sets the periodic check interval, default is 10s.
+// It takes precedence over `health.interval` of aah application config,
+// negative or 0 value is ignored.
+func WithInterval(interval time.Duration) Option {
+	return func(c *Collector) {
+		if interval <= 0 {
+			return
+		}
+		atomic.StoreInt64(&c.period, int64(interval))
+		c.intervalSet = true
+	}
+}
+
+// WithInitialDelay option sets the delay of the first periodic check after
+// the collector is created, default is 5s. Negative value is treated as 0.
+func WithInitialDelay(delay time.Duration) Option {
+	return func(c *Collector) {
+		if delay < 0 {
+			delay = 0
+		}
+		c.initialDelay = delay
+	}
+}
+
+// WithJitter option sets the maximum random delay added to the initial
+// delay and every check interval, refer to `Collector.SetJitter`.
+func WithJitter(jitter time.Duration) Option {
+	return func(c *Collector) {
+		c.SetJitter(jitter)
+	}
+}
+
+// WithTimeout option sets the default timeout of `ReporterContext` checks,
+// `Config.Timeout` takes precedence over it.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Collector) {
+		c.timeout = timeout
+	}
+}
+
+// WithLogger option sets the logger of the collector, refer to
+// `Collector.SetLogger`.
+func WithLogger(logger log.Loggerer) Option {
+	return func(c *Collector) {
+		c.logger = logger
+	}
+}
+
+// WithMaxConcurrency option limits the number of reporters checked
+// concurrently, refer to `Collector.SetMaxConcurrentChecks`.
+func WithMaxConcurrency(n int) Option {
+	return func(c *Collector) {
+		c.maxChecks = n
+	}
+}
+
+// WithSlowThreshold option sets the default slow check threshold of the
+// reporters, refer to `Config.SlowThreshold`.
+func WithSlowThreshold(threshold time.Duration) Option {
+	return func(c *Collector) {
+		c.slow = threshold
+	}
+}
+
+// WithCheckCycleTimeout option sets the deadline of a check cycle, once
+// passed the completed results are published and the reporters yet to finish
+// are reported as timed out. They are not checked again until they finish.
+// Default is 0, the cycle waits for all the reporters.
+func WithCheckCycleTimeout(timeout time.Duration) Option {
+	return func(c *Collector) {
+		c.cycleTimeout = timeout
+	}
+}
+
+// WithNotifyPolicy option sets the notification throttling of the
+// reporters, for e.g. notify at most once per 30 minutes for an ongoing
+// failure and escalate after an hour:
+//
+//	health.WithNotifyPolicy(health.NotifyPolicy{
+//	    RepeatInterval: 30 * time.Minute,
+//	    EscalateAfter:  time.Hour,
+//	})
+func WithNotifyPolicy(policy NotifyPolicy) Option {
+	return func(c *Collector) {
+		c.notifyPolicy = policy
+	}
+}
+
+// WithClock option sets the time source of the collector, default is the
+// system clock. Use `healthtest.NewFakeClock` to drive the periodic checks
+// deterministically in tests.
+func WithClock(clock Clock) Option {
+	return func(c *Collector) {
+		if clock != nil {
+			c.clock = clock
+		}
+	}
+}
+
+// WithHistorySize option sets the number of check results retained per
+// reporter, default is 20. Use 0 to disable the history.
+func WithHistorySize(n int) Option {
+	return func(c *Collector) {
+		if n < 0 {
+			n = 0
+		}
+		c.historySize = n
+	}
+}
+
+// WithStore option sets the store which the check results are saved into
+// after every check run, default is `MemoryStore`. Refer to `Store`.
+func WithStore(store Store) Option {
+	return func(c *Collector) {
+		if store != nil {
+			c.store = store
+		}
+	}
+}
+
+// WithInstance option sets the instance name of the collector in the
+// store, default is the hostname.
+func WithInstance(name string) Option {
+	return func(c *Collector) {
+		if len(name) > 0 {
+			c.instance = name
+		}
+	}
+}
+
+// WithRestore option restores the last known results of the collector's
+// instance from its store at startup, so that health check responds with
+// meaningful results before the first check. Restored result is published
+// once its reporter is added and flagged with `CheckResult.Restored` until
+// the reporter is checked. Results older than given max age are discarded,
+// 0 means no limit. Use it with persistent store such as `FileStore`, e.g.:
+//
+//	store, _ := health.NewFileStore("/var/lib/myapp/health")
+//	collector := health.NewCollector(
+//	    health.WithStore(store),
+//	    health.WithRestore(10*time.Minute),
+//	)
+func WithRestore(maxAge time.Duration) Option {
+	return func(c *Collector) {
+		c.restore = true
+		c.restoreAge = maxAge
+	}
+}
+
+// WithTracer option sets the tracer of the check runs, refer to `Tracer`.
+func WithTracer(tracer Tracer) Option {
+	return func(c *Collector) {
+		c.trace = tracer
+	}
+}
+
+// WithMetricsEmitter option adds the emitter which the check run metrics
+// are pushed to after every check run, refer to `MetricsEmitter`.
+func WithMetricsEmitter(emitter MetricsEmitter) Option {
+	return func(c *Collector) {
+		if emitter != nil {
+			c.emitters = append(c.emitters, emitter)
+		}
+	}
+}
+
+// WithStartupGracePeriod option sets the startup grace period of the
+// collector, during which `/healthcheck` reports `Starting` until every
+// critical reporter has passed once, so that a slow-starting dependency does
+// not mark the new instance unhealthy. Response status code is
+// `RegisterOptions.StartingStatusCode`. Readiness is not affected.
+func WithStartupGracePeriod(grace time.Duration) Option {
+	return func(c *Collector) {
+		c.startupGrace = grace
+	}
+}
+
+// WithCheckLogging option logs every check result at debug level, by
+// default only status transitions and failures are logged. Refer to
+// `Collector.SetLogger`.
+func WithCheckLogging(enable bool) Option {
+	return func(c *Collector) {
+		c.logChecks = enable
+	}
+}
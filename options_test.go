@@ -0,0 +1,52 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthNewCollectorOptions(t *testing.T) {
+	logger := &warnLogger{}
+	collector := NewCollector(
+		WithInterval(30*time.Second),
+		WithInitialDelay(-time.Second),
+		WithJitter(time.Second),
+		WithTimeout(3*time.Second),
+		WithLogger(logger),
+		WithMaxConcurrency(5),
+		WithSlowThreshold(time.Second),
+	)
+	defer collector.Stop()
+	assert.Equal(t, 30*time.Second, time.Duration(atomic.LoadInt64(&collector.period)))
+	assert.True(t, collector.intervalSet)
+	assert.Equal(t, time.Duration(0), collector.initialDelay)
+	assert.Equal(t, int64(time.Second), atomic.LoadInt64(&collector.jitter))
+	assert.Equal(t, 3*time.Second, collector.timeout)
+	assert.Equal(t, logger, collector.log())
+	assert.Equal(t, 5, collector.maxConcurrentChecks())
+	assert.Equal(t, time.Second, collector.slow)
+
+	defaults := NewCollector(WithInterval(-time.Second))
+	defer defaults.Stop()
+	assert.Equal(t, 10*time.Second, time.Duration(atomic.LoadInt64(&defaults.period)))
+	assert.False(t, defaults.intervalSet)
+	assert.Equal(t, 5*time.Second, defaults.initialDelay)
+}
+
+func TestHealthWithInitialDelay(t *testing.T) {
+	reporter := &flakyReporter{}
+	collector := NewCollector(WithInterval(time.Hour), WithInitialDelay(10*time.Millisecond))
+	defer collector.Stop()
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: reporter, DeferInitialCheck: true})
+
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&reporter.calls) == 1
+	}, time.Second, 5*time.Millisecond)
+}
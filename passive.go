@@ -0,0 +1,99 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var _ ReporterDetails = (*PassiveReporter)(nil)
+
+// PassiveReporter struct holds the status pushed by an external process,
+// such as batch job or sidecar, which cannot be polled. Status is pushed
+// using `Collector.ReportStatus` or the route `POST /healthcheck/report`,
+// refer to `RegisterOptions.ReportAuth`.
+//
+// Reporter fails once the status is not pushed within the TTL. It is
+// considered healthy until the TTL elapses after its creation, so that the
+// process gets a chance to push the first status.
+type PassiveReporter struct {
+	ttl      time.Duration
+	clock    Clock
+	mu       sync.RWMutex
+	err      error
+	reported time.Time
+	pushed   bool
+}
+
+// NewPassiveReporter method returns a `PassiveReporter` instance for given
+// TTL of the pushed status.
+//
+//	_ = collector.AddReporter(&health.Config{
+//	    Name:     "nightly-export",
+//	    Reporter: health.NewPassiveReporter(25 * time.Hour),
+//	})
+func NewPassiveReporter(ttl time.Duration) *PassiveReporter {
+	return &PassiveReporter{ttl: ttl, clock: realClock{}, reported: time.Now()}
+}
+
+// Report method records the status of the external process, nil error
+// means healthy.
+func (r *PassiveReporter) Report(err error) {
+	r.mu.Lock()
+	r.err, r.reported, r.pushed = err, r.clock.Now(), true
+	r.mu.Unlock()
+}
+
+// Check method returns the last pushed status, it fails once the status is
+// older than TTL.
+func (r *PassiveReporter) Check() error {
+	_, err := r.CheckDetails(context.Background())
+	return err
+}
+
+// CheckDetails method returns the last pushed status and its time as
+// details, it fails once the status is older than TTL.
+func (r *PassiveReporter) CheckDetails(_ context.Context) (map[string]interface{}, error) {
+	r.mu.RLock()
+	err, reported, pushed := r.err, r.reported, r.pushed
+	r.mu.RUnlock()
+
+	details := map[string]interface{}{
+		"ttl": r.ttl.String(),
+	}
+	if pushed {
+		details["lastReported"] = reported
+	}
+	if age := r.clock.Now().Sub(reported); r.ttl > 0 && age > r.ttl {
+		if !pushed {
+			return details, fmt.Errorf("status is not reported within ttl %s", r.ttl)
+		}
+		return details, fmt.Errorf("status is stale, last reported %s ago exceeds ttl %s",
+			age.Truncate(time.Second), r.ttl)
+	}
+	return details, err
+}
+
+// ReportStatus method records the status pushed by an external process for
+// the passive reporter of given name and publishes its result right away,
+// nil error means healthy. Refer to `PassiveReporter`.
+func (c *Collector) ReportStatus(name string, err error) error {
+	c.mu.RLock()
+	rc, exists := c.reporters[name]
+	c.mu.RUnlock()
+	if !exists {
+		return fmt.Errorf("health: reporter name '%s' does not exist", name)
+	}
+	pr, ok := rc.Reporter.(*PassiveReporter)
+	if !ok {
+		return fmt.Errorf("health: reporter '%s' is not a passive reporter", name)
+	}
+	pr.Report(err)
+	c.checkReporters([]*Config{rc})
+	return nil
+}
@@ -0,0 +1,47 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthPassiveReporter(t *testing.T) {
+	collector := newCollector()
+	export := NewPassiveReporter(time.Hour)
+	_ = collector.AddReporter(&Config{Name: "export", Reporter: export})
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: &toggleReporter{}})
+	collector.runChecks()
+	assert.True(t, collector.IsHealthy())
+
+	assert.Equal(t, "health: reporter name 'unknown' does not exist",
+		collector.ReportStatus("unknown", nil).Error())
+	assert.Equal(t, "health: reporter 'db' is not a passive reporter",
+		collector.ReportStatus("db", nil).Error())
+
+	// pushed status is published right away
+	assert.Nil(t, collector.ReportStatus("export", errors.New("export failed")))
+	assert.False(t, collector.IsHealthy())
+	assert.Equal(t, "export failed", collector.Results()["export"].Error)
+	assert.Nil(t, collector.ReportStatus("export", nil))
+	assert.True(t, collector.IsHealthy())
+	assert.Contains(t, collector.Results()["export"].Details, "lastReported")
+
+	// status is stale once ttl elapsed
+	now := time.Now()
+	export.clock = fixedClock(now.Add(2 * time.Hour))
+	collector.runChecks()
+	assert.False(t, collector.IsHealthy())
+	assert.Contains(t, collector.Results()["export"].Error, "status is stale, last reported")
+
+	pending := NewPassiveReporter(time.Hour)
+	assert.Nil(t, pending.Check())
+	pending.clock = fixedClock(now.Add(2 * time.Hour))
+	assert.Equal(t, "status is not reported within ttl 1h0m0s", pending.Check().Error())
+}
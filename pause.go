@@ -0,0 +1,39 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import "sync/atomic"
+
+// HeaderHealthPaused is the response header which is set to `true` while
+// the collector's periodic checks are paused, refer to `Collector.Pause`.
+const HeaderHealthPaused = "X-Health-Paused"
+
+// Pause method suspends the periodic checks of the collector, for e.g.
+// during a coordinated maintenance or a heavy migration. Last snapshot is
+// served meanwhile with the paused marker, that is header `X-Health-Paused`
+// and `paused` field of the envelope. Explicit checks such as
+// `Collector.CheckNow` still run.
+func (c *Collector) Pause() {
+	if atomic.CompareAndSwapInt32(&c.paused, 0, 1) {
+		if logger := c.log(); logger != nil {
+			logger.Info("health: periodic checks paused")
+		}
+	}
+}
+
+// Resume method resumes the periodic checks paused by `Collector.Pause`,
+// reporters are checked on the next tick.
+func (c *Collector) Resume() {
+	if atomic.CompareAndSwapInt32(&c.paused, 1, 0) {
+		if logger := c.log(); logger != nil {
+			logger.Info("health: periodic checks resumed")
+		}
+	}
+}
+
+// IsPaused method returns true if the periodic checks are paused.
+func (c *Collector) IsPaused() bool {
+	return atomic.LoadInt32(&c.paused) == 1
+}
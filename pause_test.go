@@ -0,0 +1,44 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthPausedResponse(t *testing.T) {
+	collector := newCollector()
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: &toggleReporter{}})
+	collector.runChecks()
+	h, _ := collector.HandlerWithOptions(RegisterOptions{Envelope: true})
+	get := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthcheck", nil))
+		return w
+	}
+
+	w := get()
+	etag := w.Header().Get("ETag")
+	assert.Empty(t, w.Header().Get(HeaderHealthPaused))
+	assert.NotContains(t, w.Body.String(), `"paused"`)
+
+	collector.Pause()
+	w = get()
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "true", w.Header().Get(HeaderHealthPaused))
+	assert.Contains(t, w.Body.String(), `"paused":true`)
+	assert.NotEqual(t, etag, w.Header().Get("ETag"))
+
+	// last snapshot is still checked on demand
+	assert.True(t, collector.CheckNow(time.Second))
+
+	collector.Resume()
+	assert.Empty(t, get().Header().Get(HeaderHealthPaused))
+}
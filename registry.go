@@ -0,0 +1,106 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import "sync"
+
+var (
+	registry = &collectorRegistry{
+		byName:   make(map[string]*Collector),
+		byDomain: make(map[domainKey]*Collector),
+		byPath:   make(map[domainKey]*binding),
+	}
+
+	// reservedNames are sub-route names of the health routes, which
+	// cannot be used as collector name.
+	reservedNames = map[string]struct{}{
+		"live":      {},
+		"ready":     {},
+		"metrics":   {},
+		"history":   {},
+		"drain":     {},
+		"mute":      {},
+		"report":    {},
+		"reporters": {},
+		"tags":      {},
+		"stream":    {},
+		"ws":        {},
+		"ui":        {},
+		"badge.svg": {},
+	}
+)
+
+// Lookup method returns the collector registered into aah application
+// with given name, refer to `RegisterOptions.Name`. The unnamed collector
+// can be looked up with empty name. If different collectors are registered
+// with the name for different domains, the first registered one is
+// returned, use `LookupForDomain` instead. It returns nil if not found.
+func Lookup(name string) *Collector {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	return registry.byName[name]
+}
+
+// LookupForDomain method returns the collector registered into aah
+// application for given domain with given name. It returns nil if not found.
+func LookupForDomain(domain, name string) *Collector {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	return registry.byDomain[domainKey{domain: domain, key: name}]
+}
+
+// collectorRegistry struct holds the collectors registered into aah
+// application keyed by name and by route path of each domain, so that
+// each domain of multi-domain application can have its own collector.
+type collectorRegistry struct {
+	mu       sync.RWMutex
+	byName   map[string]*Collector
+	byDomain map[domainKey]*Collector
+	byPath   map[domainKey]*binding
+}
+
+// domainKey struct is the key of collector name or route path within the
+// domain.
+type domainKey struct {
+	domain string
+	key    string
+}
+
+// binding struct holds the collector and its register options of a route.
+type binding struct {
+	collector *Collector
+	opts      RegisterOptions
+}
+
+// isAvailable method returns true if the name is not taken by another
+// collector in the domain. Same collector can be registered for multiple
+// domains.
+func (r *collectorRegistry) isAvailable(domain, name string, c *Collector) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	existing, found := r.byDomain[domainKey{domain: domain, key: name}]
+	return !found || existing == c
+}
+
+func (r *collectorRegistry) add(c *Collector, opts RegisterOptions, paths []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, found := r.byName[opts.Name]; !found {
+		r.byName[opts.Name] = c
+	}
+	r.byDomain[domainKey{domain: opts.Domain, key: opts.Name}] = c
+	b := &binding{collector: c, opts: opts}
+	for _, p := range paths {
+		r.byPath[domainKey{domain: opts.Domain, key: p}] = b
+	}
+}
+
+// lookupPath method returns the binding of the route path in the domain,
+// it returns nil if not found.
+func (r *collectorRegistry) lookupPath(domain, p string) *binding {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.byPath[domainKey{domain: domain, key: p}]
+}
@@ -0,0 +1,75 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRegistry() *collectorRegistry {
+	return &collectorRegistry{
+		byName:   make(map[string]*Collector),
+		byDomain: make(map[domainKey]*Collector),
+		byPath:   make(map[domainKey]*binding),
+	}
+}
+
+// useTestRegistry replaces the package registry with a new one for the
+// test, the original is restored once the test is done.
+func useTestRegistry(t *testing.T) {
+	saved := registry
+	registry = newTestRegistry()
+	t.Cleanup(func() { registry = saved })
+}
+
+func TestHealthCollectorRegistry(t *testing.T) {
+	r := newTestRegistry()
+	db, external := newCollector(), newCollector()
+
+	assert.True(t, r.isAvailable("localhost", "db", db))
+	r.add(db, RegisterOptions{Domain: "localhost", Name: "db"}, []string{"/healthcheck/db", "/healthcheck/db/live"})
+	r.add(external, RegisterOptions{Domain: "localhost", Name: "external"}, []string{"/healthcheck/external"})
+
+	// same collector for another domain
+	assert.True(t, r.isAvailable("localhost", "db", db))
+	assert.False(t, r.isAvailable("localhost", "db", external))
+
+	assert.Equal(t, db, r.lookupPath("localhost", "/healthcheck/db/live").collector)
+	assert.Equal(t, external, r.lookupPath("localhost", "/healthcheck/external").collector)
+	assert.Equal(t, "external", r.lookupPath("localhost", "/healthcheck/external").opts.Name)
+	assert.Nil(t, r.lookupPath("localhost", "/healthcheck/unknown"))
+	assert.Nil(t, r.lookupPath("example.com", "/healthcheck/db"))
+}
+
+func TestHealthCollectorRegistryDomains(t *testing.T) {
+	r := newTestRegistry()
+	tenantA, tenantB := newCollector(), newCollector()
+
+	paths := []string{"/healthcheck", "/ping"}
+	r.add(tenantA, RegisterOptions{Domain: "a.example.com"}, paths)
+	assert.True(t, r.isAvailable("b.example.com", "", tenantB))
+	r.add(tenantB, RegisterOptions{Domain: "b.example.com"}, paths)
+	assert.False(t, r.isAvailable("b.example.com", "", tenantA))
+
+	assert.Equal(t, tenantA, r.lookupPath("a.example.com", "/healthcheck").collector)
+	assert.Equal(t, tenantB, r.lookupPath("b.example.com", "/healthcheck").collector)
+	assert.Equal(t, "b.example.com", r.lookupPath("b.example.com", "/ping").opts.Domain)
+
+	// first registered collector is looked up by name
+	assert.Equal(t, tenantA, r.byName[""])
+	assert.Equal(t, tenantB, r.byDomain[domainKey{domain: "b.example.com"}])
+}
+
+func TestHealthLookupForDomain(t *testing.T) {
+	useTestRegistry(t)
+	collector := newCollector()
+	registry.add(collector, RegisterOptions{Domain: "lookup.example.com", Name: "lookup"}, nil)
+
+	assert.Equal(t, collector, Lookup("lookup"))
+	assert.Equal(t, collector, LookupForDomain("lookup.example.com", "lookup"))
+	assert.Nil(t, LookupForDomain("localhost", "lookup"))
+}
@@ -0,0 +1,146 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+var _ ReporterDetails = (*CommandReporter)(nil)
+
+// Exit codes of the Nagios-style check commands, any other is a failure.
+const (
+	commandExitOK      = 0
+	commandExitWarning = 1
+)
+
+// CommandReporterOptions struct holds the configuration of `CommandReporter`.
+type CommandReporterOptions struct {
+	// Path of the executable or script, it is required.
+	Path string
+
+	// Args of the command.
+	Args []string
+
+	// Env of the command in the form of `key=value`, if nil the command
+	// inherits the environment of the process.
+	Env []string
+
+	// Dir is the working directory of the command, default is the process
+	// working directory.
+	Dir string
+
+	// Timeout of the command, it is killed on timeout. Default is 10
+	// seconds.
+	Timeout time.Duration
+
+	// MaxOutput is the maximum number of stdout bytes captured into details,
+	// default is 4096.
+	MaxOutput int
+}
+
+// CommandReporter struct checks the health by executing the command, for
+// e.g. existing Nagios-style check scripts. Exit code 0 passes, 1 fails with
+// `SeverityWarning` and 2 or any other fails with reporter's severity. The
+// first line of stdout is the error message, the bounded stdout and exit
+// code are reported in `CheckResult.Details`.
+type CommandReporter struct {
+	opts CommandReporterOptions
+}
+
+// NewCommandReporter method returns a `CommandReporter` instance for given
+// options.
+func NewCommandReporter(opts CommandReporterOptions) *CommandReporter {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	if opts.MaxOutput <= 0 {
+		opts.MaxOutput = 4096
+	}
+	return &CommandReporter{opts: opts}
+}
+
+// Check method executes the command and interprets its exit code.
+func (r *CommandReporter) Check() error {
+	_, err := r.CheckDetails(context.Background())
+	return err
+}
+
+// CheckDetails method executes the command with given context and
+// interprets its exit code, along with the captured stdout.
+func (r *CommandReporter) CheckDetails(ctx context.Context) (map[string]interface{}, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.opts.Timeout)
+	defer cancel()
+
+	stdout := &limitedBuffer{max: r.opts.MaxOutput}
+	cmd := exec.CommandContext(ctx, r.opts.Path, r.opts.Args...)
+	cmd.Env = r.opts.Env
+	cmd.Dir = r.opts.Dir
+	cmd.Stdout = stdout
+	err := cmd.Run()
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return nil, err // not started
+	}
+	output := stdout.String()
+	details := map[string]interface{}{
+		"exitCode": cmd.ProcessState.ExitCode(),
+		"output":   output,
+	}
+	if stdout.truncated {
+		details["truncated"] = true
+	}
+
+	switch code := cmd.ProcessState.ExitCode(); code {
+	case commandExitOK:
+		return details, nil
+	case commandExitWarning:
+		return details, &SeverityError{Severity: SeverityWarning, Err: commandError(code, output)}
+	default:
+		return details, commandError(code, output)
+	}
+}
+
+// commandError returns the error of given exit code, the message is the
+// first line of the output if any.
+func commandError(code int, output string) error {
+	line, _, _ := strings.Cut(strings.TrimSpace(output), "\n")
+	if line = strings.TrimSpace(line); len(line) > 0 {
+		return errors.New(line)
+	}
+	return fmt.Errorf("exit status %d", code)
+}
+
+// limitedBuffer struct captures up to max bytes, rest is discarded, so that
+// chatty command does not fail with short write.
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	max       int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	if remaining := b.max - b.buf.Len(); n > remaining {
+		b.truncated = true
+		p = p[:remaining]
+	}
+	b.buf.Write(p)
+	return n, nil
+}
+
+func (b *limitedBuffer) String() string {
+	return b.buf.String()
+}
@@ -0,0 +1,87 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandReporter(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+
+	testcases := []struct {
+		label    string
+		script   string
+		err      string
+		severity Severity
+		exitCode int
+	}{
+		{
+			label:  "pass",
+			script: "echo 'DISK OK - free space: / 3326 MB'",
+		},
+		{
+			label:    "warning",
+			script:   "echo 'DISK WARNING - free space: / 300 MB'; echo 'perf data'; exit 1",
+			err:      "DISK WARNING - free space: / 300 MB",
+			severity: SeverityWarning,
+			exitCode: 1,
+		},
+		{
+			label:    "failure",
+			script:   "echo 'DISK CRITICAL - free space: / 30 MB'; exit 2",
+			err:      "DISK CRITICAL - free space: / 30 MB",
+			exitCode: 2,
+		},
+		{
+			label:    "unknown without output",
+			script:   "exit 3",
+			err:      "exit status 3",
+			exitCode: 3,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.label, func(t *testing.T) {
+			r := NewCommandReporter(CommandReporterOptions{Path: "sh", Args: []string{"-c", tc.script}})
+			details, err := r.CheckDetails(context.Background())
+			assert.Equal(t, tc.exitCode, details["exitCode"])
+			if len(tc.err) == 0 {
+				assert.Nil(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.err)
+			var se *SeverityError
+			assert.Equal(t, len(tc.severity) > 0, errors.As(err, &se))
+		})
+	}
+
+	r := NewCommandReporter(CommandReporterOptions{Path: "sh", Args: []string{"-c", "printf '%0100d' 0"}, MaxOutput: 10})
+	details, err := r.CheckDetails(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, strings.Repeat("0", 10), details["output"])
+	assert.Equal(t, true, details["truncated"])
+
+	r = NewCommandReporter(CommandReporterOptions{Path: "sh", Args: []string{"-c", "echo $CHECK_TARGET"}, Env: []string{"CHECK_TARGET=db"}})
+	details, _ = r.CheckDetails(context.Background())
+	assert.Equal(t, "db\n", details["output"])
+
+	r = NewCommandReporter(CommandReporterOptions{Path: "sleep", Args: []string{"5"}, Timeout: 50 * time.Millisecond})
+	start := time.Now()
+	_, err = r.CheckDetails(context.Background())
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.True(t, time.Since(start) < 5*time.Second)
+
+	_, err = NewCommandReporter(CommandReporterOptions{Path: "/nonexistent/check"}).CheckDetails(context.Background())
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,134 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var _ ReporterDetails = (*ElasticsearchReporter)(nil)
+
+// ElasticsearchReporterOptions struct holds the configuration of
+// `ElasticsearchReporter`.
+type ElasticsearchReporterOptions struct {
+	// URL of the Elasticsearch or OpenSearch cluster, for e.g.
+	// `https://es.example.com:9200`. It is required.
+	URL string
+
+	// Username and Password for basic auth, if not empty.
+	Username string
+	Password string
+
+	// Timeout of the HTTP request, default is 5 seconds.
+	Timeout time.Duration
+
+	// TLSConfig used for HTTPS requests, if nil default TLS config is used.
+	TLSConfig *tls.Config
+}
+
+// ElasticsearchReporter struct checks the health of an Elasticsearch or
+// OpenSearch cluster using `_cluster/health` API. Cluster status `green`
+// passes, `yellow` fails with `SeverityWarning` and `red` fails. Shard
+// counts are reported in `CheckResult.Details`.
+type ElasticsearchReporter struct {
+	opts   ElasticsearchReporterOptions
+	client *http.Client
+}
+
+// NewElasticsearchReporter method returns an `ElasticsearchReporter`
+// instance for given options.
+func NewElasticsearchReporter(opts ElasticsearchReporterOptions) *ElasticsearchReporter {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if opts.TLSConfig != nil {
+		transport.TLSClientConfig = opts.TLSConfig
+	}
+	return &ElasticsearchReporter{
+		opts:   opts,
+		client: &http.Client{Transport: transport},
+	}
+}
+
+// Check method queries the cluster health.
+func (r *ElasticsearchReporter) Check() error {
+	_, err := r.CheckDetails(context.Background())
+	return err
+}
+
+// esClusterHealth struct holds the fields of `_cluster/health` response.
+type esClusterHealth struct {
+	ClusterName         string `json:"cluster_name"`
+	Status              string `json:"status"`
+	NumberOfNodes       int    `json:"number_of_nodes"`
+	ActivePrimaryShards int    `json:"active_primary_shards"`
+	ActiveShards        int    `json:"active_shards"`
+	RelocatingShards    int    `json:"relocating_shards"`
+	InitializingShards  int    `json:"initializing_shards"`
+	UnassignedShards    int    `json:"unassigned_shards"`
+}
+
+// CheckDetails method queries the cluster health with given context and
+// returns the shard counts as details.
+func (r *ElasticsearchReporter) CheckDetails(ctx context.Context) (map[string]interface{}, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.opts.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		strings.TrimSuffix(r.opts.URL, "/")+"/_cluster/health", nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(r.opts.Username) > 0 {
+		req.SetBasicAuth(r.opts.Username, r.opts.Password)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPBodySize))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusCodeError(resp.StatusCode)
+	}
+	var ch esClusterHealth
+	if err = json.Unmarshal(body, &ch); err != nil {
+		return nil, fmt.Errorf("invalid cluster health response: %v", err)
+	}
+
+	details := map[string]interface{}{
+		"clusterName":         ch.ClusterName,
+		"status":              ch.Status,
+		"nodes":               ch.NumberOfNodes,
+		"activePrimaryShards": ch.ActivePrimaryShards,
+		"activeShards":        ch.ActiveShards,
+		"relocatingShards":    ch.RelocatingShards,
+		"initializingShards":  ch.InitializingShards,
+		"unassignedShards":    ch.UnassignedShards,
+	}
+	switch ch.Status {
+	case "green":
+		return details, nil
+	case "yellow":
+		return details, &SeverityError{
+			Severity: SeverityWarning,
+			Err:      fmt.Errorf("cluster status is yellow, %d unassigned shards", ch.UnassignedShards),
+		}
+	default:
+		return details, fmt.Errorf("cluster status is %s, %d unassigned shards", ch.Status, ch.UnassignedShards)
+	}
+}
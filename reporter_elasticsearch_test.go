@@ -0,0 +1,50 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestElasticsearchReporter(t *testing.T) {
+	var status atomic.Value
+	status.Store("green")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if u, p, ok := r.BasicAuth(); !ok || u != "elastic" || p != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, "/_cluster/health", r.URL.Path)
+		fmt.Fprintf(w, `{"cluster_name":"logs","status":"%s","number_of_nodes":3,
+			"active_primary_shards":5,"active_shards":8,"unassigned_shards":2}`, status.Load())
+	}))
+	defer ts.Close()
+
+	r := NewElasticsearchReporter(ElasticsearchReporterOptions{URL: ts.URL + "/", Username: "elastic", Password: "secret"})
+	details, err := r.CheckDetails(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "logs", details["clusterName"])
+	assert.Equal(t, 3, details["nodes"])
+	assert.Equal(t, 2, details["unassignedShards"])
+
+	status.Store("yellow")
+	err = r.Check()
+	assert.IsType(t, &SeverityError{}, err)
+	assert.Equal(t, "cluster status is yellow, 2 unassigned shards", err.Error())
+
+	status.Store("red")
+	err = r.Check()
+	assert.Equal(t, "cluster status is red, 2 unassigned shards", err.Error())
+
+	r = NewElasticsearchReporter(ElasticsearchReporterOptions{URL: ts.URL})
+	assert.Equal(t, "unexpected status code 401", r.Check().Error())
+}
@@ -0,0 +1,82 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+	"time"
+)
+
+// fileWriteSize is one block, so that the write allocates space on disk.
+const fileWriteSize = 4096
+
+var _ ReporterDetails = (*FileWriteReporter)(nil)
+
+// FileWriteReporter struct checks the volume is mounted read-write with
+// space available, by writing, syncing and removing a temporary file in the
+// directory. It catches read-only remounts after disk errors, which process
+// liveness does not reveal.
+type FileWriteReporter struct {
+	dir string
+}
+
+// NewFileWriteReporter method returns a `FileWriteReporter` instance for
+// given directory.
+func NewFileWriteReporter(dir string) *FileWriteReporter {
+	return &FileWriteReporter{dir: dir}
+}
+
+// Check method writes and removes the temporary file.
+func (r *FileWriteReporter) Check() error {
+	_, err := r.CheckDetails(context.Background())
+	return err
+}
+
+// CheckDetails method writes and removes the temporary file and returns
+// the latency as details. Context is only checked before the write, file
+// operations are not cancellable.
+func (r *FileWriteReporter) CheckDetails(ctx context.Context) (map[string]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	f, err := os.CreateTemp(r.dir, ".health-*.tmp")
+	if err != nil {
+		return nil, r.wrap(err)
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+
+	_, err = f.Write(make([]byte, fileWriteSize))
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return nil, r.wrap(err)
+	}
+	if err = os.Remove(f.Name()); err != nil {
+		return nil, r.wrap(err)
+	}
+	return map[string]interface{}{
+		"dir":       r.dir,
+		"latencyMs": float64(time.Since(start)) / float64(time.Millisecond),
+	}, nil
+}
+
+func (r *FileWriteReporter) wrap(err error) error {
+	switch {
+	case errors.Is(err, syscall.EROFS):
+		return fmt.Errorf("directory '%s' is on read-only file system", r.dir)
+	case errors.Is(err, syscall.ENOSPC):
+		return fmt.Errorf("no space left on device of directory '%s'", r.dir)
+	}
+	return err
+}
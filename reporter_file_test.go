@@ -0,0 +1,32 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileWriteReporter(t *testing.T) {
+	dir := t.TempDir()
+	r := NewFileWriteReporter(dir)
+	details, err := r.CheckDetails(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, dir, details["dir"])
+	entries, _ := os.ReadDir(dir)
+	assert.Equal(t, 0, len(entries))
+
+	assert.NotNil(t, NewFileWriteReporter(filepath.Join(dir, "missing")).Check())
+
+	err = r.wrap(&os.PathError{Op: "open", Path: dir, Err: syscall.EROFS})
+	assert.Equal(t, "directory '"+dir+"' is on read-only file system", err.Error())
+	err = r.wrap(&os.PathError{Op: "write", Path: dir, Err: syscall.ENOSPC})
+	assert.Equal(t, "no space left on device of directory '"+dir+"'", err.Error())
+}
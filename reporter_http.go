@@ -0,0 +1,128 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const maxHTTPBodySize = 1 << 20 // 1MB
+
+var _ ReporterContext = (*HTTPReporter)(nil)
+
+// HTTPReporterOptions struct holds the configuration of `HTTPReporter`.
+type HTTPReporterOptions struct {
+	// URL to be checked, it is required.
+	URL string
+
+	// Method is HTTP method `GET` or `HEAD`, default is `GET`.
+	Method string
+
+	// Timeout of the HTTP request, default is 5 seconds.
+	Timeout time.Duration
+
+	// ExpectedStatus codes considered as healthy, default is any `2xx` code.
+	ExpectedStatus []int
+
+	// BodyContains substring must be present in the response body if not
+	// empty, it is not supported for `HEAD`.
+	BodyContains string
+
+	// Header values added to the HTTP request.
+	Header http.Header
+
+	// TLSConfig used for HTTPS requests, if nil default TLS config is used.
+	TLSConfig *tls.Config
+}
+
+// HTTPReporter struct checks the health of a dependency via HTTP request.
+type HTTPReporter struct {
+	opts   HTTPReporterOptions
+	client *http.Client
+}
+
+// NewHTTPReporter method returns a `HTTPReporter` instance for given options,
+// it returns an error if `BodyContains` is given for `HEAD` method.
+func NewHTTPReporter(opts HTTPReporterOptions) (*HTTPReporter, error) {
+	if opts.Method == "" {
+		opts.Method = http.MethodGet
+	}
+	if len(opts.BodyContains) > 0 && strings.EqualFold(opts.Method, http.MethodHead) {
+		return nil, fmt.Errorf("health: http reporter body check is not supported for method HEAD")
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if opts.TLSConfig != nil {
+		transport.TLSClientConfig = opts.TLSConfig
+	}
+	return &HTTPReporter{
+		opts:   opts,
+		client: &http.Client{Transport: transport},
+	}, nil
+}
+
+// Check method performs the HTTP request and validates the response.
+func (r *HTTPReporter) Check() error {
+	return r.CheckContext(context.Background())
+}
+
+// CheckContext method performs the HTTP request with given context and
+// validates the response.
+func (r *HTTPReporter) CheckContext(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, r.opts.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, r.opts.Method, r.opts.URL, nil)
+	if err != nil {
+		return err
+	}
+	for k, v := range r.opts.Header {
+		req.Header[k] = v
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if !r.isExpectedStatus(resp.StatusCode) {
+		return statusCodeError(resp.StatusCode)
+	}
+
+	if len(r.opts.BodyContains) == 0 {
+		// drain the body, so that the connection is reused
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxHTTPBodySize))
+		return nil
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPBodySize))
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(string(body), r.opts.BodyContains) {
+		return fmt.Errorf("response body does not contain '%s'", r.opts.BodyContains)
+	}
+	return nil
+}
+
+func (r *HTTPReporter) isExpectedStatus(code int) bool {
+	if len(r.opts.ExpectedStatus) == 0 {
+		return code >= http.StatusOK && code < http.StatusMultipleChoices
+	}
+	for _, c := range r.opts.ExpectedStatus {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPReporter(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			_, _ = w.Write([]byte(`{"status":"green"}`))
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+		case "/head":
+			if r.Method != http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	testcases := []struct {
+		label string
+		opts  HTTPReporterOptions
+		err   string
+	}{
+		{
+			label: "status ok",
+			opts:  HTTPReporterOptions{URL: ts.URL + "/ok"},
+		},
+		{
+			label: "body contains",
+			opts:  HTTPReporterOptions{URL: ts.URL + "/ok", BodyContains: `"green"`},
+		},
+		{
+			label: "body does not contain",
+			opts:  HTTPReporterOptions{URL: ts.URL + "/ok", BodyContains: `"red"`},
+			err:   `response body does not contain '"red"'`,
+		},
+		{
+			label: "unexpected status",
+			opts:  HTTPReporterOptions{URL: ts.URL + "/notfound"},
+			err:   "unexpected status code 404",
+		},
+		{
+			label: "expected status",
+			opts:  HTTPReporterOptions{URL: ts.URL + "/notfound", ExpectedStatus: []int{404}},
+		},
+		{
+			label: "head method",
+			opts:  HTTPReporterOptions{URL: ts.URL + "/head", Method: http.MethodHead},
+		},
+		{
+			label: "timeout",
+			opts:  HTTPReporterOptions{URL: ts.URL + "/slow", Timeout: 20 * time.Millisecond},
+			err:   "context deadline exceeded",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.label, func(t *testing.T) {
+			reporter, err := NewHTTPReporter(tc.opts)
+			assert.Nil(t, err)
+			err = reporter.Check()
+			if tc.err == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), tc.err)
+			}
+		})
+	}
+}
+
+func TestHTTPReporterHeadBody(t *testing.T) {
+	_, err := NewHTTPReporter(HTTPReporterOptions{URL: "http://localhost", Method: http.MethodHead, BodyContains: "ok"})
+	assert.Equal(t, "health: http reporter body check is not supported for method HEAD", err.Error())
+}
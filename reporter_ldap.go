@@ -0,0 +1,308 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+)
+
+// LDAP protocol tags used by the reporter, see RFC 4511.
+const (
+	ldapBindRequest      = 0x60
+	ldapBindResponse     = 0x61
+	ldapUnbindRequest    = 0x42
+	ldapExtendedRequest  = 0x77
+	ldapExtendedResponse = 0x78
+	ldapStartTLSOID      = "1.3.6.1.4.1.1466.20037"
+)
+
+var ldapResultCodes = map[int]string{
+	1:  "operations error",
+	2:  "protocol error",
+	8:  "strong authentication required",
+	13: "confidentiality required",
+	48: "inappropriate authentication",
+	49: "invalid credentials",
+	50: "insufficient access rights",
+	51: "busy",
+	52: "unavailable",
+	53: "unwilling to perform",
+}
+
+// ldapAuthResultCodes are the result codes of rejected credentials.
+var ldapAuthResultCodes = map[int]bool{8: true, 48: true, 49: true, 50: true}
+
+var _ ReporterDetails = (*LDAPReporter)(nil)
+
+// LDAPReporterOptions struct holds the configuration of `LDAPReporter`.
+type LDAPReporterOptions struct {
+	// Addr of the LDAP server in the form of `host:port`, default is
+	// `localhost:389`.
+	Addr string
+
+	// BindDN and Password of the service account, anonymous bind is
+	// performed if BindDN is empty.
+	BindDN   string
+	Password string
+
+	// TLS connects using LDAPS, StartTLS upgrades the plain connection
+	// using StartTLS extended operation. Only one of them is meant to be set.
+	TLS      bool
+	StartTLS bool
+
+	// TLSConfig used for TLS connection, if nil default TLS config with
+	// server name of Addr is used.
+	TLSConfig *tls.Config
+
+	// Timeout of the check, default is 5 seconds.
+	Timeout time.Duration
+}
+
+// LDAPReporter struct checks the health of a LDAP or Active Directory server
+// by binding to it, so that authentication outages are reported before users
+// notice them on login. Latency of the bind is reported in
+// `CheckResult.Details`.
+type LDAPReporter struct {
+	opts LDAPReporterOptions
+}
+
+// NewLDAPReporter method returns a `LDAPReporter` instance for given options.
+func NewLDAPReporter(opts LDAPReporterOptions) *LDAPReporter {
+	if opts.Addr == "" {
+		opts.Addr = "localhost:389"
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultNetTimeout
+	}
+	return &LDAPReporter{opts: opts}
+}
+
+// Check method binds to the LDAP server.
+func (r *LDAPReporter) Check() error {
+	_, err := r.CheckDetails(context.Background())
+	return err
+}
+
+// CheckDetails method binds to the LDAP server with given context and
+// returns the bind latency as details.
+func (r *LDAPReporter) CheckDetails(ctx context.Context) (map[string]interface{}, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.opts.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", r.opts.Addr)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	if r.opts.TLS {
+		if conn, err = r.handshake(conn); err != nil {
+			return nil, err
+		}
+	}
+	rd := bufio.NewReader(conn)
+	msgID := 1
+	if r.opts.StartTLS && !r.opts.TLS {
+		req := berTLV(ldapExtendedRequest, berTLV(0x80, []byte(ldapStartTLSOID)))
+		if err = ldapRoundTrip(conn, rd, msgID, req, ldapExtendedResponse); err != nil {
+			return nil, fmt.Errorf("ldap start tls failed: %v", err)
+		}
+		if conn, err = r.handshake(conn); err != nil {
+			return nil, err
+		}
+		rd = bufio.NewReader(conn)
+		msgID++
+	}
+
+	bind := berTLV(ldapBindRequest, append(append(
+		berInt(3),
+		berTLV(0x04, []byte(r.opts.BindDN))...),
+		berTLV(0x80, []byte(r.opts.Password))...))
+	if err = ldapRoundTrip(conn, rd, msgID, bind, ldapBindResponse); err != nil {
+		return nil, fmt.Errorf("ldap bind failed: %w", err)
+	}
+	latency := time.Since(start)
+
+	// unbind is best effort, server closes the connection on it
+	msgID++
+	_, _ = conn.Write(berTLV(0x30, append(berInt(msgID), ldapUnbindRequest, 0x00)))
+
+	return map[string]interface{}{
+		"anonymous": r.opts.BindDN == "",
+		"tls":       r.opts.TLS || r.opts.StartTLS,
+		"latencyMs": float64(latency) / float64(time.Millisecond),
+	}, nil
+}
+
+// handshake method upgrades the connection to TLS, given connection is
+// returned on failure so that it can be closed.
+func (r *LDAPReporter) handshake(conn net.Conn) (net.Conn, error) {
+	cfg := r.opts.TLSConfig
+	if cfg == nil {
+		cfg = &tls.Config{}
+	} else {
+		cfg = cfg.Clone()
+	}
+	if cfg.ServerName == "" {
+		host, _, _ := net.SplitHostPort(r.opts.Addr)
+		cfg.ServerName = host
+	}
+	tlsConn := tls.Client(conn, cfg)
+	if err := tlsConn.Handshake(); err != nil {
+		return conn, err
+	}
+	return tlsConn, nil
+}
+
+// ldapRoundTrip method sends the LDAP message with given protocol operation
+// and verifies the result code of the expected response.
+func ldapRoundTrip(w io.Writer, rd *bufio.Reader, msgID int, op []byte, respTag byte) error {
+	if _, err := w.Write(berTLV(0x30, append(berInt(msgID), op...))); err != nil {
+		return err
+	}
+	tag, msg, err := berRead(rd)
+	if err != nil {
+		return err
+	}
+	if tag != 0x30 {
+		return errors.New("unexpected response")
+	}
+	// message id, then protocol operation
+	if _, _, msg, err = berNext(msg); err != nil {
+		return err
+	}
+	tag, resp, _, err := berNext(msg)
+	if err != nil {
+		return err
+	}
+	if tag != respTag {
+		return fmt.Errorf("unexpected response tag 0x%02x", tag)
+	}
+	tag, code, resp, err := berNext(resp)
+	if err != nil || tag != 0x0a {
+		return errors.New("unexpected response result")
+	}
+	result := berToInt(code)
+	if result == 0 {
+		return nil
+	}
+	desc, found := ldapResultCodes[result]
+	if !found {
+		desc = fmt.Sprintf("result code %d", result)
+	}
+	// matched DN, then diagnostic message
+	resultErr := errors.New(desc)
+	if _, _, resp, err = berNext(resp); err == nil {
+		if _, diag, _, err := berNext(resp); err == nil && len(diag) > 0 {
+			resultErr = fmt.Errorf("%s: %s", desc, diag)
+		}
+	}
+	if ldapAuthResultCodes[result] {
+		return &TypedError{Type: ErrorTypeAuth, Err: resultErr}
+	}
+	return resultErr
+}
+
+//‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// BER encoding, only the subset required by LDAP reporter
+//______________________________________________________________________________
+
+func berTLV(tag byte, content []byte) []byte {
+	b := []byte{tag}
+	switch l := len(content); {
+	case l < 0x80:
+		b = append(b, byte(l))
+	case l <= 0xff:
+		b = append(b, 0x81, byte(l))
+	default:
+		b = append(b, 0x82, byte(l>>8), byte(l))
+	}
+	return append(b, content...)
+}
+
+func berInt(v int) []byte {
+	var b []byte
+	for {
+		b = append([]byte{byte(v)}, b...)
+		v >>= 8
+		if v == 0 && b[0] < 0x80 {
+			break
+		}
+	}
+	return berTLV(0x02, b)
+}
+
+func berToInt(b []byte) int {
+	v := 0
+	for _, c := range b {
+		v = v<<8 | int(c)
+	}
+	return v
+}
+
+// berRead method reads one complete BER element from the reader.
+func berRead(rd *bufio.Reader) (byte, []byte, error) {
+	tag, err := rd.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	l, err := rd.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	size := int(l)
+	if l&0x80 != 0 {
+		n := int(l & 0x7f)
+		if n == 0 || n > 4 {
+			return 0, nil, errors.New("unsupported ber length")
+		}
+		size = 0
+		for i := 0; i < n; i++ {
+			c, err := rd.ReadByte()
+			if err != nil {
+				return 0, nil, err
+			}
+			size = size<<8 | int(c)
+		}
+	}
+	content := make([]byte, size)
+	if _, err = io.ReadFull(rd, content); err != nil {
+		return 0, nil, err
+	}
+	return tag, content, nil
+}
+
+// berNext method returns the tag and content of first BER element in
+// the buffer and the remaining bytes.
+func berNext(b []byte) (byte, []byte, []byte, error) {
+	if len(b) < 2 {
+		return 0, nil, nil, errors.New("malformed response")
+	}
+	tag, size, pos := b[0], int(b[1]), 2
+	if size&0x80 != 0 {
+		n := size & 0x7f
+		if n == 0 || n > 4 || len(b) < pos+n {
+			return 0, nil, nil, errors.New("malformed response")
+		}
+		size = berToInt(b[pos : pos+n])
+		pos += n
+	}
+	if len(b) < pos+size {
+		return 0, nil, nil, errors.New("malformed response")
+	}
+	return tag, b[pos : pos+size], b[pos+size:], nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLDAPReporter(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveLDAP(conn)
+		}
+	}()
+
+	r := NewLDAPReporter(LDAPReporterOptions{Addr: ln.Addr().String()})
+	details, err := r.CheckDetails(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, true, details["anonymous"])
+	assert.Equal(t, false, details["tls"])
+
+	r = NewLDAPReporter(LDAPReporterOptions{Addr: ln.Addr().String(),
+		BindDN: "cn=health,dc=example,dc=com", Password: "secret"})
+	assert.Nil(t, r.Check())
+
+	r = NewLDAPReporter(LDAPReporterOptions{Addr: ln.Addr().String(),
+		BindDN: "cn=health,dc=example,dc=com", Password: "wrong"})
+	assert.Equal(t, "ldap bind failed: invalid credentials: bad password", r.Check().Error())
+
+	r = NewLDAPReporter(LDAPReporterOptions{Addr: ln.Addr().String(), StartTLS: true})
+	assert.Equal(t, "ldap start tls failed: protocol error", r.Check().Error())
+}
+
+// serveLDAP function answers the bind request and refuses the StartTLS.
+func serveLDAP(conn net.Conn) {
+	defer conn.Close()
+	rd := bufio.NewReader(conn)
+	for {
+		_, msg, err := berRead(rd)
+		if err != nil {
+			return
+		}
+		_, id, msg, _ := berNext(msg)
+		tag, op, _, _ := berNext(msg)
+		var resp []byte
+		switch tag {
+		case ldapBindRequest:
+			_, _, op, _ = berNext(op)
+			_, dn, op, _ := berNext(op)
+			_, password, _, _ := berNext(op)
+			code, diag := 0, ""
+			if len(dn) > 0 && string(password) != "secret" {
+				code, diag = 49, "bad password"
+			}
+			resp = ldapResponse(ldapBindResponse, code, diag)
+		case ldapExtendedRequest:
+			resp = ldapResponse(ldapExtendedResponse, 2, "")
+		default:
+			return
+		}
+		_, _ = conn.Write(berTLV(0x30, append(berTLV(0x02, id), resp...)))
+	}
+}
+
+func ldapResponse(tag byte, code int, diag string) []byte {
+	b := berTLV(0x0a, []byte{byte(code)})
+	b = append(b, berTLV(0x04, nil)...)
+	b = append(b, berTLV(0x04, []byte(diag))...)
+	return berTLV(tag, b)
+}
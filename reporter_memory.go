@@ -0,0 +1,166 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+var _ ReporterDetails = (*MemoryReporter)(nil)
+
+// Memory limit files of cgroup v2 and v1 respectively.
+var cgroupMemoryLimitFiles = []string{
+	"/sys/fs/cgroup/memory.max",
+	"/sys/fs/cgroup/memory/memory.limit_in_bytes",
+}
+
+// cgroup v1 reports this huge value when the memory is not limited.
+const cgroupUnlimited = 1 << 62
+
+// MemoryReporterOptions struct holds the thresholds of `MemoryReporter`.
+// Check fails with `SeverityWarning` once the warn threshold is exceeded and
+// with reporter's severity once the fail threshold is exceeded, 0 disables
+// the respective threshold.
+type MemoryReporterOptions struct {
+	// RSSWarn and RSSFail are the resident set size bytes of the process,
+	// RSS is supported only on Linux.
+	RSSWarn uint64
+	RSSFail uint64
+
+	// HeapWarn and HeapFail are the heap in-use bytes.
+	HeapWarn uint64
+	HeapFail uint64
+
+	// PercentWarn and PercentFail are the percentage of memory usage
+	// against the memory limit, e.g. 85. Usage is RSS if supported
+	// otherwise heap in-use bytes. Limit is the system memory, unless
+	// CgroupAware is true and the container memory is limited.
+	PercentWarn float64
+	PercentFail float64
+
+	// CgroupAware uses the cgroup memory limit of the container for the
+	// percentage thresholds, so that the pod is reported unhealthy before
+	// the OOM killer kicks in.
+	CgroupAware bool
+}
+
+// MemoryReporter struct checks the memory usage of the process against
+// absolute or percentage thresholds, usage is reported in
+// `CheckResult.Details`.
+type MemoryReporter struct {
+	opts        MemoryReporterOptions
+	procStatus  string
+	procMeminfo string
+	cgroupFiles []string
+}
+
+// NewMemoryReporter method returns a `MemoryReporter` instance for given
+// thresholds.
+func NewMemoryReporter(opts MemoryReporterOptions) *MemoryReporter {
+	return &MemoryReporter{
+		opts:        opts,
+		procStatus:  "/proc/self/status",
+		procMeminfo: "/proc/meminfo",
+		cgroupFiles: cgroupMemoryLimitFiles,
+	}
+}
+
+// Check method checks the memory usage against the thresholds.
+func (r *MemoryReporter) Check() error {
+	_, err := r.CheckDetails(context.Background())
+	return err
+}
+
+// CheckDetails method checks the memory usage against the thresholds and
+// returns the usage as details.
+func (r *MemoryReporter) CheckDetails(_ context.Context) (map[string]interface{}, error) {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	details := map[string]interface{}{"heapInuse": ms.HeapInuse}
+
+	usage := ms.HeapInuse
+	rss, hasRSS := readProcKB(r.procStatus, "VmRSS")
+	if hasRSS {
+		details["rss"] = rss
+		usage = rss
+	}
+
+	var t thresholds
+	if hasRSS {
+		t.check("rss bytes", float64(rss), float64(r.opts.RSSWarn), float64(r.opts.RSSFail), formatCount)
+	}
+	t.check("heap in-use bytes", float64(ms.HeapInuse), float64(r.opts.HeapWarn), float64(r.opts.HeapFail), formatCount)
+
+	if r.opts.PercentWarn > 0 || r.opts.PercentFail > 0 {
+		limit, source := r.memoryLimit()
+		if limit == 0 {
+			return details, errors.New("unable to determine memory limit")
+		}
+		percent := float64(usage) / float64(limit) * 100
+		details["limit"] = limit
+		details["limitSource"] = source
+		details["usagePercent"] = percent
+		t.check("memory usage", percent, r.opts.PercentWarn, r.opts.PercentFail, formatPercent)
+	}
+	return details, t.err()
+}
+
+// memoryLimit method returns the memory limit and its source, either
+// `cgroup` or `system`. It returns 0 if not supported.
+func (r *MemoryReporter) memoryLimit() (uint64, string) {
+	if r.opts.CgroupAware {
+		for _, f := range r.cgroupFiles {
+			b, err := os.ReadFile(f)
+			if err != nil {
+				continue
+			}
+			v := strings.TrimSpace(string(b))
+			if v == "max" {
+				break // not limited
+			}
+			if limit, err := strconv.ParseUint(v, 10, 64); err == nil && limit > 0 && limit < cgroupUnlimited {
+				return limit, "cgroup"
+			}
+			break
+		}
+	}
+	if total, ok := readProcKB(r.procMeminfo, "MemTotal"); ok {
+		return total, "system"
+	}
+	return 0, ""
+}
+
+// readProcKB returns the bytes of given field in kB from the proc file,
+// e.g. `VmRSS:    1024 kB`.
+func readProcKB(filename, field string) (uint64, bool) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return 0, false
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		name, value, found := strings.Cut(sc.Text(), ":")
+		if !found || name != field {
+			continue
+		}
+		kb, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimSpace(value), " kB"), 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		return kb * 1024, true
+	}
+	return 0, false
+}
+
+func formatPercent(v float64) string {
+	return strconv.FormatFloat(v, 'f', 1, 64) + "%"
+}
@@ -0,0 +1,48 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryReporter(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		f := filepath.Join(dir, name)
+		assert.Nil(t, os.WriteFile(f, []byte(content), 0o600))
+		return f
+	}
+	r := NewMemoryReporter(MemoryReporterOptions{PercentWarn: 50, PercentFail: 90, CgroupAware: true})
+	r.procStatus = write("status", "Name:\tapp\nVmRSS:\t  614400 kB\n")
+	r.procMeminfo = write("meminfo", "MemTotal:       4194304 kB\n")
+	r.cgroupFiles = []string{write("memory.max", "1073741824\n")}
+
+	details, err := r.CheckDetails(context.Background())
+	assert.Equal(t, uint64(600<<20), details["rss"])
+	assert.Equal(t, uint64(1<<30), details["limit"])
+	assert.Equal(t, "cgroup", details["limitSource"])
+	assert.IsType(t, &SeverityError{}, err)
+	assert.Equal(t, "memory usage 58.6% exceeds warn threshold 50.0%", err.Error())
+
+	r.cgroupFiles = []string{write("memory.max", "max\n")}
+	details, err = r.CheckDetails(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "system", details["limitSource"])
+
+	r.opts = MemoryReporterOptions{RSSFail: 512 << 20}
+	assert.Equal(t, "rss bytes 629145600 exceeds fail threshold 536870912", r.Check().Error())
+
+	r.procStatus = filepath.Join(dir, "missing")
+	details, err = r.CheckDetails(context.Background())
+	assert.Nil(t, err)
+	assert.NotContains(t, details, "rss")
+	assert.Contains(t, details, "heapInuse")
+}
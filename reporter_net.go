@@ -0,0 +1,89 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+const defaultNetTimeout = 5 * time.Second
+
+var (
+	_ ReporterContext = (*TCPReporter)(nil)
+	_ ReporterContext = (*DNSReporter)(nil)
+)
+
+//‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// TCPReporter
+//______________________________________________________________________________
+
+// TCPReporter struct checks the health of a dependency by establishing
+// TCP connection to its address.
+type TCPReporter struct {
+	address string
+	timeout time.Duration
+}
+
+// NewTCPReporter method returns a `TCPReporter` instance for given address
+// in the form of `host:port`. Default timeout is 5 seconds if timeout <= 0.
+func NewTCPReporter(address string, timeout time.Duration) *TCPReporter {
+	if timeout <= 0 {
+		timeout = defaultNetTimeout
+	}
+	return &TCPReporter{address: address, timeout: timeout}
+}
+
+// Check method dials the TCP address and closes the connection.
+func (r *TCPReporter) Check() error {
+	return r.CheckContext(context.Background())
+}
+
+// CheckContext method dials the TCP address with given context and
+// closes the connection.
+func (r *TCPReporter) CheckContext(ctx context.Context) error {
+	d := net.Dialer{Timeout: r.timeout}
+	conn, err := d.DialContext(ctx, "tcp", r.address)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+//‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// DNSReporter
+//______________________________________________________________________________
+
+// DNSReporter struct checks the name resolution of a hostname.
+type DNSReporter struct {
+	hostname string
+	resolver *net.Resolver
+}
+
+// NewDNSReporter method returns a `DNSReporter` instance for given hostname.
+func NewDNSReporter(hostname string) *DNSReporter {
+	return &DNSReporter{hostname: hostname, resolver: net.DefaultResolver}
+}
+
+// Check method resolves the hostname with default timeout of 5 seconds.
+func (r *DNSReporter) Check() error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultNetTimeout)
+	defer cancel()
+	return r.CheckContext(ctx)
+}
+
+// CheckContext method resolves the hostname with given context.
+func (r *DNSReporter) CheckContext(ctx context.Context) error {
+	addrs, err := r.resolver.LookupHost(ctx, r.hostname)
+	if err != nil {
+		return err
+	}
+	if len(addrs) == 0 {
+		return fmt.Errorf("no addresses found for '%s'", r.hostname)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTCPReporter(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	address := ln.Addr().String()
+
+	r := NewTCPReporter(address, time.Second)
+	assert.Nil(t, r.Check())
+
+	_ = ln.Close()
+	err = r.Check()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "connection refused")
+}
+
+func TestDNSReporter(t *testing.T) {
+	assert.Nil(t, NewDNSReporter("localhost").Check())
+	assert.NotNil(t, NewDNSReporter("host.invalid").Check())
+}
@@ -0,0 +1,277 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// Ping methods reported in `CheckResult.Details`.
+const (
+	pingMethodICMP = "icmp"
+	pingMethodUDP  = "udp"
+
+	// pingUDPPort is the first destination port of UDP probes, same as
+	// traceroute, it is unlikely to be in use.
+	pingUDPPort = 33434
+)
+
+var (
+	_ ReporterDetails = (*PingReporter)(nil)
+
+	pingPayload = []byte("aah-health-ping")
+	pingSeq     uint32
+)
+
+// PingReporterOptions struct holds the configuration of `PingReporter`.
+type PingReporterOptions struct {
+	// Host to be pinged, hostname or IP address. It is required.
+	Host string
+
+	// Count of probes sent in a burst, default is 3.
+	Count int
+
+	// Interval between the probes, default is 200 milliseconds.
+	Interval time.Duration
+
+	// Timeout of each probe reply, default is 1 second.
+	Timeout time.Duration
+
+	// MaxLoss is the packet loss percentage, beyond which check reports
+	// warning. Default is 0, that is any loss. Loss of all the probes fails
+	// the check.
+	MaxLoss float64
+
+	// MaxRTT is the average round trip time, beyond which check reports
+	// warning. Default is 0, that is not checked.
+	MaxRTT time.Duration
+}
+
+// PingReporter struct checks the reachability of a host using ICMP echo,
+// useful for VPN and peering links that do not expose a TCP service.
+//
+// ICMP requires privileged raw socket, if not permitted, reporter falls back
+// to UDP probes on closed ports and treats ICMP port unreachable as reply.
+// Packet loss and round trip times are reported in `CheckResult.Details`.
+type PingReporter struct {
+	opts PingReporterOptions
+}
+
+// NewPingReporter method returns a `PingReporter` instance for given options.
+func NewPingReporter(opts PingReporterOptions) *PingReporter {
+	if opts.Count <= 0 {
+		opts.Count = 3
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = 200 * time.Millisecond
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = time.Second
+	}
+	return &PingReporter{opts: opts}
+}
+
+// Check method pings the host.
+func (r *PingReporter) Check() error {
+	_, err := r.CheckDetails(context.Background())
+	return err
+}
+
+// CheckDetails method pings the host with given context and returns packet
+// loss and round trip times as details.
+func (r *PingReporter) CheckDetails(ctx context.Context) (map[string]interface{}, error) {
+	ips, err := net.DefaultResolver.LookupIP(ctx, "ip", r.opts.Host)
+	if err != nil {
+		return nil, err
+	}
+	if len(ips) == 0 {
+		return nil, fmt.Errorf("no addresses found for '%s'", r.opts.Host)
+	}
+	ip := ips[0]
+
+	var p pinger
+	method := pingMethodICMP
+	if p, err = newICMPPinger(ip); err != nil {
+		method, p = pingMethodUDP, udpPinger{ip: ip}
+	}
+	defer p.Close()
+
+	var rtts []time.Duration
+	var lastErr error
+	for i := 0; i < r.opts.Count; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(r.opts.Interval):
+			}
+		}
+		seq := int(atomic.AddUint32(&pingSeq, 1) & 0xffff)
+		rtt, err := p.ping(seq, r.opts.Timeout)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		rtts = append(rtts, rtt)
+	}
+
+	loss := float64(r.opts.Count-len(rtts)) * 100 / float64(r.opts.Count)
+	details := map[string]interface{}{
+		"method":      method,
+		"address":     ip.String(),
+		"sent":        r.opts.Count,
+		"received":    len(rtts),
+		"lossPercent": loss,
+	}
+	if len(rtts) == 0 {
+		return details, fmt.Errorf("100%% packet loss to '%s': %v", r.opts.Host, lastErr)
+	}
+	min, max, sum := rtts[0], rtts[0], time.Duration(0)
+	for _, rtt := range rtts {
+		if rtt < min {
+			min = rtt
+		}
+		if rtt > max {
+			max = rtt
+		}
+		sum += rtt
+	}
+	avg := sum / time.Duration(len(rtts))
+	details["rttMinMs"] = float64(min) / float64(time.Millisecond)
+	details["rttAvgMs"] = float64(avg) / float64(time.Millisecond)
+	details["rttMaxMs"] = float64(max) / float64(time.Millisecond)
+
+	switch {
+	case loss > r.opts.MaxLoss:
+		err = fmt.Errorf("%s packet loss to '%s'", formatPercent(loss), r.opts.Host)
+	case r.opts.MaxRTT > 0 && avg > r.opts.MaxRTT:
+		err = fmt.Errorf("average round trip time %s to '%s' exceeds %s", avg, r.opts.Host, r.opts.MaxRTT)
+	}
+	if err != nil {
+		return details, &SeverityError{Severity: SeverityWarning, Err: err}
+	}
+	return details, nil
+}
+
+//‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Unexported types and methods
+//______________________________________________________________________________
+
+type pinger interface {
+	ping(seq int, timeout time.Duration) (time.Duration, error)
+	Close() error
+}
+
+// icmpPinger struct sends ICMP echo requests over raw socket.
+type icmpPinger struct {
+	conn net.PacketConn
+	ip   net.IP
+	id   int
+	v6   bool
+}
+
+func newICMPPinger(ip net.IP) (*icmpPinger, error) {
+	network, laddr, v6 := "ip4:icmp", "0.0.0.0", false
+	if ip.To4() == nil {
+		network, laddr, v6 = "ip6:ipv6-icmp", "::", true
+	}
+	conn, err := net.ListenPacket(network, laddr)
+	if err != nil {
+		return nil, err
+	}
+	return &icmpPinger{conn: conn, ip: ip, id: os.Getpid() & 0xffff, v6: v6}, nil
+}
+
+func (p *icmpPinger) ping(seq int, timeout time.Duration) (time.Duration, error) {
+	// echo request and reply types of ICMPv4 and ICMPv6
+	request, reply := byte(8), byte(0)
+	if p.v6 {
+		request, reply = 128, 129
+	}
+	msg := append([]byte{request, 0, 0, 0,
+		byte(p.id >> 8), byte(p.id), byte(seq >> 8), byte(seq)}, pingPayload...)
+	if !p.v6 { // kernel computes the checksum of ICMPv6
+		cs := icmpChecksum(msg)
+		msg[2], msg[3] = byte(cs>>8), byte(cs)
+	}
+
+	start := time.Now()
+	if err := p.conn.SetDeadline(start.Add(timeout)); err != nil {
+		return 0, err
+	}
+	if _, err := p.conn.WriteTo(msg, &net.IPAddr{IP: p.ip}); err != nil {
+		return 0, err
+	}
+	buf := make([]byte, 1500)
+	for {
+		n, from, err := p.conn.ReadFrom(buf)
+		if err != nil {
+			return 0, err
+		}
+		// raw socket receives every ICMP message of the host
+		if addr, ok := from.(*net.IPAddr); n < 8 || !ok || !addr.IP.Equal(p.ip) ||
+			buf[0] != reply || int(buf[4])<<8|int(buf[5]) != p.id ||
+			int(buf[6])<<8|int(buf[7]) != seq {
+			continue
+		}
+		return time.Since(start), nil
+	}
+}
+
+func (p *icmpPinger) Close() error {
+	return p.conn.Close()
+}
+
+func icmpChecksum(b []byte) uint16 {
+	var sum uint32
+	for i := 0; i+1 < len(b); i += 2 {
+		sum += uint32(b[i])<<8 | uint32(b[i+1])
+	}
+	if len(b)%2 == 1 {
+		sum += uint32(b[len(b)-1]) << 8
+	}
+	for sum>>16 != 0 {
+		sum = sum&0xffff + sum>>16
+	}
+	return ^uint16(sum)
+}
+
+// udpPinger struct sends UDP datagrams to closed ports, host replies with
+// ICMP port unreachable, which is surfaced as connection refused error.
+type udpPinger struct {
+	ip net.IP
+}
+
+func (p udpPinger) ping(seq int, timeout time.Duration) (time.Duration, error) {
+	conn, err := net.DialUDP("udp", nil, &net.UDPAddr{IP: p.ip, Port: pingUDPPort + seq%100})
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	start := time.Now()
+	if err = conn.SetDeadline(start.Add(timeout)); err != nil {
+		return 0, err
+	}
+	if _, err = conn.Write(pingPayload); err != nil {
+		return 0, err
+	}
+	_, err = conn.Read(make([]byte, 512))
+	if err == nil || errors.Is(err, syscall.ECONNREFUSED) {
+		return time.Since(start), nil
+	}
+	return 0, err
+}
+
+func (p udpPinger) Close() error {
+	return nil
+}
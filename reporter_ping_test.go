@@ -0,0 +1,42 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPingReporter(t *testing.T) {
+	r := NewPingReporter(PingReporterOptions{Host: "127.0.0.1", Interval: time.Millisecond})
+	details, err := r.CheckDetails(context.Background())
+	assert.Nil(t, err)
+	assert.Contains(t, []string{pingMethodICMP, pingMethodUDP}, details["method"])
+	assert.Equal(t, 3, details["sent"])
+	assert.Equal(t, 3, details["received"])
+	assert.Equal(t, float64(0), details["lossPercent"])
+	assert.Contains(t, details, "rttAvgMs")
+
+	r = NewPingReporter(PingReporterOptions{Host: "127.0.0.1", MaxRTT: time.Nanosecond})
+	err = r.Check()
+	assert.IsType(t, &SeverityError{}, err)
+	assert.Contains(t, err.Error(), "average round trip time")
+
+	assert.NotNil(t, NewPingReporter(PingReporterOptions{Host: "host.invalid"}).Check())
+}
+
+func TestPingUDPFallback(t *testing.T) {
+	p := udpPinger{ip: net.ParseIP("127.0.0.1")}
+	rtt, err := p.ping(1, time.Second)
+	assert.Nil(t, err)
+	assert.True(t, rtt > 0)
+
+	// echo request of id 1 and seq 1
+	assert.Equal(t, uint16(0xf7fd), icmpChecksum([]byte{8, 0, 0, 0, 0, 1, 0, 1}))
+}
@@ -0,0 +1,169 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+)
+
+var _ ReporterDetails = (*RuntimeReporter)(nil)
+
+// RuntimeReporterOptions struct holds the thresholds of `RuntimeReporter`.
+// Check fails with `SeverityWarning` once the warn threshold is exceeded and
+// with reporter's severity once the fail threshold is exceeded, 0 disables
+// the respective threshold.
+type RuntimeReporterOptions struct {
+	GoroutinesWarn int
+	GoroutinesFail int
+
+	// HeapWarn and HeapFail are the heap in-use bytes.
+	HeapWarn uint64
+	HeapFail uint64
+
+	// GCPauseWarn and GCPauseFail are the duration of the last GC pause.
+	GCPauseWarn time.Duration
+	GCPauseFail time.Duration
+
+	// OpenFilesWarn and OpenFilesFail are the number of open file
+	// descriptors, it is supported only on Linux.
+	OpenFilesWarn int
+	OpenFilesFail int
+}
+
+// RuntimeReporter struct reports the Go runtime stats such as goroutine
+// count, heap usage, GC pause and open file descriptors along with the build
+// info in `CheckResult.Details`, it is useful to detect leaks before the
+// process boils over.
+type RuntimeReporter struct {
+	opts RuntimeReporterOptions
+}
+
+// NewRuntimeReporter method returns a `RuntimeReporter` instance for given
+// thresholds.
+func NewRuntimeReporter(opts RuntimeReporterOptions) *RuntimeReporter {
+	return &RuntimeReporter{opts: opts}
+}
+
+// Check method checks the runtime stats against the thresholds.
+func (r *RuntimeReporter) Check() error {
+	_, err := r.CheckDetails(context.Background())
+	return err
+}
+
+// CheckDetails method checks the runtime stats against the thresholds and
+// returns the stats as details.
+func (r *RuntimeReporter) CheckDetails(_ context.Context) (map[string]interface{}, error) {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	var lastPause time.Duration
+	if ms.NumGC > 0 {
+		lastPause = time.Duration(ms.PauseNs[(ms.NumGC+255)%256])
+	}
+	goroutines := runtime.NumGoroutine()
+	details := map[string]interface{}{
+		"goroutines":   goroutines,
+		"heapInuse":    ms.HeapInuse,
+		"heapAlloc":    ms.HeapAlloc,
+		"heapSys":      ms.HeapSys,
+		"numGC":        ms.NumGC,
+		"gcPauseLast":  lastPause.String(),
+		"gcPauseTotal": time.Duration(ms.PauseTotalNs).String(),
+		"goVersion":    runtime.Version(),
+	}
+	openFiles, hasOpenFiles := openFileCount()
+	if hasOpenFiles {
+		details["openFiles"] = openFiles
+	}
+	for k, v := range buildDetails() {
+		details[k] = v
+	}
+
+	var t thresholds
+	t.check("goroutines", float64(goroutines), float64(r.opts.GoroutinesWarn), float64(r.opts.GoroutinesFail), formatCount)
+	t.check("heap in-use bytes", float64(ms.HeapInuse), float64(r.opts.HeapWarn), float64(r.opts.HeapFail), formatCount)
+	t.check("last GC pause", float64(lastPause), float64(r.opts.GCPauseWarn), float64(r.opts.GCPauseFail), formatDuration)
+	if hasOpenFiles {
+		t.check("open files", float64(openFiles), float64(r.opts.OpenFilesWarn), float64(r.opts.OpenFilesFail), formatCount)
+	}
+	return details, t.err()
+}
+
+// thresholds struct collects the exceeded warn and fail thresholds of
+// a check.
+type thresholds struct {
+	warns []string
+	fails []string
+}
+
+// check method records the value if it exceeds the fail or warn threshold,
+// 0 threshold is disabled.
+func (t *thresholds) check(name string, value, warn, fail float64, format func(float64) string) {
+	switch {
+	case fail > 0 && value > fail:
+		t.fails = append(t.fails, fmt.Sprintf("%s %s exceeds fail threshold %s", name, format(value), format(fail)))
+	case warn > 0 && value > warn:
+		t.warns = append(t.warns, fmt.Sprintf("%s %s exceeds warn threshold %s", name, format(value), format(warn)))
+	}
+}
+
+// err method returns the error of exceeded thresholds, it is
+// `SeverityWarning` if only the warn thresholds are exceeded.
+func (t *thresholds) err() error {
+	if len(t.fails) > 0 {
+		return errors.New(strings.Join(append(t.fails, t.warns...), "; "))
+	}
+	if len(t.warns) > 0 {
+		return &SeverityError{Severity: SeverityWarning, Err: errors.New(strings.Join(t.warns, "; "))}
+	}
+	return nil
+}
+
+func formatCount(v float64) string {
+	return fmt.Sprint(uint64(v))
+}
+
+func formatDuration(v float64) string {
+	return time.Duration(v).String()
+}
+
+// openFileCount returns the number of open file descriptors of the process,
+// it returns false if not supported.
+func openFileCount() (int, bool) {
+	entries, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		return 0, false
+	}
+	return len(entries), true
+}
+
+// buildDetails returns the main module and VCS info of the binary.
+func buildDetails() map[string]interface{} {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+	details := map[string]interface{}{
+		"module":        bi.Main.Path,
+		"moduleVersion": bi.Main.Version,
+	}
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			details["vcsRevision"] = s.Value
+		case "vcs.time":
+			details["vcsTime"] = s.Value
+		case "vcs.modified":
+			details["vcsModified"] = s.Value == "true"
+		}
+	}
+	return details
+}
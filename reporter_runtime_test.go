@@ -0,0 +1,54 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuntimeReporter(t *testing.T) {
+	details, err := NewRuntimeReporter(RuntimeReporterOptions{}).CheckDetails(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, runtime.Version(), details["goVersion"])
+	assert.Contains(t, details, "goroutines")
+	assert.Contains(t, details, "heapInuse")
+	if runtime.GOOS == "linux" {
+		assert.Contains(t, details, "openFiles")
+	}
+
+	err = NewRuntimeReporter(RuntimeReporterOptions{GoroutinesWarn: 1}).Check()
+	assert.IsType(t, &SeverityError{}, err)
+	assert.Contains(t, err.Error(), "exceeds warn threshold 1")
+
+	err = NewRuntimeReporter(RuntimeReporterOptions{GoroutinesWarn: 1, HeapFail: 1}).Check()
+	assert.True(t, strings.HasPrefix(err.Error(), "heap in-use bytes"))
+	assert.Contains(t, err.Error(), "; goroutines")
+}
+
+func TestHealthSeverityError(t *testing.T) {
+	collector := newCollector()
+	_ = collector.AddReporter(&Config{
+		Name:     "runtime",
+		Reporter: NewRuntimeReporter(RuntimeReporterOptions{GoroutinesWarn: 1}),
+	})
+	_ = collector.AddReporter(&Config{
+		Name:     "info",
+		Reporter: NewRuntimeReporter(RuntimeReporterOptions{GoroutinesFail: 1}),
+		Severity: SeverityInfo,
+	})
+	collector.runChecks()
+
+	results := collector.Results()
+	assert.Equal(t, SeverityWarning, results["runtime"].Severity)
+	assert.True(t, results["runtime"].SoftFail)
+	assert.Contains(t, results["runtime"].Details, "goroutines")
+	assert.Equal(t, SeverityInfo, results["info"].Severity)
+	assert.Equal(t, Degraded, collector.Status())
+}
@@ -0,0 +1,171 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"aahframe.work"
+)
+
+var _ ReporterDetails = (*SelfReporter)(nil)
+
+// SelfReporterOptions struct holds the configuration of `SelfReporter`.
+type SelfReporterOptions struct {
+	// Path of the application route to be requested, default is `/`. Use a
+	// lightweight route, pointing it to the health check route of the same
+	// collector checks the reporters recursively.
+	Path string
+
+	// ExpectedStatus code of the response, default is `200`.
+	ExpectedStatus int
+
+	// Host header of the request, so that domain routing and middleware
+	// behave as for the clients. Default is the root domain of the app.
+	Host string
+
+	// Timeout of the request, default is 5 seconds.
+	Timeout time.Duration
+
+	// TLSConfig used when the app serves HTTPS, if nil default TLS config is
+	// used. Certificate is verified for the Host, not the loopback address
+	// of the listener.
+	TLSConfig *tls.Config
+}
+
+// SelfReporter struct checks the application's own serving stack by
+// requesting one of its routes through the real listener, TLS and
+// middleware. It catches the process being alive while the listener is
+// wedged. Latency of the request is reported in `CheckResult.Details`.
+type SelfReporter struct {
+	opts    SelfReporterOptions
+	baseURL string
+	client  *http.Client
+}
+
+// NewSelfReporter method returns a `SelfReporter` instance for given aah
+// application, listener address is derived from the app configuration
+// `server.address`, `server.port` and `server.ssl.enable`.
+func NewSelfReporter(app *aah.Application, opts SelfReporterOptions) *SelfReporter {
+	cfg := app.Config()
+	if opts.Host == "" {
+		opts.Host = app.Router().RootDomain().Key
+	}
+	return newSelfReporter(cfg.StringDefault("server.address", ""),
+		cfg.StringDefault("server.port", "8080"),
+		cfg.BoolDefault("server.ssl.enable", false), opts)
+}
+
+func newSelfReporter(address, port string, ssl bool, opts SelfReporterOptions) *SelfReporter {
+	if opts.Path == "" {
+		opts.Path = "/"
+	}
+	if opts.ExpectedStatus == 0 {
+		opts.ExpectedStatus = http.StatusOK
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	if opts.TLSConfig != nil {
+		transport.TLSClientConfig = opts.TLSConfig.Clone()
+	} else {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	if transport.TLSClientConfig.ServerName == "" && len(opts.Host) > 0 {
+		host, _, err := net.SplitHostPort(opts.Host)
+		if err != nil {
+			host = opts.Host
+		}
+		transport.TLSClientConfig.ServerName = host
+	}
+
+	scheme := "http"
+	if ssl {
+		scheme = "https"
+	}
+	var baseURL string
+	if strings.HasPrefix(address, "unix:") {
+		socket := strings.TrimPrefix(address, "unix:")
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", socket)
+		}
+		baseURL = scheme + "://localhost"
+	} else {
+		baseURL = scheme + "://" + net.JoinHostPort(selfHost(address), port)
+	}
+
+	return &SelfReporter{
+		opts:    opts,
+		baseURL: baseURL,
+		client: &http.Client{
+			Transport: transport,
+			// redirect is a valid response of the route, it is not followed
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Check method requests the application route.
+func (r *SelfReporter) Check() error {
+	_, err := r.CheckDetails(context.Background())
+	return err
+}
+
+// CheckDetails method requests the application route with given context
+// and returns the latency as details.
+func (r *SelfReporter) CheckDetails(ctx context.Context) (map[string]interface{}, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.opts.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.baseURL+r.opts.Path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(r.opts.Host) > 0 {
+		req.Host = r.opts.Host
+	}
+	req.Header.Set("User-Agent", "aah-health-self-check")
+
+	start := time.Now()
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	_ = resp.Body.Close()
+
+	details := map[string]interface{}{
+		"url":        r.baseURL + r.opts.Path,
+		"statusCode": resp.StatusCode,
+		"latencyMs":  float64(time.Since(start)) / float64(time.Millisecond),
+	}
+	if resp.StatusCode != r.opts.ExpectedStatus {
+		return details, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return details, nil
+}
+
+// selfHost function returns the loopback address for the listener bound
+// to all interfaces.
+func selfHost(address string) string {
+	switch address {
+	case "", "0.0.0.0":
+		return "127.0.0.1"
+	case "::", "[::]":
+		return "::1"
+	}
+	return strings.Trim(address, "[]")
+}
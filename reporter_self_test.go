@@ -0,0 +1,58 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelfReporter(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "example.com", r.Host)
+		if r.URL.Path == "/ping" {
+			_, _ = w.Write([]byte("pong"))
+			return
+		}
+		http.Redirect(w, r, "/login", http.StatusFound)
+	})
+	ts := httptest.NewTLSServer(handler)
+	defer ts.Close()
+	host, port, _ := net.SplitHostPort(ts.Listener.Addr().String())
+
+	opts := SelfReporterOptions{
+		Path:      "/ping",
+		Host:      "example.com",
+		TLSConfig: ts.Client().Transport.(*http.Transport).TLSClientConfig,
+	}
+	details, err := newSelfReporter(host, port, true, opts).CheckDetails(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 200, details["statusCode"])
+	assert.Equal(t, "https://"+ts.Listener.Addr().String()+"/ping", details["url"])
+
+	opts.Path = "/"
+	assert.Equal(t, "unexpected status code 302", newSelfReporter(host, port, true, opts).Check().Error())
+	opts.ExpectedStatus = http.StatusFound
+	assert.Nil(t, newSelfReporter(host, port, true, opts).Check())
+
+	socket := filepath.Join(t.TempDir(), "app.sock")
+	ln, err := net.Listen("unix", socket)
+	assert.Nil(t, err)
+	us := &httptest.Server{Listener: ln, Config: &http.Server{Handler: handler}}
+	us.Start()
+	defer us.Close()
+	assert.Nil(t, newSelfReporter("unix:"+socket, "", false,
+		SelfReporterOptions{Path: "/ping", Host: "example.com"}).Check())
+
+	assert.Equal(t, "127.0.0.1", selfHost(""))
+	assert.Equal(t, "::1", selfHost("[::]"))
+	assert.Equal(t, "10.0.0.5", selfHost("10.0.0.5"))
+}
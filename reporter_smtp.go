@@ -0,0 +1,130 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/smtp"
+	"time"
+)
+
+var _ ReporterDetails = (*SMTPReporter)(nil)
+
+// SMTPReporterOptions struct holds the configuration of `SMTPReporter`.
+type SMTPReporterOptions struct {
+	// Addr of the mail relay in the form of `host:port`, default is
+	// `localhost:25`.
+	Addr string
+
+	// LocalName sent with EHLO, default is `localhost`.
+	LocalName string
+
+	// TLS connects using implicit TLS, usually port 465. StartTLS upgrades
+	// the plain connection using STARTTLS and fails if relay does not
+	// support it.
+	TLS      bool
+	StartTLS bool
+
+	// TLSConfig used for TLS connection, if nil default TLS config with
+	// server name of Addr is used.
+	TLSConfig *tls.Config
+
+	// Timeout of the check, default is 5 seconds.
+	Timeout time.Duration
+}
+
+// SMTPReporter struct checks the health of a mail relay by issuing EHLO
+// and NOOP, no mail is sent. Latency of the conversation is reported in
+// `CheckResult.Details`.
+type SMTPReporter struct {
+	opts SMTPReporterOptions
+	host string
+}
+
+// NewSMTPReporter method returns a `SMTPReporter` instance for given options.
+func NewSMTPReporter(opts SMTPReporterOptions) *SMTPReporter {
+	if opts.Addr == "" {
+		opts.Addr = "localhost:25"
+	}
+	if opts.LocalName == "" {
+		opts.LocalName = "localhost"
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultNetTimeout
+	}
+	host, _, _ := net.SplitHostPort(opts.Addr)
+	return &SMTPReporter{opts: opts, host: host}
+}
+
+// Check method talks to the mail relay.
+func (r *SMTPReporter) Check() error {
+	_, err := r.CheckDetails(context.Background())
+	return err
+}
+
+// CheckDetails method talks to the mail relay with given context and
+// returns the latency as details.
+func (r *SMTPReporter) CheckDetails(ctx context.Context) (map[string]interface{}, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.opts.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", r.opts.Addr)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	if r.opts.TLS {
+		tlsConn := tls.Client(conn, r.tlsConfig())
+		if err = tlsConn.Handshake(); err != nil {
+			return nil, err
+		}
+		conn = tlsConn
+	}
+
+	c, err := smtp.NewClient(conn, r.host)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.Hello(r.opts.LocalName); err != nil {
+		return nil, err
+	}
+	if r.opts.StartTLS && !r.opts.TLS {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			return nil, errors.New("smtp server does not support STARTTLS")
+		}
+		if err = c.StartTLS(r.tlsConfig()); err != nil {
+			return nil, err
+		}
+	}
+	if err = c.Noop(); err != nil {
+		return nil, err
+	}
+	latency := time.Since(start)
+	_ = c.Quit()
+
+	return map[string]interface{}{
+		"tls":       r.opts.TLS || r.opts.StartTLS,
+		"latencyMs": float64(latency) / float64(time.Millisecond),
+	}, nil
+}
+
+func (r *SMTPReporter) tlsConfig() *tls.Config {
+	if r.opts.TLSConfig == nil {
+		return &tls.Config{ServerName: r.host}
+	}
+	cfg := r.opts.TLSConfig.Clone()
+	if cfg.ServerName == "" {
+		cfg.ServerName = r.host
+	}
+	return cfg
+}
@@ -0,0 +1,74 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"context"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSMTPReporter(t *testing.T) {
+	var busy int32
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveSMTP(conn, atomic.LoadInt32(&busy) == 1)
+		}
+	}()
+
+	r := NewSMTPReporter(SMTPReporterOptions{Addr: ln.Addr().String()})
+	details, err := r.CheckDetails(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, false, details["tls"])
+	assert.Contains(t, details, "latencyMs")
+
+	r = NewSMTPReporter(SMTPReporterOptions{Addr: ln.Addr().String(), StartTLS: true})
+	assert.Equal(t, "smtp server does not support STARTTLS", r.Check().Error())
+
+	atomic.StoreInt32(&busy, 1)
+	r = NewSMTPReporter(SMTPReporterOptions{Addr: ln.Addr().String()})
+	assert.Contains(t, r.Check().Error(), "service not available")
+}
+
+// serveSMTP function answers EHLO, NOOP and QUIT, NOOP fails if busy.
+func serveSMTP(conn net.Conn, busy bool) {
+	tc := textproto.NewConn(conn)
+	defer tc.Close()
+	_ = tc.PrintfLine("220 mail.example.com ESMTP")
+	for {
+		line, err := tc.ReadLine()
+		if err != nil {
+			return
+		}
+		switch cmd := strings.ToUpper(strings.Fields(line)[0]); cmd {
+		case "EHLO":
+			_ = tc.PrintfLine("250-mail.example.com")
+			_ = tc.PrintfLine("250 8BITMIME")
+		case "NOOP":
+			if busy {
+				_ = tc.PrintfLine("421 service not available")
+				return
+			}
+			_ = tc.PrintfLine("250 OK")
+		case "QUIT":
+			_ = tc.PrintfLine("221 bye")
+			return
+		default:
+			_ = tc.PrintfLine("502 command not implemented")
+		}
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+var _ ReporterDetails = (*UpstreamHealthReporter)(nil)
+
+// UpstreamHealthReporterOptions struct holds the configuration of
+// `UpstreamHealthReporter`.
+type UpstreamHealthReporterOptions struct {
+	// URL of the upstream service health check, for e.g.
+	// `https://orders.example.com/healthcheck`. It is required.
+	URL string
+
+	// CriticalOnly considers only the critical checks of the upstream, so
+	// that its degraded status does not affect ours.
+	CriticalOnly bool
+
+	// Timeout of the HTTP request, default is 5 seconds.
+	Timeout time.Duration
+
+	// Header values added to the HTTP request, for e.g. authorization.
+	Header http.Header
+
+	// TLSConfig used for HTTPS requests, if nil default TLS config is used.
+	TLSConfig *tls.Config
+}
+
+// UpstreamHealthReporter struct folds the health of another service into
+// ours, by fetching its health check response. It understands the responses
+// of this package, `FormatJSON` with or without envelope and
+// `FormatHealthJSON`. Upstream degraded status is reported as warning and
+// unhealthy status as failure, failing checks of the upstream are reported
+// in `CheckResult.Details`.
+type UpstreamHealthReporter struct {
+	opts   UpstreamHealthReporterOptions
+	client *http.Client
+}
+
+// upstreamHealth struct holds the status and failing checks of upstream.
+type upstreamHealth struct {
+	status   AggregateStatus
+	critical []string
+	warning  []string
+}
+
+// NewUpstreamHealthReporter method returns a `UpstreamHealthReporter`
+// instance for given options.
+func NewUpstreamHealthReporter(opts UpstreamHealthReporterOptions) *UpstreamHealthReporter {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if opts.TLSConfig != nil {
+		transport.TLSClientConfig = opts.TLSConfig
+	}
+	return &UpstreamHealthReporter{
+		opts:   opts,
+		client: &http.Client{Transport: transport},
+	}
+}
+
+// Check method fetches the upstream health.
+func (r *UpstreamHealthReporter) Check() error {
+	_, err := r.CheckDetails(context.Background())
+	return err
+}
+
+// CheckDetails method fetches the upstream health with given context and
+// returns its status and failing checks as details.
+func (r *UpstreamHealthReporter) CheckDetails(ctx context.Context) (map[string]interface{}, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.opts.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.opts.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", HealthJSONContentType+", application/json")
+	for k, v := range r.opts.Header {
+		req.Header[k] = v
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// unhealthy upstream responds with non 2xx status code, so body is
+	// interpreted regardless of it.
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPBodySize))
+	if err != nil {
+		return nil, err
+	}
+	uh, err := parseUpstreamHealth(body)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	details := map[string]interface{}{
+		"status": uh.status,
+	}
+	if len(uh.critical) > 0 {
+		details["failedCritical"] = uh.critical
+	}
+	if len(uh.warning) > 0 {
+		details["failedWarning"] = uh.warning
+	}
+	switch {
+	case uh.status == Unhealthy:
+		return details, upstreamError(uh.status, uh.critical)
+	case uh.status == Degraded && !r.opts.CriticalOnly:
+		return details, &SeverityError{
+			Severity: SeverityWarning,
+			Err:      upstreamError(uh.status, uh.warning),
+		}
+	}
+	return details, nil
+}
+
+func upstreamError(status AggregateStatus, checks []string) error {
+	if len(checks) == 0 {
+		return fmt.Errorf("upstream is %s", status)
+	}
+	return fmt.Errorf("upstream is %s, failing checks: %s", status, strings.Join(checks, ", "))
+}
+
+// parseUpstreamHealth function parses the health check response body of
+// any format supported by this package.
+func parseUpstreamHealth(body []byte) (*upstreamHealth, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, err
+	}
+	var status string
+	if raw, found := fields["status"]; !found || json.Unmarshal(raw, &status) != nil {
+		// FormatJSON without envelope, check results keyed by name
+		var results map[string]CheckResult
+		if err := json.Unmarshal(body, &results); err != nil {
+			return nil, err
+		}
+		return upstreamFromResults(results), nil
+	}
+
+	switch status {
+	case healthJSONPass, healthJSONWarn, healthJSONFail:
+		var hj healthJSON
+		if err := json.Unmarshal(body, &hj); err != nil {
+			return nil, err
+		}
+		return upstreamFromHealthJSON(&hj), nil
+	case string(Healthy), string(Degraded), string(Unhealthy), string(Starting):
+		var env struct {
+			Status AggregateStatus        `json:"status"`
+			Checks map[string]CheckResult `json:"checks"`
+		}
+		if err := json.Unmarshal(body, &env); err != nil {
+			return nil, err
+		}
+		if len(env.Checks) == 0 { // summary exposure
+			return &upstreamHealth{status: env.Status}, nil
+		}
+		return upstreamFromResults(env.Checks), nil
+	}
+	return nil, fmt.Errorf("unknown upstream status '%s'", status)
+}
+
+func upstreamFromResults(results map[string]CheckResult) *upstreamHealth {
+	uh := &upstreamHealth{status: Healthy}
+	for name, result := range results {
+		if result.IsOK() || result.Maintenance || result.Muted || result.Skipped {
+			continue
+		}
+		switch result.severity() {
+		case SeverityCritical:
+			uh.critical = append(uh.critical, name)
+		case SeverityWarning:
+			uh.warning = append(uh.warning, name)
+		}
+	}
+	return uh.evaluate()
+}
+
+func upstreamFromHealthJSON(hj *healthJSON) *upstreamHealth {
+	uh := &upstreamHealth{status: Healthy}
+	if len(hj.Checks) == 0 { // summary exposure
+		switch hj.Status {
+		case healthJSONWarn:
+			uh.status = Degraded
+		case healthJSONFail:
+			uh.status = Unhealthy
+		}
+		return uh
+	}
+	for key, entries := range hj.Checks {
+		name, _, _ := strings.Cut(key, ":")
+		for _, e := range entries {
+			switch e.Status {
+			case healthJSONFail:
+				uh.critical = append(uh.critical, name)
+			case healthJSONWarn:
+				uh.warning = append(uh.warning, name)
+			}
+		}
+	}
+	return uh.evaluate()
+}
+
+func (uh *upstreamHealth) evaluate() *upstreamHealth {
+	sort.Strings(uh.critical)
+	sort.Strings(uh.warning)
+	switch {
+	case len(uh.critical) > 0:
+		uh.status = Unhealthy
+	case len(uh.warning) > 0:
+		uh.status = Degraded
+	}
+	return uh
+}
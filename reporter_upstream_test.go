@@ -0,0 +1,65 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpstreamHealthReporter(t *testing.T) {
+	var resp atomic.Value
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v := resp.Load().([]interface{})
+		w.WriteHeader(v[0].(int))
+		_, _ = w.Write([]byte(v[1].(string)))
+	}))
+	defer ts.Close()
+
+	testcases := []struct {
+		code         int
+		body         string
+		criticalOnly bool
+		err          string
+	}{
+		{code: 200, body: `{"db":{"status":"OK"},"cache":{"status":"OK"}}`},
+		{code: 200, body: `{"db":{"status":"OK"},"cache":{"status":"KO","severity":"warning"}}`,
+			err: "upstream is degraded, failing checks: cache"},
+		{code: 200, body: `{"db":{"status":"OK"},"cache":{"status":"KO","softFail":true}}`, criticalOnly: true},
+		{code: 503, body: `{"db":{"status":"KO"},"queue":{"status":"KO","muted":true}}`,
+			err: "upstream is unhealthy, failing checks: db"},
+		{code: 503, body: `{"status":"unhealthy","service":"orders","checks":{"db":{"status":"KO"}}}`,
+			err: "upstream is unhealthy, failing checks: db"},
+		{code: 200, body: `{"status":"degraded"}`, err: "upstream is degraded"},
+		{code: 200, body: `{"status":"degraded"}`, criticalOnly: true},
+		{code: 200, body: `{"status":"pass","checks":{"db:responseTime":[{"status":"pass"}]}}`},
+		{code: 503, body: `{"status":"fail","checks":{"db:responseTime":[{"status":"fail"}],
+			"cache:responseTime":[{"status":"warn"}]}}`, err: "upstream is unhealthy, failing checks: db"},
+		{code: 503, body: `{"status":"fail"}`, criticalOnly: true, err: "upstream is unhealthy"},
+		{code: 502, body: `bad gateway`, err: "unexpected status code 502"},
+	}
+	for _, tc := range testcases {
+		resp.Store([]interface{}{tc.code, tc.body})
+		r := NewUpstreamHealthReporter(UpstreamHealthReporterOptions{URL: ts.URL, CriticalOnly: tc.criticalOnly})
+		err := r.Check()
+		if tc.err == "" {
+			assert.Nil(t, err, tc.body)
+		} else {
+			assert.Equal(t, tc.err, err.Error(), tc.body)
+		}
+	}
+
+	resp.Store([]interface{}{200, `{"db":{"status":"KO","severity":"warning"}}`})
+	details, err := NewUpstreamHealthReporter(UpstreamHealthReporterOptions{URL: ts.URL}).
+		CheckDetails(context.Background())
+	assert.IsType(t, &SeverityError{}, err)
+	assert.Equal(t, Degraded, details["status"])
+	assert.Equal(t, []string{"db"}, details["failedWarning"])
+}
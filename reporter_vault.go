@@ -0,0 +1,134 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var _ ReporterDetails = (*VaultReporter)(nil)
+
+// VaultReporterOptions struct holds the configuration of `VaultReporter`.
+type VaultReporterOptions struct {
+	// URL of the Vault server, for e.g. `https://vault.example.com:8200`.
+	// It is required.
+	URL string
+
+	// StandbyOK treats the standby node as healthy, PerfStandbyOK treats the
+	// performance standby node as healthy and DRSecondaryOK treats the
+	// disaster recovery secondary node as healthy. They fail otherwise.
+	StandbyOK     bool
+	PerfStandbyOK bool
+	DRSecondaryOK bool
+
+	// Timeout of the HTTP request, default is 5 seconds.
+	Timeout time.Duration
+
+	// TLSConfig used for HTTPS requests, if nil default TLS config is used.
+	TLSConfig *tls.Config
+}
+
+// VaultReporter struct checks the health of a HashiCorp Vault server using
+// `/v1/sys/health` API. Sealed and uninitialized server fails, standby
+// servers fail unless tolerated by the options. Server state is reported
+// in `CheckResult.Details`.
+type VaultReporter struct {
+	opts   VaultReporterOptions
+	client *http.Client
+}
+
+// NewVaultReporter method returns a `VaultReporter` instance for given options.
+func NewVaultReporter(opts VaultReporterOptions) *VaultReporter {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if opts.TLSConfig != nil {
+		transport.TLSClientConfig = opts.TLSConfig
+	}
+	return &VaultReporter{
+		opts:   opts,
+		client: &http.Client{Transport: transport},
+	}
+}
+
+// Check method queries the Vault server health.
+func (r *VaultReporter) Check() error {
+	_, err := r.CheckDetails(context.Background())
+	return err
+}
+
+// vaultHealth struct holds the fields of `/v1/sys/health` response.
+type vaultHealth struct {
+	Initialized                bool   `json:"initialized"`
+	Sealed                     bool   `json:"sealed"`
+	Standby                    bool   `json:"standby"`
+	PerformanceStandby         bool   `json:"performance_standby"`
+	ReplicationDRMode          string `json:"replication_dr_mode"`
+	ReplicationPerformanceMode string `json:"replication_performance_mode"`
+	Version                    string `json:"version"`
+	ClusterName                string `json:"cluster_name"`
+}
+
+// CheckDetails method queries the Vault server health with given context
+// and returns the server state as details.
+func (r *VaultReporter) CheckDetails(ctx context.Context) (map[string]interface{}, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.opts.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		strings.TrimSuffix(r.opts.URL, "/")+"/v1/sys/health", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Vault reports the state with non 2xx status codes as well, for e.g.
+	// 429 standby, 503 sealed, so body is interpreted regardless of it.
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPBodySize))
+	if err != nil {
+		return nil, err
+	}
+	var vh vaultHealth
+	if err = json.Unmarshal(body, &vh); err != nil {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	drSecondary := vh.ReplicationDRMode == "secondary"
+	details := map[string]interface{}{
+		"initialized":        vh.Initialized,
+		"sealed":             vh.Sealed,
+		"standby":            vh.Standby,
+		"performanceStandby": vh.PerformanceStandby,
+		"drSecondary":        drSecondary,
+		"version":            vh.Version,
+		"clusterName":        vh.ClusterName,
+	}
+	switch {
+	case !vh.Initialized:
+		return details, errors.New("vault is not initialized")
+	case vh.Sealed:
+		return details, errors.New("vault is sealed")
+	case drSecondary && !r.opts.DRSecondaryOK:
+		return details, errors.New("vault is disaster recovery secondary")
+	case vh.PerformanceStandby && !r.opts.PerfStandbyOK:
+		return details, errors.New("vault is performance standby")
+	case vh.Standby && !vh.PerformanceStandby && !r.opts.StandbyOK:
+		return details, errors.New("vault is standby")
+	}
+	return details, nil
+}
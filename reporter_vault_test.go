@@ -0,0 +1,52 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVaultReporter(t *testing.T) {
+	var resp atomic.Value
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/sys/health", r.URL.Path)
+		v := resp.Load().([]interface{})
+		w.WriteHeader(v[0].(int))
+		_, _ = w.Write([]byte(v[1].(string)))
+	}))
+	defer ts.Close()
+
+	testcases := []struct {
+		code int
+		body string
+		opts VaultReporterOptions
+		err  string
+	}{
+		{code: 200, body: `{"initialized":true,"sealed":false,"standby":false}`},
+		{code: 501, body: `{"initialized":false,"sealed":true}`, err: "vault is not initialized"},
+		{code: 503, body: `{"initialized":true,"sealed":true}`, err: "vault is sealed"},
+		{code: 429, body: `{"initialized":true,"standby":true}`, err: "vault is standby"},
+		{code: 429, body: `{"initialized":true,"standby":true}`, opts: VaultReporterOptions{StandbyOK: true}},
+		{code: 473, body: `{"initialized":true,"standby":true,"performance_standby":true}`, opts: VaultReporterOptions{StandbyOK: true}, err: "vault is performance standby"},
+		{code: 473, body: `{"initialized":true,"standby":true,"performance_standby":true}`, opts: VaultReporterOptions{PerfStandbyOK: true}},
+		{code: 472, body: `{"initialized":true,"replication_dr_mode":"secondary"}`, err: "vault is disaster recovery secondary"},
+		{code: 502, body: `bad gateway`, err: "unexpected status code 502"},
+	}
+	for _, tc := range testcases {
+		resp.Store([]interface{}{tc.code, tc.body})
+		tc.opts.URL = ts.URL
+		err := NewVaultReporter(tc.opts).Check()
+		if tc.err == "" {
+			assert.Nil(t, err, tc.body)
+		} else {
+			assert.Equal(t, tc.err, err.Error(), tc.body)
+		}
+	}
+}
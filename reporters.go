@@ -0,0 +1,72 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"time"
+)
+
+// ReporterInfo struct describes a registered reporter along with its
+// current status, for e.g. to audit what each service actually checks.
+// Refer to `Collector.Reporters`.
+type ReporterInfo struct {
+	Name      string   `json:"name"`
+	Type      string   `json:"type"`
+	Tags      []string `json:"tags,omitempty"`
+	Severity  Severity `json:"severity"`
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// Interval is the collector's periodic check interval and Timeout is
+	// the check timeout of `ReporterContext` reporters, 0 means no timeout.
+	Interval time.Duration `json:"interval"`
+	Timeout  time.Duration `json:"timeout"`
+
+	// Status and LastChecked are empty until the reporter is checked.
+	Status      Status    `json:"status,omitempty"`
+	LastChecked time.Time `json:"lastChecked"`
+	Muted       bool      `json:"muted,omitempty"`
+}
+
+// Reporters method returns the registered reporters of the collector sorted
+// by name.
+func (c *Collector) Reporters() []ReporterInfo {
+	results := c.load().results
+	interval := time.Duration(atomic.LoadInt64(&c.period))
+
+	c.mu.RLock()
+	configs := make([]*Config, 0, len(c.reporters))
+	for _, rc := range c.reporters {
+		configs = append(configs, rc)
+	}
+	muted := make(map[string]bool, len(c.muted))
+	for name, m := range c.muted {
+		muted[name] = m
+	}
+	c.mu.RUnlock()
+	sort.Slice(configs, func(i, j int) bool { return configs[i].Name < configs[j].Name })
+
+	infos := make([]ReporterInfo, 0, len(configs))
+	for _, rc := range configs {
+		info := ReporterInfo{
+			Name:      rc.Name,
+			Type:      fmt.Sprintf("%T", rc.Reporter),
+			Tags:      rc.Tags,
+			Severity:  rc.severity(),
+			DependsOn: rc.DependsOn,
+			Interval:  interval,
+			Timeout:   c.reporterTimeout(rc),
+			Muted:     muted[rc.Name],
+		}
+		if result, found := results[rc.Name]; found {
+			info.Status = result.Status
+			info.LastChecked = result.LastChecked
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
@@ -0,0 +1,54 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthReporters(t *testing.T) {
+	collector := newCollector()
+	collector.timeout = 5 * time.Second
+	collector.SetInterval(30 * time.Second)
+	assert.Empty(t, collector.Reporters())
+
+	db := &toggleReporter{}
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: db, Tags: []string{"core"}, Timeout: 2 * time.Second})
+	_ = collector.AddReporter(&Config{Name: "cache", Reporter: &toggleReporter{}, Severity: SeverityWarning, DependsOn: []string{"db"}})
+	assert.Nil(t, collector.Mute("cache"))
+
+	infos := collector.Reporters()
+	if assert.Len(t, infos, 2) {
+		assert.Equal(t, ReporterInfo{
+			Name:      "cache",
+			Type:      "*health.toggleReporter",
+			Severity:  SeverityWarning,
+			DependsOn: []string{"db"},
+			Interval:  30 * time.Second,
+			Timeout:   5 * time.Second,
+			Muted:     true,
+		}, infos[0])
+		assert.Equal(t, "db", infos[1].Name)
+		assert.Equal(t, []string{"core"}, infos[1].Tags)
+		assert.Equal(t, SeverityCritical, infos[1].Severity)
+		assert.Equal(t, 2*time.Second, infos[1].Timeout)
+		assert.Empty(t, infos[1].Status)
+	}
+
+	db.set(errors.New("down"))
+	collector.runChecks()
+	infos = collector.Reporters()
+	assert.Equal(t, StatusKO, infos[1].Status)
+	assert.False(t, infos[1].LastChecked.IsZero())
+
+	b, err := json.Marshal(infos[1])
+	assert.Nil(t, err)
+	assert.Contains(t, string(b), `"name":"db","type":"*health.toggleReporter","tags":["core"],"severity":"critical"`)
+}
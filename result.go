@@ -0,0 +1,156 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import "time"
+
+// Status type represents the health status of a reporter.
+type Status string
+
+// Health status values of a reporter.
+const (
+	StatusOK Status = "OK"
+	StatusKO Status = "KO"
+)
+
+// AggregateStatus type represents the overall health status of the collector.
+type AggregateStatus string
+
+// Aggregate health status values of the collector.
+const (
+	// Healthy means all the reporters are healthy.
+	Healthy AggregateStatus = "healthy"
+
+	// Degraded means only warning severity reporters are unhealthy.
+	Degraded AggregateStatus = "degraded"
+
+	// Unhealthy means at least one critical severity reporter is unhealthy.
+	Unhealthy AggregateStatus = "unhealthy"
+
+	// Starting means the collector is within its startup grace period and
+	// not all the critical reporters have passed yet, refer to
+	// `WithStartupGracePeriod`.
+	Starting AggregateStatus = "starting"
+)
+
+// Severity type represents the impact of a reporter's failure on the
+// collector's aggregate status.
+type Severity string
+
+// Severity levels of a reporter.
+const (
+	// SeverityCritical failure makes the collector `Unhealthy`.
+	SeverityCritical Severity = "critical"
+
+	// SeverityWarning failure makes the collector `Degraded`.
+	SeverityWarning Severity = "warning"
+
+	// SeverityInfo failure is only reported, it does not affect the
+	// aggregate status.
+	SeverityInfo Severity = "info"
+)
+
+// severityRank is the order of severity levels, lower is less severe.
+var severityRank = map[Severity]int{
+	SeverityInfo:     0,
+	SeverityWarning:  1,
+	SeverityCritical: 2,
+}
+
+// SeverityError struct is returned by the reporter to lower the severity of
+// a particular failure, for e.g. threshold exceeded is a warning but not
+// critical yet. Reporter's configured severity is never escalated.
+type SeverityError struct {
+	Severity Severity
+	Err      error
+}
+
+// Error method returns the error message of underlying error.
+func (e *SeverityError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap method returns the underlying error.
+func (e *SeverityError) Unwrap() error {
+	return e.Err
+}
+
+// CheckResult struct holds the outcome of the reporter's latest health check.
+type CheckResult struct {
+	Status      Status        `json:"status"`
+	Error       string        `json:"error,omitempty"`
+	Duration    time.Duration `json:"duration"`
+	LastChecked time.Time     `json:"lastChecked"`
+	SoftFail    bool          `json:"softFail"` // true if severity is not critical
+	Severity    Severity      `json:"severity,omitempty"`
+
+	// ErrorType is the machine-readable class of the error, refer to
+	// `ErrorType`.
+	ErrorType ErrorType `json:"errorType,omitempty"`
+
+	// Details holds additional information of the check, for e.g. stack
+	// trace of the recovered panic.
+	Details map[string]interface{} `json:"details,omitempty"`
+
+	// Availability is the rolling success ratio of the reporter's checks,
+	// it is set only in the health check response.
+	Availability *Availability `json:"availability,omitempty"`
+
+	// Metadata of the reporter, refer to `Config.Metadata`.
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// Slow is true when the check took longer than reporter's slow threshold.
+	Slow bool `json:"slow,omitempty"`
+
+	// Maintenance is true when the check ran within the reporter's
+	// maintenance window, its failure does not affect the global health.
+	Maintenance bool `json:"maintenance,omitempty"`
+
+	// Muted is true when the reporter is muted by operator, its failure
+	// does not affect the global health. Refer to `Collector.Mute`.
+	Muted bool `json:"muted,omitempty"`
+
+	// Flapping is true while the reporter's status is changing frequently,
+	// status is held steady meanwhile. Refer to `Config.FlapWindow`.
+	Flapping bool `json:"flapping,omitempty"`
+
+	// Skipped is true when the check is skipped because of the unhealthy
+	// dependency, refer to `Config.DependsOn`.
+	Skipped bool `json:"skipped,omitempty"`
+
+	// CircuitOpen is true when the reporter's checks are backed off due to
+	// persistent failure, refer to `Config.CircuitOpenAfter`.
+	CircuitOpen bool `json:"circuitOpen,omitempty"`
+
+	// Restored is true when the result is restored from the store at
+	// startup and the reporter is not checked yet, refer to `WithRestore`.
+	Restored bool `json:"restored,omitempty"`
+
+	// Stale is true when the result is older than twice the check interval,
+	// it is evaluated while responding.
+	Stale bool `json:"stale,omitempty"`
+
+	// ConsecutiveFailures and ConsecutiveSuccesses are the count of
+	// consecutive check outcomes, used with reporter's thresholds.
+	ConsecutiveFailures  int `json:"consecutiveFailures,omitempty"`
+	ConsecutiveSuccesses int `json:"consecutiveSuccesses,omitempty"`
+}
+
+// IsOK method returns true if the check result status is `OK`.
+func (cr CheckResult) IsOK() bool {
+	return cr.Status == StatusOK
+}
+
+// severity method returns the result severity, it falls back to `SoftFail`
+// when severity is not set.
+func (cr CheckResult) severity() Severity {
+	if cr.Severity != "" {
+		return cr.Severity
+	}
+	if cr.SoftFail {
+		return SeverityWarning
+	}
+	return SeverityCritical
+}
@@ -0,0 +1,49 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import "math"
+
+// HeaderHealthScore is the response header which carries the weighted
+// health score of the collector, refer to `Collector.Score`.
+const HeaderHealthScore = "X-Health-Score"
+
+// Score method returns the weighted health score of the collector from 0
+// to 100, that is the percentage of weight of healthy reporters. Reporters
+// in maintenance or muted are counted as healthy, same as aggregate status.
+// Refer to `Config.Weight`.
+func (c *Collector) Score() float64 {
+	return c.load().score
+}
+
+// computeScore method returns the weighted health score of given results
+// rounded to one decimal, it is 100 if there are no reporters. Caller must
+// hold the lock.
+func (c *Collector) computeScore(results map[string]CheckResult) float64 {
+	var total, healthy float64
+	for name, result := range results {
+		rc, found := c.reporters[name]
+		if !found {
+			continue
+		}
+		weight := rc.weight()
+		total += weight
+		if result.IsOK() || result.Maintenance || result.Muted {
+			healthy += weight
+		}
+	}
+	if total == 0 {
+		return 100
+	}
+	return math.Round(healthy/total*1000) / 10
+}
+
+// weight method returns the weight of the reporter in health score.
+func (rc *Config) weight() float64 {
+	if rc.Weight <= 0 {
+		return 1
+	}
+	return rc.Weight
+}
@@ -0,0 +1,80 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthScore(t *testing.T) {
+	collector := newCollector()
+	assert.Equal(t, 100.0, collector.Score())
+
+	db, cache, search := &toggleReporter{}, &toggleReporter{}, &toggleReporter{}
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: db, Weight: 3, Tags: []string{"core"}})
+	_ = collector.AddReporter(&Config{Name: "cache", Reporter: cache, Severity: SeverityWarning})
+	_ = collector.AddReporter(&Config{Name: "search", Reporter: search, Severity: SeverityWarning, Weight: 2})
+	collector.runChecks()
+	assert.Equal(t, 100.0, collector.Score())
+
+	cache.set(errors.New("down"))
+	collector.runChecks()
+	assert.Equal(t, 83.3, collector.Score())
+	assert.Equal(t, Degraded, collector.Status())
+	assert.Equal(t, 100.0, collector.tagged(collector.load(), []string{"core"}).score)
+
+	search.set(errors.New("down"))
+	collector.runChecks()
+	assert.Equal(t, 50.0, collector.Score())
+
+	assert.Nil(t, collector.Mute("search"))
+	assert.Equal(t, 83.3, collector.Score())
+}
+
+func TestHealthScoreThreshold(t *testing.T) {
+	collector := newCollector()
+	db, cache := &toggleReporter{}, &toggleReporter{}
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: db, Weight: 4})
+	_ = collector.AddReporter(&Config{Name: "cache", Reporter: cache, Weight: 1})
+	collector.runChecks()
+
+	_, err := collector.HandlerWithOptions(RegisterOptions{ScoreThreshold: 101})
+	assert.NotNil(t, err)
+
+	h, _ := collector.HandlerWithOptions(RegisterOptions{ScoreThreshold: 75})
+	get := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthcheck", nil))
+		return w
+	}
+
+	// unhealthy but above the threshold
+	cache.set(errors.New("down"))
+	collector.runChecks()
+	w := get()
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, string(Unhealthy), w.Header().Get(HeaderHealthStatus))
+	assert.Equal(t, "80", w.Header().Get(HeaderHealthScore))
+
+	db.set(errors.New("down"))
+	collector.runChecks()
+	w = get()
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "0", w.Header().Get(HeaderHealthScore))
+
+	// draining is not reflected by the score
+	db.set(nil)
+	cache.set(nil)
+	collector.runChecks()
+	collector.SetDraining(true)
+	assert.Equal(t, http.StatusServiceUnavailable, get().Code)
+	collector.SetDraining(false)
+	assert.Equal(t, http.StatusOK, get().Code)
+}
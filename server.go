@@ -0,0 +1,71 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// ListenAndServe method starts a dedicated HTTP server on given address
+// serving `/healthcheck`, `/healthcheck/live`, `/healthcheck/ready`,
+// `/healthcheck/metrics`, `/healthcheck/history` and `/ping`, so that probes
+// don't share the application listener. Address with prefix `unix:` listens
+// on the unix socket, e.g. `unix:/var/run/app-health.sock`.
+//
+// It blocks until the collector is stopped, then the server is shutdown
+// gracefully and it returns nil.
+func (c *Collector) ListenAndServe(addr string) error {
+	network := "tcp"
+	if strings.HasPrefix(addr, "unix:") {
+		network, addr = "unix", strings.TrimPrefix(addr, "unix:")
+		// remove stale socket file of the previous run
+		if fi, err := os.Stat(addr); err == nil && fi.Mode()&os.ModeSocket != 0 {
+			_ = os.Remove(addr)
+		}
+	}
+	ln, err := net.Listen(network, addr)
+	if err != nil {
+		return err
+	}
+	return c.Serve(ln)
+}
+
+// Serve method serves the health endpoints on given listener, refer to
+// `Collector.ListenAndServe`.
+func (c *Collector) Serve(ln net.Listener) error {
+	mux := http.NewServeMux()
+	mux.Handle("/healthcheck", c.Handler())
+	mux.Handle("/healthcheck/", c.Handler())
+	mux.Handle("/ping", c.PingHandler())
+	srv := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       10 * time.Second,
+		WriteTimeout:      10 * time.Second,
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-c.ctx.Done():
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_ = srv.Shutdown(ctx)
+		case <-done:
+		}
+	}()
+
+	if err := srv.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
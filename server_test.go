@@ -0,0 +1,66 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthServe(t *testing.T) {
+	collector := newCollector()
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: &toggleReporter{}})
+	collector.runChecks()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	errCh := make(chan error, 1)
+	go func() { errCh <- collector.Serve(ln) }()
+
+	for _, p := range []string{"/healthcheck", "/healthcheck/ready", "/ping"} {
+		resp, err := http.Get("http://" + ln.Addr().String() + p)
+		assert.Nil(t, err)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode, p)
+	}
+
+	collector.Stop()
+	assert.Nil(t, <-errCh)
+}
+
+func TestHealthListenAndServeUnix(t *testing.T) {
+	collector := newCollector()
+	sock := filepath.Join(t.TempDir(), "health.sock")
+	errCh := make(chan error, 1)
+	go func() { errCh <- collector.ListenAndServe("unix:" + sock) }()
+
+	client := &http.Client{Transport: &http.Transport{
+		Dial: func(_, _ string) (net.Conn, error) { return net.Dial("unix", sock) },
+	}}
+	var resp *http.Response
+	var err error
+	for i := 0; i < 50; i++ {
+		if resp, err = client.Get("http://health/ping"); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Nil(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	assert.Equal(t, "pong!\n", string(body))
+
+	collector.Stop()
+	assert.Nil(t, <-errCh)
+
+	assert.NotNil(t, collector.ListenAndServe("256.0.0.1:bad"))
+}
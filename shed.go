@@ -0,0 +1,87 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	aah "aahframe.work"
+)
+
+// ShedOptions struct holds the options of load shedding middleware, refer
+// to `Collector.ShedMiddleware`.
+type ShedOptions struct {
+	// Paths are the route path prefixes to shed, empty means all the routes.
+	Paths []string
+
+	// ExcludePaths are the route path prefixes never shed. Health routes
+	// registered by the collectors and static routes are always excluded.
+	ExcludePaths []string
+
+	// RetryAfter is the value of `Retry-After` response header, default
+	// is 30 seconds.
+	RetryAfter time.Duration
+
+	// ShedOnDegraded sheds the load when only soft fail reporters are
+	// unhealthy too.
+	ShedOnDegraded bool
+}
+
+// ShedMiddleware method returns the aah middleware which responds with
+// `503 Service Unavailable` along with `Retry-After` header while the
+// collector reports unhealthy, so that the application stops accepting
+// expensive requests when its critical dependencies are down.
+//
+//	app.HTTPEngine().Middlewares(
+//	    aah.RouteMiddleware,
+//	    collector.ShedMiddleware(health.ShedOptions{Paths: []string{"/api"}}),
+//	    aah.CORSMiddleware,
+//	    ...
+//	)
+func (c *Collector) ShedMiddleware(opts ShedOptions) aah.MiddlewareFunc {
+	if opts.RetryAfter <= 0 {
+		opts.RetryAfter = 30 * time.Second
+	}
+	retryAfter := strconv.Itoa(int((opts.RetryAfter + time.Second - 1) / time.Second))
+	return func(ctx *aah.Context, m *aah.Middleware) {
+		if !ctx.IsStaticRoute() && c.shouldShed(opts, requestDomain(ctx), ctx.Req.Path) {
+			ctx.Reply().ServiceUnavailable().
+				Header("Retry-After", retryAfter).
+				Text("503 Service Unavailable\n")
+			return
+		}
+		m.Next(ctx)
+	}
+}
+
+// shouldShed method returns true if the request for given domain and path
+// has to be shed as per collector's current status.
+func (c *Collector) shouldShed(opts ShedOptions, domain, reqPath string) bool {
+	switch c.Status() {
+	case Unhealthy:
+	case Degraded:
+		if !opts.ShedOnDegraded {
+			return false
+		}
+	default:
+		return false
+	}
+	if registry.lookupPath(domain, reqPath) != nil || hasPathPrefix(reqPath, opts.ExcludePaths) {
+		return false
+	}
+	return len(opts.Paths) == 0 || hasPathPrefix(reqPath, opts.Paths)
+}
+
+func hasPathPrefix(p string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		prefix = strings.TrimSuffix(prefix, "/")
+		if p == prefix || strings.HasPrefix(p, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthShouldShed(t *testing.T) {
+	useTestRegistry(t)
+	collector := newCollector()
+	hard, soft := &toggleReporter{}, &toggleReporter{}
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: hard})
+	_ = collector.AddReporter(&Config{Name: "cache", Reporter: soft, SoftFail: true})
+	registry.add(collector, RegisterOptions{Name: "shed"}, []string{"/healthcheck/shed"})
+
+	opts := ShedOptions{Paths: []string{"/api"}, ExcludePaths: []string{"/api/status/"}}
+	collector.runChecks()
+	assert.False(t, collector.shouldShed(opts, "", "/api/orders"))
+
+	soft.set(errors.New("down"))
+	collector.runChecks()
+	assert.False(t, collector.shouldShed(opts, "", "/api/orders"))
+	opts.ShedOnDegraded = true
+	assert.True(t, collector.shouldShed(opts, "", "/api/orders"))
+
+	hard.set(errors.New("down"))
+	collector.runChecks()
+	testcases := []struct {
+		path string
+		shed bool
+	}{
+		{path: "/api", shed: true},
+		{path: "/api/orders", shed: true},
+		{path: "/apis", shed: false},
+		{path: "/api/status", shed: false},
+		{path: "/api/status/db", shed: false},
+		{path: "/home", shed: false},
+		{path: "/healthcheck/shed", shed: false},
+	}
+	for _, tc := range testcases {
+		assert.Equal(t, tc.shed, collector.shouldShed(opts, "", tc.path), tc.path)
+	}
+
+	assert.True(t, collector.shouldShed(ShedOptions{}, "", "/home"))
+	assert.False(t, collector.shouldShed(ShedOptions{}, "", "/healthcheck/shed"))
+}
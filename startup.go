@@ -0,0 +1,45 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// IsStarting method returns true while the collector is within its startup
+// grace period and not all the critical reporters have passed once yet.
+// Meanwhile `/healthcheck` reports `Starting`, refer to
+// `WithStartupGracePeriod`.
+func (c *Collector) IsStarting() bool {
+	if c.startupGrace <= 0 || atomic.LoadInt32(&c.started) == 1 {
+		return false
+	}
+	if !c.clock.Now().Before(c.createdAt.Add(c.startupGrace)) {
+		atomic.StoreInt32(&c.started, 1)
+		return false
+	}
+	return true
+}
+
+// updateStartup method completes the startup once every critical reporter
+// has passed in given results. Caller must hold the lock.
+func (c *Collector) updateStartup(results map[string]CheckResult) {
+	if c.startupGrace <= 0 || atomic.LoadInt32(&c.started) == 1 {
+		return
+	}
+	for name, rc := range c.reporters {
+		if rc.severity() != SeverityCritical {
+			continue
+		}
+		if result, found := results[name]; !found || !result.IsOK() {
+			return
+		}
+	}
+	atomic.StoreInt32(&c.started, 1)
+	if logger := c.logger; logger != nil {
+		logger.Infof("health: startup completed in %s", c.clock.Now().Sub(c.createdAt).Round(time.Millisecond))
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthStartupGracePeriod(t *testing.T) {
+	now := time.Date(2019, 3, 1, 10, 0, 0, 0, time.UTC)
+	collector := newCollector()
+	collector.clock = fixedClock(now)
+	collector.createdAt = now
+	WithStartupGracePeriod(time.Minute)(collector)
+
+	db, cache := &toggleReporter{err: errors.New("connecting")}, &toggleReporter{err: errors.New("down")}
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: db})
+	_ = collector.AddReporter(&Config{Name: "cache", Reporter: cache, Severity: SeverityWarning})
+	collector.runChecks()
+	assert.True(t, collector.IsStarting())
+
+	_, err := collector.HandlerWithOptions(RegisterOptions{StartingStatusCode: 100})
+	assert.NotNil(t, err)
+
+	h, _ := collector.HandlerWithOptions(RegisterOptions{StartingStatusCode: http.StatusAccepted})
+	get := func(target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+		return w
+	}
+
+	w := get("/healthcheck")
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Equal(t, string(Starting), w.Header().Get(HeaderHealthStatus))
+	assert.Equal(t, http.StatusServiceUnavailable, get("/healthcheck/ready").Code)
+
+	// warning reporter does not hold the startup
+	db.set(nil)
+	collector.runChecks()
+	assert.False(t, collector.IsStarting())
+	w = get("/healthcheck")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, string(Degraded), w.Header().Get(HeaderHealthStatus))
+
+	// startup completes once
+	db.set(errors.New("down"))
+	collector.runChecks()
+	assert.Equal(t, http.StatusServiceUnavailable, get("/healthcheck").Code)
+}
+
+func TestHealthStartupGracePeriodElapsed(t *testing.T) {
+	now := time.Date(2019, 3, 1, 10, 0, 0, 0, time.UTC)
+	collector := newCollector()
+	collector.clock = fixedClock(now)
+	collector.createdAt = now
+	assert.False(t, collector.IsStarting())
+
+	WithStartupGracePeriod(time.Minute)(collector)
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: &toggleReporter{err: errors.New("down")}})
+	collector.runChecks()
+	assert.True(t, collector.IsStarting())
+
+	collector.clock = fixedClock(now.Add(time.Minute))
+	assert.False(t, collector.IsStarting())
+	_, status := collector.currentStatus(false, nil)
+	assert.Equal(t, Unhealthy, status)
+}
@@ -0,0 +1,161 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+var _ Store = (*MemoryStore)(nil)
+
+// Store interface for a backend which holds the latest check results of
+// the collector instances, so that a sidecar or central dashboard can read
+// all the instances' health without scraping each one. Refer to `WithStore`.
+type Store interface {
+	// Save stores the health of given instance replacing the existing one.
+	Save(h *InstanceHealth) error
+
+	// Load returns the health of given instance, it returns nil if not found.
+	Load(instance string) (*InstanceHealth, error)
+
+	// LoadAll returns the health of all the instances keyed by instance.
+	LoadAll() (map[string]*InstanceHealth, error)
+}
+
+// InstanceHealth struct holds the check results of a collector instance
+// saved into the `Store`.
+type InstanceHealth struct {
+	Instance  string                 `json:"instance"`
+	Status    AggregateStatus        `json:"status"`
+	Results   map[string]CheckResult `json:"results"`
+	UpdatedAt time.Time              `json:"updatedAt"`
+}
+
+// Store method returns the store of the collector, default is `MemoryStore`.
+func (c *Collector) Store() Store {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.store
+}
+
+// Instance method returns the instance name of the collector in the store,
+// default is the hostname.
+func (c *Collector) Instance() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.instance
+}
+
+// saveToStore method saves the current snapshot into the collector's store,
+// failure is logged.
+func (c *Collector) saveToStore() {
+	c.mu.RLock()
+	store, instance := c.store, c.instance
+	c.mu.RUnlock()
+	state := c.load()
+	h := &InstanceHealth{
+		Instance:  instance,
+		Status:    state.status,
+		Results:   state.results,
+		UpdatedAt: c.clock.Now(),
+	}
+	if err := store.Save(h); err != nil {
+		if logger := c.log(); logger != nil {
+			logger.Errorf("health: unable to save results into store: %v", err)
+		}
+	}
+}
+
+// restoreFromStore method loads the last known results of the collector's
+// instance from its store, results older than the restore max age are
+// discarded. Restored result is published once its reporter is added.
+func (c *Collector) restoreFromStore() {
+	h, err := c.store.Load(c.instance)
+	if err != nil {
+		if c.logger != nil {
+			c.logger.Errorf("health: unable to restore results from store: %v", err)
+		}
+		return
+	}
+	if h == nil {
+		return
+	}
+	now := c.clock.Now()
+	c.restored = make(map[string]CheckResult, len(h.Results))
+	for name, result := range h.Results {
+		if c.restoreAge > 0 && now.Sub(result.LastChecked) > c.restoreAge {
+			continue
+		}
+		result.Restored = true
+		c.restored[name] = result
+	}
+}
+
+// publishRestored method publishes the restored result of given reporter
+// if any, it returns the previous and current global health. Caller must
+// hold the lock.
+func (c *Collector) publishRestored(name string) (bool, bool) {
+	result, found := c.restored[name]
+	if !found {
+		healthy := c.load().healthy
+		return healthy, healthy
+	}
+	delete(c.restored, name)
+	results := c.load().without()
+	results[name] = result
+	return c.publish(results)
+}
+
+// defaultInstance returns the hostname as default instance name.
+func defaultInstance() string {
+	if hostname, err := os.Hostname(); err == nil {
+		return hostname
+	}
+	return "localhost"
+}
+
+//‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// MemoryStore
+//______________________________________________________________________________
+
+// MemoryStore struct holds the instances' health in memory, it is the
+// default store of the collector and holds only its own instance.
+type MemoryStore struct {
+	mu        sync.RWMutex
+	instances map[string]*InstanceHealth
+}
+
+// NewMemoryStore method returns an empty `MemoryStore` instance.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{instances: make(map[string]*InstanceHealth)}
+}
+
+// Save method stores the health of the instance.
+func (s *MemoryStore) Save(h *InstanceHealth) error {
+	s.mu.Lock()
+	s.instances[h.Instance] = h
+	s.mu.Unlock()
+	return nil
+}
+
+// Load method returns the health of given instance.
+func (s *MemoryStore) Load(instance string) (*InstanceHealth, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.instances[instance], nil
+}
+
+// LoadAll method returns the health of all the instances.
+func (s *MemoryStore) LoadAll() (map[string]*InstanceHealth, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	all := make(map[string]*InstanceHealth, len(s.instances))
+	for instance, h := range s.instances {
+		all[instance] = h
+	}
+	return all, nil
+}
@@ -0,0 +1,103 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const fileStoreExt = ".health.json"
+
+var _ Store = (*FileStore)(nil)
+
+// FileStore struct holds the instances' health as JSON files in a
+// directory, one file per instance named `<instance>.health.json`. Use a
+// shared volume to make it available across the instances.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore method returns the `FileStore` instance for given directory,
+// directory is created if it does not exist.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("health: unable to create store directory: %v", err)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+// Save method writes the health of the instance into its file, file is
+// replaced atomically so that readers never see partial content.
+func (s *FileStore) Save(h *InstanceHealth) error {
+	b, err := json.Marshal(h)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(s.dir, ".health-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.filename(h.Instance))
+}
+
+// Load method reads the health of given instance from its file.
+func (s *FileStore) Load(instance string) (*InstanceHealth, error) {
+	h, err := readInstanceHealth(s.filename(instance))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return h, err
+}
+
+// LoadAll method reads the health of all the instances from the directory.
+func (s *FileStore) LoadAll() (map[string]*InstanceHealth, error) {
+	files, err := filepath.Glob(filepath.Join(s.dir, "*"+fileStoreExt))
+	if err != nil {
+		return nil, err
+	}
+	all := make(map[string]*InstanceHealth, len(files))
+	for _, f := range files {
+		h, err := readInstanceHealth(f)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue // removed meanwhile
+		}
+		if err != nil {
+			return nil, err
+		}
+		all[h.Instance] = h
+	}
+	return all, nil
+}
+
+func (s *FileStore) filename(instance string) string {
+	name := strings.NewReplacer("/", "_", string(filepath.Separator), "_").Replace(instance)
+	return filepath.Join(s.dir, name+fileStoreExt)
+}
+
+func readInstanceHealth(filename string) (*InstanceHealth, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	h := new(InstanceHealth)
+	if err = json.Unmarshal(b, h); err != nil {
+		return nil, fmt.Errorf("health: invalid store file '%s': %v", filename, err)
+	}
+	return h, nil
+}
@@ -0,0 +1,258 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var _ Store = (*RedisStore)(nil)
+
+// RedisStoreOptions struct holds the configuration of `RedisStore`.
+type RedisStoreOptions struct {
+	// Addr of the Redis server, default is `localhost:6379`.
+	Addr string
+
+	// Password and DB of the Redis server, if set.
+	Password string
+	DB       int
+
+	// KeyPrefix of the instance keys, default is `health:`.
+	KeyPrefix string
+
+	// TTL of the instance key, so that health of the terminated instance
+	// expires. Default is 5 minutes, keep it larger than the check interval.
+	TTL time.Duration
+
+	// Timeout of the Redis commands, default is 5 seconds.
+	Timeout time.Duration
+}
+
+// RedisStore struct holds the instances' health in Redis as JSON values
+// keyed by `<KeyPrefix><instance>`. It speaks the Redis protocol over
+// a single connection, which is re-established on failure.
+type RedisStore struct {
+	opts RedisStoreOptions
+	mu   sync.Mutex
+	conn net.Conn
+	rd   *bufio.Reader
+}
+
+// NewRedisStore method returns the `RedisStore` instance for given options,
+// connection is established on first use.
+func NewRedisStore(opts RedisStoreOptions) *RedisStore {
+	if opts.Addr == "" {
+		opts.Addr = "localhost:6379"
+	}
+	if opts.KeyPrefix == "" {
+		opts.KeyPrefix = "health:"
+	}
+	if opts.TTL <= 0 {
+		opts.TTL = 5 * time.Minute
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+	return &RedisStore{opts: opts}
+}
+
+// Save method stores the health of the instance with TTL.
+func (s *RedisStore) Save(h *InstanceHealth) error {
+	b, err := json.Marshal(h)
+	if err != nil {
+		return err
+	}
+	_, err = s.do("SET", s.opts.KeyPrefix+h.Instance, string(b),
+		"PX", strconv.FormatInt(s.opts.TTL.Milliseconds(), 10))
+	return err
+}
+
+// Load method returns the health of given instance.
+func (s *RedisStore) Load(instance string) (*InstanceHealth, error) {
+	v, err := s.do("GET", s.opts.KeyPrefix+instance)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	return s.decode(v)
+}
+
+// LoadAll method returns the health of all the instances, keys are
+// iterated using `SCAN`.
+func (s *RedisStore) LoadAll() (map[string]*InstanceHealth, error) {
+	all := make(map[string]*InstanceHealth)
+	cursor := "0"
+	for {
+		v, err := s.do("SCAN", cursor, "MATCH", s.opts.KeyPrefix+"*", "COUNT", "100")
+		if err != nil {
+			return nil, err
+		}
+		reply, ok := v.([]interface{})
+		if !ok || len(reply) != 2 {
+			return nil, errors.New("health: unexpected redis SCAN reply")
+		}
+		keys, _ := reply[1].([]interface{})
+		for _, k := range keys {
+			key, _ := k.(string)
+			v, err := s.do("GET", key)
+			if err != nil {
+				return nil, err
+			}
+			if v == nil {
+				continue // expired meanwhile
+			}
+			h, err := s.decode(v)
+			if err != nil {
+				return nil, err
+			}
+			all[h.Instance] = h
+		}
+		if cursor, _ = reply[0].(string); cursor == "0" || cursor == "" {
+			return all, nil
+		}
+	}
+}
+
+// Close method closes the connection to the Redis server.
+func (s *RedisStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn, s.rd = nil, nil
+	return err
+}
+
+func (s *RedisStore) decode(v interface{}) (*InstanceHealth, error) {
+	str, ok := v.(string)
+	if !ok {
+		return nil, errors.New("health: unexpected redis GET reply")
+	}
+	h := new(InstanceHealth)
+	if err := json.Unmarshal([]byte(str), h); err != nil {
+		return nil, fmt.Errorf("health: invalid redis store value: %v", err)
+	}
+	return h, nil
+}
+
+// do method sends the command and returns its reply, connection is closed
+// on failure so that next command reconnects.
+func (s *RedisStore) do(args ...string) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		if err := s.connect(); err != nil {
+			return nil, err
+		}
+	}
+	v, err := s.roundTrip(args)
+	if _, isReplyErr := err.(redisError); err != nil && !isReplyErr {
+		_ = s.conn.Close()
+		s.conn, s.rd = nil, nil
+	}
+	return v, err
+}
+
+func (s *RedisStore) connect() error {
+	conn, err := net.DialTimeout("tcp", s.opts.Addr, s.opts.Timeout)
+	if err != nil {
+		return err
+	}
+	s.conn, s.rd = conn, bufio.NewReader(conn)
+	if len(s.opts.Password) > 0 {
+		if _, err = s.roundTrip([]string{"AUTH", s.opts.Password}); err != nil {
+			return s.closeWith(err)
+		}
+	}
+	if s.opts.DB > 0 {
+		if _, err = s.roundTrip([]string{"SELECT", strconv.Itoa(s.opts.DB)}); err != nil {
+			return s.closeWith(err)
+		}
+	}
+	return nil
+}
+
+func (s *RedisStore) closeWith(err error) error {
+	_ = s.conn.Close()
+	s.conn, s.rd = nil, nil
+	return err
+}
+
+func (s *RedisStore) roundTrip(args []string) (interface{}, error) {
+	if err := s.conn.SetDeadline(time.Now().Add(s.opts.Timeout)); err != nil {
+		return nil, err
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&sb, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(s.conn, sb.String()); err != nil {
+		return nil, err
+	}
+	return readRedisReply(s.rd)
+}
+
+// redisError type represents the error reply of the Redis server.
+type redisError string
+
+func (e redisError) Error() string {
+	return "health: redis: " + string(e)
+}
+
+// readRedisReply reads a reply of Redis protocol, bulk string is returned
+// as string, nil bulk string as nil and array as []interface{}.
+func readRedisReply(rd *bufio.Reader) (interface{}, error) {
+	line, err := rd.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if len(line) == 0 {
+		return nil, errors.New("health: invalid redis reply")
+	}
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, redisError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		b := make([]byte, n+2)
+		if _, err = io.ReadFull(rd, b); err != nil {
+			return nil, err
+		}
+		return string(b[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		arr := make([]interface{}, n)
+		for i := range arr {
+			if arr[i], err = readRedisReply(rd); err != nil {
+				return nil, err
+			}
+		}
+		return arr, nil
+	default:
+		return nil, fmt.Errorf("health: invalid redis reply '%s'", line)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRedis serves the subset of Redis commands used by `RedisStore`.
+type fakeRedis struct {
+	ln   net.Listener
+	mu   sync.Mutex
+	data map[string]string
+	cmds []string
+}
+
+func newFakeRedis(t *testing.T) *fakeRedis {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	r := &fakeRedis{ln: ln, data: make(map[string]string)}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go r.serve(conn)
+		}
+	}()
+	return r
+}
+
+func (r *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	rd := bufio.NewReader(conn)
+	for {
+		v, err := readRedisReply(rd)
+		if err != nil {
+			return
+		}
+		var args []string
+		for _, a := range v.([]interface{}) {
+			args = append(args, a.(string))
+		}
+		r.mu.Lock()
+		r.cmds = append(r.cmds, strings.Join(args, " "))
+		switch args[0] {
+		case "AUTH":
+			if args[1] == "secret" {
+				fmt.Fprint(conn, "+OK\r\n")
+			} else {
+				fmt.Fprint(conn, "-WRONGPASS invalid password\r\n")
+			}
+		case "SET":
+			r.data[args[1]] = args[2]
+			fmt.Fprint(conn, "+OK\r\n")
+		case "GET":
+			if v, found := r.data[args[1]]; found {
+				fmt.Fprintf(conn, "$%d\r\n%s\r\n", len(v), v)
+			} else {
+				fmt.Fprint(conn, "$-1\r\n")
+			}
+		case "SCAN":
+			prefix := strings.TrimSuffix(args[3], "*")
+			var keys []string
+			for k := range r.data {
+				if strings.HasPrefix(k, prefix) {
+					keys = append(keys, k)
+				}
+			}
+			fmt.Fprintf(conn, "*2\r\n$1\r\n0\r\n*%d\r\n", len(keys))
+			for _, k := range keys {
+				fmt.Fprintf(conn, "$%d\r\n%s\r\n", len(k), k)
+			}
+		}
+		r.mu.Unlock()
+	}
+}
+
+func TestHealthRedisStore(t *testing.T) {
+	server := newFakeRedis(t)
+	defer server.ln.Close()
+
+	store := NewRedisStore(RedisStoreOptions{Addr: server.ln.Addr().String(), Password: "secret", TTL: time.Minute})
+	defer store.Close()
+
+	collector := NewCollector(WithStore(store), WithInstance("web-1"))
+	defer collector.Stop()
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: &toggleReporter{}, DeferInitialCheck: true})
+	collector.runChecks()
+	assert.Nil(t, store.Save(&InstanceHealth{Instance: "web-2", Status: Degraded}))
+
+	h, err := store.Load("web-1")
+	assert.Nil(t, err)
+	assert.Equal(t, Healthy, h.Status)
+	assert.True(t, h.Results["db"].IsOK())
+
+	h, err = store.Load("unknown")
+	assert.Nil(t, err)
+	assert.Nil(t, h)
+
+	all, err := store.LoadAll()
+	assert.Nil(t, err)
+	assert.Len(t, all, 2)
+	assert.Equal(t, Degraded, all["web-2"].Status)
+
+	server.mu.Lock()
+	assert.Equal(t, "AUTH secret", server.cmds[0])
+	assert.True(t, strings.HasPrefix(server.cmds[1], "SET health:web-1 {"))
+	assert.True(t, strings.HasSuffix(server.cmds[1], " PX 60000"))
+	server.mu.Unlock()
+
+	bad := NewRedisStore(RedisStoreOptions{Addr: server.ln.Addr().String(), Password: "wrong"})
+	_, err = bad.Load("web-1")
+	assert.Equal(t, "health: redis: WRONGPASS invalid password", err.Error())
+}
@@ -0,0 +1,92 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthStoreDefault(t *testing.T) {
+	collector := newCollector()
+	assert.IsType(t, &MemoryStore{}, collector.Store())
+	hostname, _ := os.Hostname()
+	assert.Equal(t, hostname, collector.Instance())
+
+	reporter := &toggleReporter{}
+	reporter.set(errors.New("down"))
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: reporter})
+	collector.runChecks()
+
+	h, err := collector.Store().Load(collector.Instance())
+	assert.Nil(t, err)
+	assert.Equal(t, Unhealthy, h.Status)
+	assert.Equal(t, StatusKO, h.Results["db"].Status)
+}
+
+func TestHealthFileStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "store")
+	store, err := NewFileStore(dir)
+	assert.Nil(t, err)
+
+	web1 := NewCollector(WithStore(store), WithInstance("web-1"))
+	defer web1.Stop()
+	web2 := NewCollector(WithStore(store), WithInstance("web/2"))
+	defer web2.Stop()
+
+	_ = web1.AddReporter(&Config{Name: "db", Reporter: &toggleReporter{}, DeferInitialCheck: true})
+	web1.runChecks()
+	web2.runChecks()
+
+	h, err := store.Load("web-1")
+	assert.Nil(t, err)
+	assert.Equal(t, Healthy, h.Status)
+	assert.True(t, h.Results["db"].IsOK())
+
+	h, err = store.Load("unknown")
+	assert.Nil(t, err)
+	assert.Nil(t, h)
+
+	all, err := store.LoadAll()
+	assert.Nil(t, err)
+	assert.Len(t, all, 2)
+	assert.Equal(t, "web/2", all["web/2"].Instance)
+
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "bad"+fileStoreExt), []byte("{"), 0o600))
+	_, err = store.LoadAll()
+	assert.Contains(t, err.Error(), "health: invalid store file")
+}
+
+func TestHealthRestore(t *testing.T) {
+	store := NewMemoryStore()
+	now := time.Now()
+	_ = store.Save(&InstanceHealth{Instance: "web-1", Results: map[string]CheckResult{
+		"db":    {Status: StatusKO, Error: "down", LastChecked: now.Add(-time.Minute)},
+		"cache": {Status: StatusKO, LastChecked: now.Add(-time.Hour)},
+	}})
+
+	collector := NewCollector(WithStore(store), WithInstance("web-1"), WithRestore(10*time.Minute))
+	defer collector.Stop()
+	assert.True(t, collector.IsHealthy())
+	assert.Empty(t, collector.Results())
+
+	db := &toggleReporter{}
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: db, DeferInitialCheck: true})
+	_ = collector.AddReporter(&Config{Name: "cache", Reporter: &toggleReporter{}, DeferInitialCheck: true})
+	assert.False(t, collector.IsHealthy())
+	result := collector.Results()["db"]
+	assert.True(t, result.Restored)
+	assert.Equal(t, "down", result.Error)
+	assert.NotContains(t, collector.Results(), "cache")
+
+	collector.runChecks()
+	assert.True(t, collector.IsHealthy())
+	assert.False(t, collector.Results()["db"].Restored)
+}
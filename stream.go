@@ -0,0 +1,307 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// Event types of the live health feed.
+const (
+	// StreamEventStatus is sent with the current status of every reporter
+	// once subscribed and then whenever a reporter's status changes.
+	StreamEventStatus = "status"
+
+	// StreamEventHeartbeat is sent periodically with the aggregate status,
+	// so that clients and proxies keep the idle connection open.
+	StreamEventHeartbeat = "heartbeat"
+
+	// StreamEventAggregate is sent instead of the reporter events for
+	// `ExposureSummary`, with the aggregate status once subscribed and then
+	// whenever it changes.
+	StreamEventAggregate = "aggregate"
+)
+
+// streamBufferSize is the number of events buffered per subscriber, slow
+// subscriber is dropped once its buffer is full.
+const streamBufferSize = 64
+
+// StreamEvent struct is the event of the live health feed, refer to
+// `RegisterOptions.EnableStream`. Result is same as the check result of
+// health check response, reporter fields are empty for heartbeat.
+type StreamEvent struct {
+	Type           string          `json:"type"`
+	Reporter       string          `json:"reporter,omitempty"`
+	PreviousStatus Status          `json:"previousStatus,omitempty"`
+	Result         *CheckResult    `json:"result,omitempty"`
+	Status         AggregateStatus `json:"status"`
+	Time           time.Time       `json:"time"`
+}
+
+// streamFilter struct narrows the events to the given reporter names or
+// reporters tagged with any of the given tags, empty filter allows all.
+type streamFilter struct {
+	reporters []string
+	tags      []string
+}
+
+// streamChange struct is the status change of a reporter to broadcast.
+type streamChange struct {
+	rc    *Config
+	event StreamEvent
+}
+
+// subscriber struct is a subscription of the live health feed.
+type subscriber struct {
+	filter streamFilter
+	events chan StreamEvent
+	done   chan struct{} // closed once unsubscribed or dropped
+}
+
+// feed struct is the live health feed of a client as per the exposure
+// level, it is common to Server-Sent Events and WebSocket.
+type feed struct {
+	c         *Collector
+	s         *subscriber
+	summary   bool
+	status    AggregateStatus // last sent aggregate status of summary
+	interval  time.Duration
+	heartbeat Timer
+}
+
+// parseStreamFilter returns the filter of query parameters `reporter` and
+// `tag`, both are comma separated.
+func parseStreamFilter(query url.Values) streamFilter {
+	return streamFilter{
+		reporters: parseTags(query.Get("reporter")),
+		tags:      parseTags(query.Get("tag")),
+	}
+}
+
+// allows method returns true if the events of given reporter pass the
+// filter.
+func (f streamFilter) allows(name string, rc *Config) bool {
+	if len(f.reporters) == 0 && len(f.tags) == 0 {
+		return true
+	}
+	for _, r := range f.reporters {
+		if r == name {
+			return true
+		}
+	}
+	if rc != nil {
+		for _, tag := range f.tags {
+			if rc.HasTag(tag) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// subscribe method adds a subscriber of the live health feed for given
+// filter, it must be unsubscribed once done.
+func (c *Collector) subscribe(filter streamFilter) *subscriber {
+	s := &subscriber{
+		filter: filter,
+		events: make(chan StreamEvent, streamBufferSize),
+		done:   make(chan struct{}),
+	}
+	c.streamMu.Lock()
+	c.subscribers[s] = struct{}{}
+	c.streamMu.Unlock()
+	return s
+}
+
+// unsubscribe method removes given subscriber of the live health feed.
+func (c *Collector) unsubscribe(s *subscriber) {
+	c.streamMu.Lock()
+	if _, found := c.subscribers[s]; found {
+		delete(c.subscribers, s)
+		close(s.done)
+	}
+	c.streamMu.Unlock()
+}
+
+// broadcast method sends the status change events to the subscribers, slow
+// subscriber is dropped instead of blocking the check run.
+func (c *Collector) broadcast(changes []streamChange) {
+	if len(changes) == 0 {
+		return
+	}
+	c.streamMu.Lock()
+	defer c.streamMu.Unlock()
+	for _, sc := range changes {
+		for s := range c.subscribers {
+			if !s.filter.allows(sc.event.Reporter, sc.rc) {
+				continue
+			}
+			select {
+			case s.events <- sc.event:
+			default:
+				delete(c.subscribers, s)
+				close(s.done)
+			}
+		}
+	}
+}
+
+// streamSnapshot method returns the current status events of the reporters
+// allowed by given filter, sorted by reporter name.
+func (c *Collector) streamSnapshot(filter streamFilter) []StreamEvent {
+	state := c.load()
+	now := c.clock.Now()
+	results := c.resultsWithStaleness(state.results)
+	events := make([]StreamEvent, 0, len(results))
+	c.mu.RLock()
+	for name, result := range results {
+		if !filter.allows(name, c.reporters[name]) {
+			continue
+		}
+		result := result
+		events = append(events, StreamEvent{
+			Type:     StreamEventStatus,
+			Reporter: name,
+			Result:   &result,
+			Status:   state.status,
+			Time:     now,
+		})
+	}
+	c.mu.RUnlock()
+	sort.Slice(events, func(i, j int) bool { return events[i].Reporter < events[j].Reporter })
+	return events
+}
+
+// heartbeatEvent method returns the heartbeat event with current aggregate
+// status.
+func (c *Collector) heartbeatEvent() StreamEvent {
+	return StreamEvent{Type: StreamEventHeartbeat, Status: c.Status(), Time: c.clock.Now()}
+}
+
+// openFeed method subscribes the live health feed of given query
+// parameters as per the register options, it must be closed once done.
+// Query parameters are ignored for `ExposureSummary`, since the reporters
+// are not exposed.
+func (c *Collector) openFeed(query url.Values, opts RegisterOptions) *feed {
+	f := &feed{c: c, summary: opts.Exposure == ExposureSummary, interval: opts.StreamHeartbeat}
+	filter := streamFilter{}
+	if !f.summary {
+		filter = parseStreamFilter(query)
+	}
+	f.s = c.subscribe(filter)
+	f.heartbeat = c.clock.NewTimer(f.interval)
+	return f
+}
+
+// close method unsubscribes the feed and stops its heartbeat.
+func (f *feed) close() {
+	f.heartbeat.Stop()
+	f.c.unsubscribe(f.s)
+}
+
+// snapshot method returns the events sent first on the feed, that is the
+// current status of the matching reporters or the aggregate status for
+// `ExposureSummary`.
+func (f *feed) snapshot() []StreamEvent {
+	if f.summary {
+		e := f.c.heartbeatEvent()
+		e.Type, f.status = StreamEventAggregate, e.Status
+		return []StreamEvent{e}
+	}
+	return f.c.streamSnapshot(f.s.filter)
+}
+
+// next method waits for the next event of the feed. It returns false once
+// the subscriber is dropped, the collector is stopped or given done channel
+// is closed. Reporter events of `ExposureSummary` are sent as aggregate
+// event only if the aggregate status changes.
+func (f *feed) next(done <-chan struct{}) (StreamEvent, bool) {
+	for {
+		select {
+		case e := <-f.s.events:
+			if !f.summary {
+				return e, true
+			}
+			if e.Status == f.status {
+				continue
+			}
+			f.status = e.Status
+			return StreamEvent{Type: StreamEventAggregate, Status: e.Status, Time: e.Time}, true
+		case <-f.heartbeat.C():
+			f.heartbeat.Reset(f.interval)
+			e := f.c.heartbeatEvent()
+			f.status = e.Status
+			return e, true
+		case <-f.s.done:
+			return StreamEvent{}, false
+		case <-done:
+			return StreamEvent{}, false
+		case <-f.c.ctx.Done():
+			return StreamEvent{}, false
+		}
+	}
+}
+
+// serveStream method streams the health events as Server-Sent Events until
+// the client disconnects or the collector is stopped. Current status of the
+// matching reporters is sent first, then their status changes and
+// heartbeats. Query parameters `reporter` and `tag` narrow the reporters.
+// Only the aggregate status is sent for `ExposureSummary`.
+func (c *Collector) serveStream(w http.ResponseWriter, r *http.Request, opts RegisterOptions) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeText(w, http.StatusInternalServerError, "streaming is not supported\n")
+		return
+	}
+	w.Header().Set("Content-Type", eventStreamContentType)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // nginx must not buffer the stream
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	f := c.openFeed(r.URL.Query(), opts)
+	defer f.close()
+
+	for _, e := range f.snapshot() {
+		if writeSSE(w, e) != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	for {
+		e, ok := f.next(r.Context().Done())
+		if !ok {
+			return
+		}
+		if writeSSE(w, e) != nil {
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// writeSSE writes the event in Server-Sent Events format, the event type is
+// the SSE event name and its JSON is the data.
+func writeSSE(w http.ResponseWriter, e StreamEvent) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, 0, len(data)+len(e.Type)+16)
+	buf = append(buf, "event: "...)
+	buf = append(buf, e.Type...)
+	buf = append(buf, "\ndata: "...)
+	buf = append(buf, data...)
+	buf = append(buf, "\n\n"...)
+	_, err = w.Write(buf)
+	return err
+}
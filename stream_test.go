@@ -0,0 +1,124 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// readSSE reads the next event of the Server-Sent Events stream.
+func readSSE(t *testing.T, r *bufio.Reader) (string, StreamEvent) {
+	var name string
+	var e StreamEvent
+	for {
+		line, err := r.ReadString('\n')
+		if !assert.NoError(t, err) {
+			return "", e
+		}
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "":
+			if name != "" {
+				return name, e
+			}
+		case strings.HasPrefix(line, "event: "):
+			name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			assert.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &e))
+		}
+	}
+}
+
+func TestHealthStream(t *testing.T) {
+	collector := newCollector()
+	defer collector.Stop()
+	db, cache := &toggleReporter{}, &toggleReporter{}
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: db, Tags: []string{"core"}})
+	_ = collector.AddReporter(&Config{Name: "cache", Reporter: cache, SoftFail: true})
+	_ = collector.AddReporter(&Config{Name: "queue", Reporter: &toggleReporter{}})
+	collector.runChecks()
+
+	w := httptest.NewRecorder()
+	collector.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthcheck/stream", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	h, _ := collector.HandlerWithOptions(RegisterOptions{EnableStream: true, StreamHeartbeat: 50 * time.Millisecond})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/healthcheck/stream?reporter=cache&tag=core")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, eventStreamContentType, resp.Header.Get("Content-Type"))
+	assert.Equal(t, "no-cache", resp.Header.Get("Cache-Control"))
+
+	// current status of the matching reporters first
+	r := bufio.NewReader(resp.Body)
+	name, e := readSSE(t, r)
+	assert.Equal(t, StreamEventStatus, name)
+	assert.Equal(t, "cache", e.Reporter)
+	assert.Equal(t, StatusOK, e.Result.Status)
+	name, e = readSSE(t, r)
+	assert.Equal(t, StreamEventStatus, name)
+	assert.Equal(t, "db", e.Reporter)
+	assert.Equal(t, Healthy, e.Status)
+
+	// reporter excluded by filter is not streamed
+	cache.set(errors.New("connection refused"))
+	collector.runChecks()
+	for {
+		name, e = readSSE(t, r)
+		if name == StreamEventStatus {
+			break
+		}
+	}
+	assert.Equal(t, "cache", e.Reporter)
+	assert.Equal(t, StatusOK, e.PreviousStatus)
+	assert.Equal(t, StatusKO, e.Result.Status)
+	assert.Equal(t, "connection refused", e.Result.Error)
+	assert.Equal(t, Degraded, e.Status)
+
+	name, e = readSSE(t, r)
+	assert.Equal(t, StreamEventHeartbeat, name)
+	assert.Empty(t, e.Reporter)
+	assert.Nil(t, e.Result)
+	assert.Equal(t, Degraded, e.Status)
+}
+
+func TestHealthStreamSlowSubscriber(t *testing.T) {
+	collector := newCollector()
+	reporter := &toggleReporter{}
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: reporter})
+	collector.runChecks()
+
+	s := collector.subscribe(streamFilter{})
+	for i := 0; i <= streamBufferSize; i++ {
+		if i%2 == 0 {
+			reporter.set(errors.New("down"))
+		} else {
+			reporter.set(nil)
+		}
+		collector.runChecks()
+	}
+	select {
+	case <-s.done:
+	default:
+		t.Error("slow subscriber is not dropped")
+	}
+	collector.unsubscribe(s)
+	assert.Empty(t, collector.subscribers)
+}
@@ -0,0 +1,74 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import "strings"
+
+// tagRoutePrefix is the sub-route of the health routes under which the tag
+// routes are registered, refer to `RegisterOptions.TagRoutes`.
+const tagRoutePrefix = "tags"
+
+// HasTag method returns true if the reporter is tagged with given tag.
+func (rc *Config) HasTag(tag string) bool {
+	for _, t := range rc.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// TaggedStatus method returns the aggregate status computed only over the
+// reporters tagged with any of the given tags, it returns `Healthy` if none
+// of the reporters is tagged.
+func (c *Collector) TaggedStatus(tags ...string) AggregateStatus {
+	return c.tagged(c.load(), tags).status
+}
+
+// tagged method returns the snapshot of given snapshot's results filtered
+// to the reporters tagged with any of the given tags, with the aggregate
+// status recomputed over them. It returns the same snapshot if no tags given.
+func (c *Collector) tagged(state *snapshot, tags []string) *snapshot {
+	if len(tags) == 0 {
+		return state
+	}
+	results := make(map[string]CheckResult)
+	c.mu.RLock()
+	for name, result := range state.results {
+		rc, found := c.reporters[name]
+		if !found {
+			continue
+		}
+		for _, tag := range tags {
+			if rc.HasTag(tag) {
+				results[name] = result
+				break
+			}
+		}
+	}
+	score := c.computeScore(results)
+	c.mu.RUnlock()
+	status := computeStatus(results)
+	return &snapshot{
+		healthy: status != Unhealthy,
+		status:  status,
+		score:   score,
+		results: results,
+		version: state.version,
+		updated: state.updated,
+	}
+}
+
+// parseTags returns the non-empty tags of given comma separated value,
+// e.g. query parameter `tag=core,db`.
+func parseTags(v string) []string {
+	var tags []string
+	for _, tag := range strings.Split(v, ",") {
+		if tag = strings.TrimSpace(tag); len(tag) > 0 {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
@@ -0,0 +1,75 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthTaggedStatus(t *testing.T) {
+	collector := newCollector()
+	db, api := &toggleReporter{}, &toggleReporter{}
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: db, Tags: []string{"core"}})
+	_ = collector.AddReporter(&Config{Name: "api", Reporter: api, Tags: []string{"external"}})
+	_ = collector.AddReporter(&Config{Name: "cache", Reporter: &toggleReporter{}})
+
+	api.set(errors.New("down"))
+	collector.runChecks()
+	assert.Equal(t, Unhealthy, collector.load().status)
+	assert.Equal(t, Healthy, collector.TaggedStatus("core"))
+	assert.Equal(t, Unhealthy, collector.TaggedStatus("external"))
+	assert.Equal(t, Unhealthy, collector.TaggedStatus("core", "external"))
+	assert.Equal(t, Healthy, collector.TaggedStatus("unknown"))
+
+	state := collector.tagged(collector.load(), []string{"core"})
+	assert.Len(t, state.results, 1)
+	assert.Contains(t, state.results, "db")
+	assert.Equal(t, []string{"core", "db"}, parseTags(" core, ,db"))
+}
+
+func TestHealthHTTPHandlerTags(t *testing.T) {
+	collector := newCollector()
+	api := &toggleReporter{}
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: &toggleReporter{}, Tags: []string{"core"}})
+	_ = collector.AddReporter(&Config{Name: "api", Reporter: api, Tags: []string{"external"}})
+	api.set(errors.New("down"))
+	collector.runChecks()
+
+	h, err := collector.HandlerWithOptions(RegisterOptions{TagRoutes: []string{"core"}})
+	assert.Nil(t, err)
+	mux := http.NewServeMux()
+	mux.Handle("/healthcheck", h)
+	mux.Handle("/healthcheck/", h)
+
+	testcases := []struct {
+		path     string
+		code     int
+		contains string
+		excludes string
+	}{
+		{path: "/healthcheck", code: http.StatusServiceUnavailable, contains: `"api"`},
+		{path: "/healthcheck?tag=core", code: http.StatusOK, contains: `"db"`, excludes: `"api"`},
+		{path: "/healthcheck?tag=external", code: http.StatusServiceUnavailable, contains: `"api"`, excludes: `"db"`},
+		{path: "/healthcheck/ready?tag=core", code: http.StatusOK, contains: `"db"`, excludes: `"api"`},
+		{path: "/healthcheck/tags/core", code: http.StatusOK, contains: `"db"`, excludes: `"api"`},
+	}
+	for _, tc := range testcases {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.path, nil))
+		assert.Equal(t, tc.code, w.Code, tc.path)
+		assert.Contains(t, w.Body.String(), tc.contains, tc.path)
+		if len(tc.excludes) > 0 {
+			assert.NotContains(t, w.Body.String(), tc.excludes, tc.path)
+		}
+	}
+
+	_, err = collector.HandlerWithOptions(RegisterOptions{TagRoutes: []string{"live"}})
+	assert.Equal(t, "health: invalid tag route 'live'", err.Error())
+}
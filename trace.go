@@ -0,0 +1,29 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import "context"
+
+// Tracer interface traces the check runs of the collector, so that time
+// spent by the reporters shows up in the tracing backend along with the
+// requests. Refer to `WithTracer` and package `healthotel` for
+// OpenTelemetry implementation.
+type Tracer interface {
+	// StartCycle starts the trace of a check run of given number of
+	// reporters, returned function ends it once all the reporters are checked.
+	StartCycle(ctx context.Context, reporters int) (context.Context, func())
+
+	// StartCheck starts the trace of given reporter's check within the check
+	// run context, returned function ends it with the check result. Context
+	// returned is passed to `ReporterContext` and `ReporterDetails` checks.
+	StartCheck(ctx context.Context, name string) (context.Context, func(result CheckResult))
+}
+
+// tracer method returns the tracer of the collector, it is nil if not set.
+func (c *Collector) tracer() Tracer {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.trace
+}
@@ -0,0 +1,77 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type traceKey struct{}
+
+// recordTracer records the traced cycles and checks, check context carries
+// the reporter name.
+type recordTracer struct {
+	mu      sync.Mutex
+	cycles  []int
+	results map[string]CheckResult
+}
+
+func (t *recordTracer) StartCycle(ctx context.Context, reporters int) (context.Context, func()) {
+	return context.WithValue(ctx, traceKey{}, "cycle"), func() {
+		t.mu.Lock()
+		t.cycles = append(t.cycles, reporters)
+		t.mu.Unlock()
+	}
+}
+
+func (t *recordTracer) StartCheck(ctx context.Context, name string) (context.Context, func(CheckResult)) {
+	parent, _ := ctx.Value(traceKey{}).(string)
+	return context.WithValue(ctx, traceKey{}, parent+"/"+name), func(result CheckResult) {
+		t.mu.Lock()
+		t.results[name] = result
+		t.mu.Unlock()
+	}
+}
+
+// traceReporter fails unless its check context is traced.
+type traceReporter struct{ want string }
+
+func (r traceReporter) Check() error {
+	return r.CheckContext(context.Background())
+}
+
+func (r traceReporter) CheckContext(ctx context.Context) error {
+	if v, _ := ctx.Value(traceKey{}).(string); v != r.want {
+		return errors.New("unexpected trace context " + v)
+	}
+	return nil
+}
+
+func TestHealthTracer(t *testing.T) {
+	tracer := &recordTracer{results: make(map[string]CheckResult)}
+	collector := newCollector()
+	WithTracer(tracer)(collector)
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: traceReporter{want: "cycle/db"}})
+	_ = collector.AddReporter(&Config{Name: "cache", Reporter: &toggleReporter{err: errors.New("down")}})
+	_ = collector.AddReporter(&Config{Name: "search", Reporter: &toggleReporter{}, DependsOn: []string{"cache"}})
+	collector.runChecks()
+
+	assert.Equal(t, []int{3}, tracer.cycles)
+	names := make([]string, 0, len(tracer.results))
+	for name := range tracer.results {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	// skipped reporter is not checked, so not traced
+	assert.Equal(t, []string{"cache", "db"}, names)
+	assert.True(t, tracer.results["db"].IsOK())
+	assert.Equal(t, "down", tracer.results["cache"].Error)
+}
@@ -0,0 +1,159 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"bytes"
+	"html/template"
+	"sort"
+	"sync/atomic"
+	"time"
+)
+
+// uiTemplate is the self-contained HTML status page, refer to
+// `RegisterOptions.EnableUI`.
+var uiTemplate = template.Must(template.New("ui").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta http-equiv="refresh" content="{{.Refresh}}">
+<title>{{.Status}} - Health{{with .Service}} - {{.}}{{end}}</title>
+<style>
+body{font-family:-apple-system,BlinkMacSystemFont,"Segoe UI",Roboto,sans-serif;margin:0;background:#f5f6f8;color:#222}
+header{padding:20px 24px;color:#fff}
+header h1{margin:0;font-size:24px;text-transform:capitalize}
+header p{margin:6px 0 0;opacity:.9;font-size:13px}
+.healthy,.OK{background:#2e9e4f}.degraded,.warning{background:#d99a00}.unhealthy,.critical{background:#d0342c}.info{background:#4a7bd0}
+main{padding:16px 24px}
+table{width:100%;border-collapse:collapse;background:#fff;box-shadow:0 1px 2px rgba(0,0,0,.1)}
+th,td{padding:8px 12px;text-align:left;border-bottom:1px solid #eee;font-size:14px;vertical-align:top}
+th{background:#fafafa;font-weight:600}
+.badge{display:inline-block;min-width:28px;padding:2px 8px;border-radius:3px;color:#fff;font-size:12px;font-weight:600;text-align:center}
+.flag{display:inline-block;margin-right:4px;padding:1px 6px;border-radius:3px;background:#e4e6ea;font-size:11px}
+.error{color:#b3261e;font-family:monospace;font-size:13px;white-space:pre-wrap;word-break:break-word}
+.muted{color:#888}
+</style>
+</head>
+<body>
+<header class="{{.Status}}">
+<h1>{{.Status}}</h1>
+<p>{{with .Service}}{{.}} {{end}}{{with .Version}}{{.}} {{end}}instance {{.Instance}} &middot; {{len .Checks}} checks &middot; generated {{.Generated}} &middot; refreshes every {{.Refresh}}s</p>
+</header>
+<main>
+<table>
+<thead><tr><th>Status</th><th>Reporter</th><th>Severity</th><th>Duration</th><th>Last checked</th><th>Details</th></tr></thead>
+<tbody>
+{{- range .Checks}}
+<tr>
+<td><span class="badge {{.Class}}">{{.Status}}</span></td>
+<td>{{.Name}}</td>
+<td>{{.Severity}}</td>
+<td>{{.Duration}}</td>
+<td>{{if .LastChecked}}<span title="{{.LastChecked}}">{{.Ago}}</span>{{else}}<span class="muted">never</span>{{end}}</td>
+<td>{{range .Flags}}<span class="flag">{{.}}</span>{{end}}{{with .Error}}<div class="error">{{.}}</div>{{end}}</td>
+</tr>
+{{- else}}
+<tr><td colspan="6" class="muted">No reporters</td></tr>
+{{- end}}
+</tbody>
+</table>
+</main>
+</body>
+</html>
+`))
+
+// uiPage struct is the data of the HTML status page.
+type uiPage struct {
+	Status    AggregateStatus
+	Service   string
+	Version   string
+	Instance  string
+	Generated string
+	Refresh   int
+	Checks    []uiCheck
+}
+
+// uiCheck struct is the reporter row of the HTML status page.
+type uiCheck struct {
+	Name        string
+	Status      Status
+	Class       string
+	Severity    Severity
+	Duration    string
+	LastChecked string
+	Ago         string
+	Error       string
+	Flags       []string
+}
+
+// statusPage method renders the HTML status page of given snapshot, failing
+// reporters are listed first. Errors are omitted for `ExposureSummary`. Page
+// refreshes itself every check interval, at least every 5 seconds.
+func (c *Collector) statusPage(state *snapshot, status AggregateStatus, opts RegisterOptions) ([]byte, error) {
+	now := c.clock.Now()
+	refresh := int(time.Duration(atomic.LoadInt64(&c.period)) / time.Second)
+	if refresh < 5 {
+		refresh = 5
+	}
+	page := &uiPage{
+		Status:    status,
+		Service:   opts.ServiceID,
+		Version:   opts.ReleaseID,
+		Instance:  c.Instance(),
+		Generated: now.Format(time.RFC3339),
+		Refresh:   refresh,
+	}
+	for name, result := range c.resultsWithStaleness(state.results) {
+		check := uiCheck{
+			Name:     name,
+			Status:   result.Status,
+			Class:    string(result.Status),
+			Severity: result.severity(),
+			Duration: result.Duration.Round(time.Microsecond).String(),
+		}
+		if !result.IsOK() {
+			check.Class = string(check.Severity)
+			if opts.Exposure != ExposureSummary {
+				check.Error = result.Error
+			}
+		}
+		if !result.LastChecked.IsZero() {
+			check.LastChecked = result.LastChecked.Format(time.RFC3339)
+			check.Ago = now.Sub(result.LastChecked).Truncate(time.Second).String() + " ago"
+		}
+		for _, f := range []struct {
+			set  bool
+			name string
+		}{
+			{result.Slow, "slow"},
+			{result.Stale, "stale"},
+			{result.Flapping, "flapping"},
+			{result.Skipped, "skipped"},
+			{result.CircuitOpen, "circuit open"},
+			{result.Maintenance, "maintenance"},
+			{result.Muted, "muted"},
+			{result.Restored, "restored"},
+		} {
+			if f.set {
+				check.Flags = append(check.Flags, f.name)
+			}
+		}
+		page.Checks = append(page.Checks, check)
+	}
+	sort.Slice(page.Checks, func(i, j int) bool {
+		a, b := page.Checks[i], page.Checks[j]
+		if (a.Status == StatusOK) != (b.Status == StatusOK) {
+			return b.Status == StatusOK
+		}
+		return a.Name < b.Name
+	})
+
+	buf := new(bytes.Buffer)
+	if err := uiTemplate.Execute(buf, page); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
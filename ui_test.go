@@ -0,0 +1,50 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthStatusPage(t *testing.T) {
+	collector := newCollector()
+	collector.SetInterval(30 * time.Second)
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: &toggleReporter{}})
+	_ = collector.AddReporter(&Config{Name: "cache", Reporter: &toggleReporter{err: errors.New("dial <tcp> refused")}, SoftFail: true})
+	collector.runChecks()
+
+	w := httptest.NewRecorder()
+	collector.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthcheck/ui", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	h, _ := collector.HandlerWithOptions(RegisterOptions{EnableUI: true, ServiceID: "orders"})
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthcheck/ui", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, htmlContentType, w.Header().Get("Content-Type"))
+	assert.Equal(t, string(Degraded), w.Header().Get(HeaderHealthStatus))
+
+	body := w.Body.String()
+	assert.Contains(t, body, `<header class="degraded">`)
+	assert.Contains(t, body, `<meta http-equiv="refresh" content="30">`)
+	assert.Contains(t, body, "<title>degraded - Health - orders</title>")
+	assert.Contains(t, body, `<span class="badge warning">KO</span>`)
+	assert.Contains(t, body, `<div class="error">dial &lt;tcp&gt; refused</div>`)
+	// failing reporters first
+	assert.True(t, strings.Index(body, "<td>cache</td>") < strings.Index(body, "<td>db</td>"))
+
+	// errors are omitted for summary exposure
+	h, _ = collector.HandlerWithOptions(RegisterOptions{EnableUI: true, Exposure: ExposureSummary})
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthcheck/ui", nil))
+	assert.NotContains(t, w.Body.String(), "refused")
+}
@@ -0,0 +1,317 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// websocketGUID is the magic value of the WebSocket handshake, RFC 6455.
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// WebSocket frame opcodes.
+const (
+	wsOpContinuation = 0x0
+	wsOpText         = 0x1
+	wsOpBinary       = 0x2
+	wsOpClose        = 0x8
+	wsOpPing         = 0x9
+	wsOpPong         = 0xA
+)
+
+// WebSocket close status codes.
+const (
+	wsCloseNormal        = 1000
+	wsCloseGoingAway     = 1001
+	wsCloseProtocolError = 1002
+	wsCloseTooBig        = 1009
+)
+
+const (
+	// wsMaxReadSize is the maximum payload size of the client frames, feed
+	// is one way so that clients send only control frames.
+	wsMaxReadSize = 4096
+
+	// wsMaxControlSize is the maximum payload size of the control frames.
+	wsMaxControlSize = 125
+
+	// wsWriteTimeout of every frame written to the client, so that stalled
+	// client does not hold the feed.
+	wsWriteTimeout = 10 * time.Second
+)
+
+var (
+	errWSFrameTooBig = errors.New("health: websocket frame is too big")
+	errWSProtocol    = errors.New("health: websocket protocol error")
+)
+
+// wsConn struct is the server side of a WebSocket connection, it supports
+// only what the health feed needs, that is writing text frames and
+// answering the control frames of the client.
+type wsConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+	mu   sync.Mutex // guards writes
+}
+
+// serveWebSocket method streams the health events over WebSocket until the
+// client disconnects or the collector is stopped. Events and query
+// parameters `reporter` and `tag` are same as the Server-Sent Events stream,
+// every event is sent as a JSON text message. Refer to `serveStream`.
+// Cross-origin handshake is allowed only for the origins of
+// `RegisterOptions.CORSAllowedOrigins`.
+func (c *Collector) serveWebSocket(w http.ResponseWriter, r *http.Request, opts RegisterOptions) {
+	if r.Method != http.MethodGet ||
+		!headerContainsToken(r.Header, "Connection", "upgrade") ||
+		!headerContainsToken(r.Header, "Upgrade", "websocket") {
+		writeText(w, http.StatusBadRequest, "websocket upgrade is expected\n")
+		return
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		writeText(w, http.StatusUpgradeRequired, "unsupported websocket version\n")
+		return
+	}
+	if !wsOriginAllowed(opts, r) {
+		writeText(w, http.StatusForbidden, "websocket origin is not allowed\n")
+		return
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if len(key) == 0 {
+		writeText(w, http.StatusBadRequest, "websocket key is missing\n")
+		return
+	}
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		writeText(w, http.StatusInternalServerError, "websocket is not supported\n")
+		return
+	}
+	conn, brw, err := hj.Hijack()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	ws := &wsConn{conn: conn, r: brw.Reader}
+	if err = ws.handshake(key); err != nil {
+		return
+	}
+
+	f := c.openFeed(r.URL.Query(), opts)
+	defer f.close()
+
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		ws.readLoop()
+	}()
+
+	for _, e := range f.snapshot() {
+		if ws.writeEvent(e) != nil {
+			return
+		}
+	}
+
+	for {
+		e, ok := f.next(closed)
+		if !ok {
+			break
+		}
+		if ws.writeEvent(e) != nil {
+			return
+		}
+	}
+	select {
+	case <-closed:
+	default:
+		code := uint16(wsCloseNormal)
+		if c.ctx.Err() != nil {
+			code = wsCloseGoingAway
+		}
+		_ = ws.writeClose(code)
+	}
+}
+
+// handshake method completes the WebSocket opening handshake of given
+// client key.
+func (ws *wsConn) handshake(key string) error {
+	h := sha1.New()
+	_, _ = h.Write([]byte(key + websocketGUID))
+	accept := base64.StdEncoding.EncodeToString(h.Sum(nil))
+	_ = ws.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	_, err := io.WriteString(ws.conn, "HTTP/1.1 101 Switching Protocols\r\n"+
+		"Upgrade: websocket\r\n"+
+		"Connection: Upgrade\r\n"+
+		"Sec-WebSocket-Accept: "+accept+"\r\n\r\n")
+	return err
+}
+
+// readLoop method reads the client frames until close frame or error, ping
+// is answered with pong and data frames are discarded. Connection is closed
+// with protocol error status for the frames violating RFC 6455.
+func (ws *wsConn) readLoop() {
+	fragmented := false // a message continues until its final frame
+	for {
+		fin, op, payload, err := ws.readFrame()
+		switch err {
+		case nil:
+		case errWSFrameTooBig:
+			_ = ws.writeClose(wsCloseTooBig)
+			return
+		case errWSProtocol:
+			_ = ws.writeClose(wsCloseProtocolError)
+			return
+		default:
+			return
+		}
+		switch op {
+		case wsOpContinuation, wsOpText, wsOpBinary:
+			if (op == wsOpContinuation) != fragmented {
+				_ = ws.writeClose(wsCloseProtocolError)
+				return
+			}
+			fragmented = !fin
+		case wsOpClose:
+			_ = ws.writeFrame(wsOpClose, payload)
+			return
+		case wsOpPing:
+			if ws.writeFrame(wsOpPong, payload) != nil {
+				return
+			}
+		case wsOpPong:
+		default:
+			_ = ws.writeClose(wsCloseProtocolError)
+			return
+		}
+	}
+}
+
+// readFrame method reads a client frame and returns its final flag, opcode
+// and the unmasked payload. Client frames must be masked, control frames
+// must be final and at most 125 bytes, reserved bits must not be set since
+// no extension is negotiated.
+func (ws *wsConn) readFrame() (bool, byte, []byte, error) {
+	var hdr [2]byte
+	if _, err := io.ReadFull(ws.r, hdr[:]); err != nil {
+		return false, 0, nil, err
+	}
+	fin, op, masked := hdr[0]&0x80 != 0, hdr[0]&0x0F, hdr[1]&0x80 != 0
+	if hdr[0]&0x70 != 0 || !masked {
+		return false, 0, nil, errWSProtocol
+	}
+	size := uint64(hdr[1] & 0x7F)
+	switch size {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(ws.r, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		size = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(ws.r, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		size = binary.BigEndian.Uint64(ext[:])
+	}
+	if op&0x08 != 0 && (!fin || size > wsMaxControlSize) {
+		return false, 0, nil, errWSProtocol
+	}
+	if size > wsMaxReadSize {
+		return false, 0, nil, errWSFrameTooBig
+	}
+	var mask [4]byte
+	if _, err := io.ReadFull(ws.r, mask[:]); err != nil {
+		return false, 0, nil, err
+	}
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(ws.r, payload); err != nil {
+		return false, 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return fin, op, payload, nil
+}
+
+// writeEvent method writes the event as JSON text message.
+func (ws *wsConn) writeEvent(e StreamEvent) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return ws.writeFrame(wsOpText, data)
+}
+
+// writeClose method writes the close frame with given status code.
+func (ws *wsConn) writeClose(code uint16) error {
+	var payload [2]byte
+	binary.BigEndian.PutUint16(payload[:], code)
+	return ws.writeFrame(wsOpClose, payload[:])
+}
+
+// writeFrame method writes an unmasked final frame of given opcode, server
+// frames must not be masked.
+func (ws *wsConn) writeFrame(op byte, payload []byte) error {
+	buf := make([]byte, 0, len(payload)+10)
+	buf = append(buf, 0x80|op)
+	switch n := len(payload); {
+	case n < 126:
+		buf = append(buf, byte(n))
+	case n <= 0xFFFF:
+		buf = append(buf, 126, byte(n>>8), byte(n))
+	default:
+		var ext [8]byte
+		binary.BigEndian.PutUint64(ext[:], uint64(n))
+		buf = append(append(buf, 127), ext[:]...)
+	}
+	buf = append(buf, payload...)
+
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	_ = ws.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	_, err := ws.conn.Write(buf)
+	return err
+}
+
+// headerContainsToken returns true if the comma separated values of given
+// header contain the token, case insensitive.
+func headerContainsToken(hdr http.Header, name, token string) bool {
+	for _, v := range hdr.Values(name) {
+		for _, t := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// wsOriginAllowed returns true if the handshake request is of same origin,
+// from non-browser client without `Origin` header or from the origin
+// allowed by `RegisterOptions.CORSAllowedOrigins`.
+func wsOriginAllowed(opts RegisterOptions, r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if len(origin) == 0 {
+		return true
+	}
+	if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	_, allowed := corsAllowedOrigin(opts, origin)
+	return allowed
+}
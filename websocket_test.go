@@ -0,0 +1,261 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package health
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// dialWebSocket opens the WebSocket connection of given health server path.
+func dialWebSocket(t *testing.T, server *httptest.Server, path string) (net.Conn, *bufio.Reader) {
+	conn, r, resp := handshakeWebSocket(t, server, path, "")
+	assert.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+	assert.Equal(t, "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=", resp.Header.Get("Sec-WebSocket-Accept"))
+	return conn, r
+}
+
+// handshakeWebSocket sends the WebSocket handshake of given health server
+// path and origin, it returns the handshake response.
+func handshakeWebSocket(t *testing.T, server *httptest.Server, path, origin string) (net.Conn, *bufio.Reader, *http.Response) {
+	conn, err := net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+	hdr := "GET " + path + " HTTP/1.1\r\n" +
+		"Host: localhost\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: keep-alive, Upgrade\r\n" +
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\n" +
+		"Sec-WebSocket-Version: 13\r\n"
+	if len(origin) > 0 {
+		hdr += "Origin: " + origin + "\r\n"
+	}
+	_, _ = conn.Write([]byte(hdr + "\r\n"))
+	r := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(r, nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return conn, r, resp
+}
+
+// readWSFrame reads the next server frame, it must be final and not masked.
+func readWSFrame(t *testing.T, r *bufio.Reader) (byte, []byte) {
+	var hdr [2]byte
+	_, err := io.ReadFull(r, hdr[:])
+	assert.NoError(t, err)
+	assert.Equal(t, byte(0x80), hdr[0]&0xF0)
+	assert.Zero(t, hdr[1]&0x80)
+	size := int(hdr[1] & 0x7F)
+	if size == 126 {
+		var ext [2]byte
+		_, _ = io.ReadFull(r, ext[:])
+		size = int(binary.BigEndian.Uint16(ext[:]))
+	}
+	payload := make([]byte, size)
+	_, err = io.ReadFull(r, payload)
+	assert.NoError(t, err)
+	return hdr[0] & 0x0F, payload
+}
+
+// writeWSFrame writes a masked final client frame.
+func writeWSFrame(conn net.Conn, op byte, payload []byte) {
+	writeWSFragment(conn, true, op, payload)
+}
+
+// writeWSFragment writes a masked client frame of given final flag.
+func writeWSFragment(conn net.Conn, fin bool, op byte, payload []byte) {
+	mask := [4]byte{1, 2, 3, 4}
+	buf := []byte{op}
+	if fin {
+		buf[0] |= 0x80
+	}
+	if n := len(payload); n < 126 {
+		buf = append(buf, 0x80|byte(n))
+	} else {
+		buf = append(buf, 0x80|126, byte(n>>8), byte(n))
+	}
+	buf = append(buf, mask[:]...)
+	for i, b := range payload {
+		buf = append(buf, b^mask[i%4])
+	}
+	_, _ = conn.Write(buf)
+}
+
+func TestHealthWebSocket(t *testing.T) {
+	collector := newCollector()
+	defer collector.Stop()
+	db, cache := &toggleReporter{}, &toggleReporter{}
+	_ = collector.AddReporter(&Config{Name: "db", Reporter: db})
+	_ = collector.AddReporter(&Config{Name: "cache", Reporter: cache, Tags: []string{"external"}})
+	collector.runChecks()
+
+	w := httptest.NewRecorder()
+	collector.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthcheck/ws", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	h, _ := collector.HandlerWithOptions(RegisterOptions{EnableWebSocket: true, StreamHeartbeat: time.Hour})
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthcheck/ws", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	server := httptest.NewServer(h)
+	defer server.Close()
+	conn, r := dialWebSocket(t, server, "/healthcheck/ws?tag=external")
+	defer conn.Close()
+
+	// current status of the matching reporters first
+	op, payload := readWSFrame(t, r)
+	assert.Equal(t, byte(wsOpText), op)
+	var e StreamEvent
+	assert.NoError(t, json.Unmarshal(payload, &e))
+	assert.Equal(t, StreamEventStatus, e.Type)
+	assert.Equal(t, "cache", e.Reporter)
+
+	// ping is answered
+	writeWSFrame(conn, wsOpPing, []byte("hi"))
+	op, payload = readWSFrame(t, r)
+	assert.Equal(t, byte(wsOpPong), op)
+	assert.Equal(t, "hi", string(payload))
+
+	db.set(errors.New("down"))
+	cache.set(errors.New("timeout"))
+	collector.runChecks()
+	op, payload = readWSFrame(t, r)
+	assert.Equal(t, byte(wsOpText), op)
+	e = StreamEvent{}
+	assert.NoError(t, json.Unmarshal(payload, &e))
+	assert.Equal(t, "cache", e.Reporter)
+	assert.Equal(t, StatusOK, e.PreviousStatus)
+	assert.Equal(t, "timeout", e.Result.Error)
+	assert.Equal(t, Unhealthy, e.Status)
+
+	// close handshake
+	writeWSFrame(conn, wsOpClose, []byte{0x03, 0xE8})
+	op, payload = readWSFrame(t, r)
+	assert.Equal(t, byte(wsOpClose), op)
+	assert.Equal(t, uint16(wsCloseNormal), binary.BigEndian.Uint16(payload))
+}
+
+func TestHealthWebSocketVersion(t *testing.T) {
+	collector := newCollector()
+	h, _ := collector.HandlerWithOptions(RegisterOptions{EnableWebSocket: true})
+	req := httptest.NewRequest(http.MethodGet, "/healthcheck/ws", nil)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "8")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUpgradeRequired, w.Code)
+	assert.Equal(t, "13", w.Header().Get("Sec-WebSocket-Version"))
+}
+
+func TestHealthWebSocketOrigin(t *testing.T) {
+	collector := newCollector()
+	defer collector.Stop()
+	h, _ := collector.HandlerWithOptions(RegisterOptions{
+		EnableWebSocket:    true,
+		StreamHeartbeat:    time.Hour,
+		CORSAllowedOrigins: []string{"https://status.example.com"},
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	testcases := []struct {
+		origin string
+		code   int
+	}{
+		{"", http.StatusSwitchingProtocols},
+		{"http://localhost", http.StatusSwitchingProtocols},
+		{"https://status.example.com", http.StatusSwitchingProtocols},
+		{"https://evil.example.com", http.StatusForbidden},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.origin, func(t *testing.T) {
+			conn, _, resp := handshakeWebSocket(t, server, "/healthcheck/ws", tc.origin)
+			defer conn.Close()
+			assert.Equal(t, tc.code, resp.StatusCode)
+		})
+	}
+}
+
+func TestHealthWebSocketProtocolError(t *testing.T) {
+	collector := newCollector()
+	defer collector.Stop()
+	h, _ := collector.HandlerWithOptions(RegisterOptions{EnableWebSocket: true, StreamHeartbeat: time.Hour})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	testcases := []struct {
+		label string
+		write func(conn net.Conn)
+	}{
+		{"unmasked frame", func(conn net.Conn) {
+			_, _ = conn.Write([]byte{0x80 | wsOpPing, 2, 'h', 'i'})
+		}},
+		{"control frame too big", func(conn net.Conn) {
+			writeWSFrame(conn, wsOpPing, make([]byte, 126))
+		}},
+		{"fragmented control frame", func(conn net.Conn) {
+			writeWSFragment(conn, false, wsOpPing, []byte("hi"))
+		}},
+		{"unexpected continuation", func(conn net.Conn) {
+			writeWSFrame(conn, wsOpContinuation, []byte("hi"))
+		}},
+		{"unfinished message", func(conn net.Conn) {
+			writeWSFragment(conn, false, wsOpText, []byte("h"))
+			writeWSFrame(conn, wsOpText, []byte("i"))
+		}},
+		{"reserved opcode", func(conn net.Conn) {
+			writeWSFrame(conn, 0x3, nil)
+		}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.label, func(t *testing.T) {
+			conn, r := dialWebSocket(t, server, "/healthcheck/ws")
+			defer conn.Close()
+			tc.write(conn)
+			op, payload := readWSFrame(t, r)
+			assert.Equal(t, byte(wsOpClose), op)
+			assert.Equal(t, uint16(wsCloseProtocolError), binary.BigEndian.Uint16(payload))
+		})
+	}
+}
+
+func TestHealthWebSocketFragments(t *testing.T) {
+	collector := newCollector()
+	defer collector.Stop()
+	h, _ := collector.HandlerWithOptions(RegisterOptions{EnableWebSocket: true, StreamHeartbeat: time.Hour})
+	server := httptest.NewServer(h)
+	defer server.Close()
+	conn, r := dialWebSocket(t, server, "/healthcheck/ws")
+	defer conn.Close()
+
+	// ping in between the fragments of a message is answered
+	writeWSFragment(conn, false, wsOpText, []byte("he"))
+	writeWSFrame(conn, wsOpPing, []byte("hi"))
+	writeWSFrame(conn, wsOpContinuation, []byte("llo"))
+	op, payload := readWSFrame(t, r)
+	assert.Equal(t, byte(wsOpPong), op)
+	assert.Equal(t, "hi", string(payload))
+
+	writeWSFrame(conn, wsOpClose, []byte{0x03, 0xE8})
+	op, payload = readWSFrame(t, r)
+	assert.Equal(t, byte(wsOpClose), op)
+	assert.Equal(t, uint16(wsCloseNormal), binary.BigEndian.Uint16(payload))
+}